@@ -2,9 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 	"trailarr/internal"
 
 	"github.com/gin-gonic/gin"
@@ -42,6 +47,9 @@ func main() {
 	if err := internal.LoadConfig(); err != nil {
 		internal.TrailarrLog(internal.WARN, "Startup", "Could not load config.yml: %v", err)
 	}
+	if err := internal.CheckPathMappingsWritable(); err != nil {
+		internal.TrailarrLog(internal.WARN, "Startup", "%v", err)
+	}
 
 	var err error
 	timings, err = internal.EnsureSyncTimingsConfig()
@@ -50,6 +58,14 @@ func main() {
 	}
 	internal.Timings = timings
 	internal.TrailarrLog(internal.INFO, "Startup", "Sync timings: %v", timings)
+	crons, err := internal.LoadSyncCronTimings()
+	if err != nil {
+		internal.TrailarrLog(internal.WARN, "Startup", "Could not load cron sync timings: %v", err)
+	}
+	internal.CronTimings = crons
+	for id, c := range crons {
+		internal.TrailarrLog(internal.INFO, "Startup", "Task %s scheduled by cron: %s", id, c)
+	}
 
 	// Load last task run times (store primary, disk fallback)
 	if _, err := internal.LoadTaskStates(); err != nil {
@@ -58,18 +74,40 @@ func main() {
 	internal.TrailarrLog(internal.DEBUG, "Startup", "Loaded GlobalTaskStates: %+v", internal.GlobalTaskStates)
 	r := gin.Default()
 	// Configure trusted proxies to avoid Gin warning about trusting all proxies.
-	if proxies, err := internal.GetTrustedProxies(); err == nil {
-		if err2 := r.SetTrustedProxies(proxies); err2 != nil {
-			internal.TrailarrLog(internal.WARN, "Startup", "Failed to set trusted proxies: %v", err2)
-		} else {
-			internal.TrailarrLog(internal.INFO, "Startup", "Set TrustedProxies: %v", strings.Join(proxies, ","))
-		}
+	proxies, err := internal.GetTrustedProxies()
+	if err != nil {
+		internal.TrailarrLog(internal.WARN, "Startup", "Ignoring trusted proxies: %v", err)
+	}
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		internal.TrailarrLog(internal.WARN, "Startup", "Failed to set trusted proxies: %v", err)
 	} else {
-		internal.TrailarrLog(internal.WARN, "Startup", "Unable to read trusted proxies config; using default loopback only: %v", err)
+		internal.TrailarrLog(internal.INFO, "Startup", "Set TrustedProxies: %v", strings.Join(proxies, ","))
 	}
 	internal.RegisterRoutes(r)
-	go internal.StartBackgroundTasks()
-	r.Run(":8080")
+
+	// Stop workers and the HTTP server on SIGINT/SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	workerDone := internal.StartDownloadQueueWorker(ctx)
+	go internal.StartBackgroundTasks(ctx)
+
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			internal.TrailarrLog(internal.ERROR, "Startup", "HTTP server error: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	internal.TrailarrLog(internal.INFO, "Shutdown", "Shutdown signal received, draining workers")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		internal.TrailarrLog(internal.WARN, "Shutdown", "HTTP server shutdown error: %v", err)
+	}
+	internal.DrainDownloadQueueWorker(workerDone)
+	internal.TrailarrLog(internal.INFO, "Shutdown", "Shutdown complete")
 }
 
 // cleanYTDLPTmpDirs removes all yt-dlp-tmp-* directories from /tmp
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authExemptPaths are reachable without credentials even when auth is
+// enabled so container probes and uptime checks keep working.
+var authExemptPaths = map[string]bool{
+	"/api/health":   true,
+	"/health/live":  true,
+	"/health/ready": true,
+}
+
+// authSettingsRefreshInterval bounds how long a hand edit of config.yml takes
+// to reach AuthMiddleware; writeConfigFile refreshes it immediately.
+var authSettingsRefreshInterval = 5 * time.Second
+
+// authSettings caches general.authEnabled and general.apiKey so
+// AuthMiddleware does not read config.yml for every request.
+type authSettings struct {
+	enabled  bool
+	apiKey   string
+	loadedAt time.Time
+}
+
+var cachedAuthSettings atomic.Pointer[authSettings]
+
+// refreshAuthSettings marks the cached auth settings stale so the next
+// request picks up a changed authEnabled or apiKey. The stale values stay
+// available as the fallback when config.yml cannot be read.
+func refreshAuthSettings() {
+	if cur := cachedAuthSettings.Load(); cur != nil {
+		cachedAuthSettings.Store(&authSettings{enabled: cur.enabled, apiKey: cur.apiKey})
+	}
+}
+
+// GetAuthSettings returns general.authEnabled and general.apiKey. When
+// config.yml cannot be read it keeps the last settings it loaded, or fails
+// closed (enabled with no key, which AuthMiddleware rejects) if there are none.
+func GetAuthSettings() (bool, string) {
+	cur := cachedAuthSettings.Load()
+	if cur != nil && time.Since(cur.loadedAt) < authSettingsRefreshInterval {
+		return cur.enabled, cur.apiKey
+	}
+	cfg, err := readConfigFile()
+	if err != nil {
+		TrailarrLog(ERROR, "Auth", "Failed to read auth settings from config: %v", err)
+		if cur == nil {
+			return true, ""
+		}
+		cachedAuthSettings.Store(&authSettings{enabled: cur.enabled, apiKey: cur.apiKey, loadedAt: time.Now()})
+		return cur.enabled, cur.apiKey
+	}
+	next := &authSettings{loadedAt: time.Now()}
+	general, _ := cfg["general"].(map[string]interface{})
+	next.enabled, _ = general["authEnabled"].(bool)
+	if next.enabled {
+		next.apiKey, _ = general["apiKey"].(string)
+	}
+	cachedAuthSettings.Store(next)
+	return next.enabled, next.apiKey
+}
+
+// requestAPIKey extracts the credential supplied by the client: the X-Api-Key
+// header, the apikey query parameter (for websockets, EventSource and <img>
+// tags, which cannot set headers) or the password of HTTP basic auth.
+func requestAPIKey(c *gin.Context) string {
+	if v := c.GetHeader(HeaderApiKey); v != "" {
+		return v
+	}
+	if v := c.Query("apikey"); v != "" {
+		return v
+	}
+	if _, pass, ok := c.Request.BasicAuth(); ok {
+		return pass
+	}
+	return ""
+}
+
+// AuthMiddleware gates every route (API, websockets and the embedded
+// frontend) behind general.apiKey when general.authEnabled is true. Health
+// endpoints are exempt. Unauthenticated requests get 401 with a basic-auth
+// challenge so browsers prompt for credentials; any username is accepted and
+// the API key is the password.
+func AuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		enabled, key := GetAuthSettings()
+		if !enabled || authExemptPaths[strings.TrimRight(c.Request.URL.Path, "/")] {
+			c.Next()
+			return
+		}
+		if key == "" {
+			TrailarrLog(ERROR, "Auth", "general.authEnabled is true but general.apiKey is empty; rejecting %s", c.Request.URL.Path)
+			respondError(c, http.StatusInternalServerError, "authentication enabled but no apiKey configured")
+			c.Abort()
+			return
+		}
+		got := requestAPIKey(c)
+		if got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(key)) != 1 {
+			c.Header("WWW-Authenticate", `Basic realm="Trailarr"`)
+			respondError(c, http.StatusUnauthorized, "unauthorized")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestAuthMiddlewareGatesRoutesExceptHealth(t *testing.T) {
+	CreateTempConfig(t)
+	r := ginDefaultRouterForTests()
+
+	// Disabled by default: API is open.
+	if w := DoRequest(r, "GET", "/api/tmdb/extratypes", nil); w.Code != 200 {
+		t.Fatalf("expected 200 with auth disabled, got %d", w.Code)
+	}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["authEnabled"] = true
+	general["apiKey"] = "secret"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	defer func() {
+		general["authEnabled"] = false
+		_ = writeConfigFile(cfg)
+	}()
+
+	if w := DoRequest(r, "GET", "/api/tmdb/extratypes", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without credentials, got %d", w.Code)
+	} else if w.Header().Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected a basic-auth challenge")
+	}
+	if w := DoRequest(r, "GET", "/", nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected frontend to be gated, got %d", w.Code)
+	}
+	for _, p := range []string{"/api/health", "/health/live"} {
+		if w := DoRequest(r, "GET", p, nil); w.Code != 200 {
+			t.Fatalf("expected %s to stay open, got %d", p, w.Code)
+		}
+	}
+
+	do := func(mod func(*http.Request)) int {
+		req := httptest.NewRequest("GET", "/api/tmdb/extratypes", nil)
+		mod(req)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "secret") }); code != 200 {
+		t.Fatalf("expected 200 with X-Api-Key, got %d", code)
+	}
+	if code := do(func(req *http.Request) { req.SetBasicAuth("admin", "secret") }); code != 200 {
+		t.Fatalf("expected 200 with basic auth, got %d", code)
+	}
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "wrong") }); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with wrong key, got %d", code)
+	}
+	if w := DoRequest(r, "GET", "/api/tmdb/extratypes?apikey=secret", nil); w.Code != 200 {
+		t.Fatalf("expected 200 with apikey query param, got %d", w.Code)
+	}
+
+	// The settings are cached: a hand edit of config.yml is not seen until
+	// the cache is refreshed.
+	oldInterval := authSettingsRefreshInterval
+	authSettingsRefreshInterval = time.Hour
+	defer func() { authSettingsRefreshInterval = oldInterval }()
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "secret") }); code != 200 {
+		t.Fatalf("expected 200 with X-Api-Key, got %d", code)
+	}
+	data, err := os.ReadFile(GetConfigPath())
+	if err != nil {
+		t.Fatalf("read config file: %v", err)
+	}
+	if err := os.WriteFile(GetConfigPath(), bytes.Replace(data, []byte("apiKey: secret"), []byte("apiKey: rotated"), 1), 0644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "secret") }); code != 200 {
+		t.Fatalf("expected the cached apiKey to still apply, got %d", code)
+	}
+	refreshAuthSettings()
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "rotated") }); code != 200 {
+		t.Fatalf("expected the edited apiKey after a refresh, got %d", code)
+	}
+
+	// An unreadable config.yml keeps the last loaded settings, or fails
+	// closed when there are none, instead of opening the API.
+	if err := os.WriteFile(GetConfigPath(), []byte("general: [unclosed"), 0644); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	refreshAuthSettings()
+	if code := do(func(req *http.Request) {}); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with an unreadable config, got %d", code)
+	}
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "rotated") }); code != 200 {
+		t.Fatalf("expected the last loaded apiKey to still apply, got %d", code)
+	}
+	cachedAuthSettings.Store(nil)
+	if code := do(func(req *http.Request) { req.Header.Set(HeaderApiKey, "rotated") }); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 with an unreadable config and nothing cached, got %d", code)
+	}
+
+	general["apiKey"] = ""
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if w := DoRequest(r, "GET", "/api/tmdb/extratypes", nil); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when auth is enabled without an apiKey, got %d", w.Code)
+	}
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// concurrencyLimiter bounds how many callers hold a slot at once. It backs
+// the TMDB extras, thumbnail, poster and yt-dlp process limits. The limit is
+// read on every acquire so config changes apply without restart.
+type concurrencyLimiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+}
+
+func newConcurrencyLimiter() *concurrencyLimiter {
+	l := &concurrencyLimiter{}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire blocks until a slot is free. A limit <= 0 means unbounded.
+func (l *concurrencyLimiter) acquire(limit int) {
+	l.mu.Lock()
+	for limit > 0 && l.active >= limit {
+		l.cond.Wait()
+	}
+	l.active++
+	l.mu.Unlock()
+}
+
+// acquireCtx is acquire that gives up with ctx's error when ctx is done
+// before a slot frees up.
+func (l *concurrencyLimiter) acquireCtx(ctx context.Context, limit int) error {
+	stop := context.AfterFunc(ctx, func() {
+		l.mu.Lock()
+		l.cond.Broadcast()
+		l.mu.Unlock()
+	})
+	defer stop()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for limit > 0 && l.active >= limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		l.cond.Wait()
+	}
+	l.active++
+	return nil
+}
+
+func (l *concurrencyLimiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
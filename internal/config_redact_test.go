@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetConfigRedactsSecrets(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["tmdbKey"] = "tmdb-0123456789"
+	cfg["radarr"] = map[string]interface{}{"url": "http://radarr:7878", "apiKey": "abcdefghijklmnop", "pathMappings": []map[string]interface{}{{"from": "/movies", "to": "/media"}}}
+	cfg["sonarr"] = map[string]interface{}{"url": "http://sonarr:8989", "apiKey": "short"}
+	cfg["plex"] = map[string]interface{}{"token": "plex-token-value", "clientId": "client"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/config", GetConfigHandler)
+	w := DoRequest(r, "GET", "/api/config", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var got map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for section, want := range map[string]map[string]interface{}{
+		"general": {"tmdbKey": "tmdb...6789", "apiKey": ""},
+		"radarr":  {"apiKey": "abcd...mnop", "url": "http://radarr:7878"},
+		"sonarr":  {"apiKey": "****"},
+		"plex":    {"token": "plex...alue", "clientId": "client"},
+	} {
+		for k, v := range want {
+			if got[section][k] != v {
+				t.Fatalf("%s.%s = %v, want %v", section, k, got[section][k], v)
+			}
+		}
+	}
+	if pm, _ := got["radarr"]["pathMappings"].([]interface{}); len(pm) != 1 {
+		t.Fatalf("expected non-secret values kept, got %v", got["radarr"])
+	}
+}
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronAndNext(t *testing.T) {
+	loc := time.UTC
+	base := time.Date(2024, 1, 10, 14, 30, 0, 0, loc) // Wednesday
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 3 * * *", time.Date(2024, 1, 11, 3, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2024, 1, 10, 14, 45, 0, 0, loc)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, loc)},
+		{"30 9 * * 1-5", time.Date(2024, 1, 11, 9, 30, 0, 0, loc)},
+		{"0 12 * * 7", time.Date(2024, 1, 14, 12, 0, 0, 0, loc)},
+		{"0,45 14 * * *", time.Date(2024, 1, 10, 14, 45, 0, 0, loc)},
+	}
+	for _, c := range cases {
+		s, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) error: %v", c.expr, err)
+		}
+		if got := s.Next(base); !got.Equal(c.want) {
+			t.Fatalf("Next(%q) = %v, want %v", c.expr, got, c.want)
+		}
+	}
+	for _, bad := range []string{"* * * *", "60 * * * *", "a * * * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := ParseCron(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestConvertTimingsSeparatesCron(t *testing.T) {
+	in := map[string]interface{}{"radarr": 15, "extras": "0 3 * * *", "bad": "0 25 * * *"}
+	ints := convertTimings(in)
+	if len(ints) != 1 || ints["radarr"] != 15 {
+		t.Fatalf("unexpected interval timings: %v", ints)
+	}
+	crons := convertCronTimings(in)
+	if len(crons) != 1 || crons["extras"] == nil || crons["extras"].String() != "0 3 * * *" {
+		t.Fatalf("unexpected cron timings: %v", crons)
+	}
+}
+
+func TestBuildSchedulesReportsCronNextExecution(t *testing.T) {
+	origTasksMeta, origTimings, origCrons := tasksMeta, Timings, CronTimings
+	defer func() { tasksMeta, Timings, CronTimings = origTasksMeta, origTimings, origCrons }()
+
+	cron, _ := ParseCron("0 3 * * *")
+	tasksMeta = map[TaskID]TaskMeta{"extras": {ID: "extras", Name: "Extras"}}
+	Timings = map[string]int{"extras": 360}
+	CronTimings = map[string]*CronSchedule{"extras": cron}
+
+	last := time.Now().Add(-time.Hour)
+	schedules := buildSchedules(TaskStates{"extras": {ID: "extras", LastExecution: last}})
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	s := schedules[0]
+	if s.Cron != "0 3 * * *" || s.Interval != 0 {
+		t.Fatalf("expected cron schedule without interval, got %+v", s)
+	}
+	if want := cron.Next(time.Now()); !s.NextExecution.Equal(want) {
+		t.Fatalf("NextExecution = %v, want %v", s.NextExecution, want)
+	}
+}
@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FlushInMemoryCaches drops every in-memory cache so subsequent reads go back
+// to the store (or upstream). It returns the names of the flushed caches.
+func FlushInMemoryCaches() []string {
+	wantedIndexMu.Lock()
+	wantedIndexMem = map[string][]map[string]interface{}{}
+	wantedIndexMu.Unlock()
+
+	rejectedIndexMu.Lock()
+	rejectedIndexMem = nil
+	rejectedIndexMu.Unlock()
+
+	latestReleaseCacheMu.Lock()
+	latestReleaseCache = map[string]latestRelease{}
+	latestReleaseCacheMu.Unlock()
+
+	invalidateToolVersions()
+	invalidateFfmpegHwaccels()
+
+	return []string{"wantedIndex", "rejectedIndex", "latestRelease", "toolVersions", "ffmpegHwaccels"}
+}
+
+// DebugEndpointsGuard rejects requests with 403 unless general.debugEndpoints
+// is enabled.
+func DebugEndpointsGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !GetDebugEndpointsEnabled() {
+			respondError(c, http.StatusForbidden, "debug endpoints are disabled (set general.debugEndpoints)")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// FlushCachesHandler handles POST /api/debug/flush-caches.
+func FlushCachesHandler(c *gin.Context) {
+	flushed := FlushInMemoryCaches()
+	TrailarrLog(INFO, "Debug", "Flushed in-memory caches: %v", flushed)
+	respondJSON(c, http.StatusOK, gin.H{"flushed": flushed})
+}
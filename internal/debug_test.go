@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestFlushCachesForcesStoreReload(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	defer client.Del(ctx, MoviesWantedStoreKey)
+	defer client.Del(ctx, RejectedExtrasStoreKey)
+
+	// Prime the in-memory caches, then change the store behind their back.
+	if err := SaveWantedIndex(MoviesWantedStoreKey, []map[string]interface{}{{"id": 1.0}}); err != nil {
+		t.Fatalf("SaveWantedIndex: %v", err)
+	}
+	storeRejectedIndexInMemory([]ExtrasEntry{{YoutubeId: "stale"}})
+	toolVersionsMu.Lock()
+	toolVersionsCache = &ToolVersions{Trailarr: "stale"}
+	toolVersionsMu.Unlock()
+	wanted, _ := json.Marshal([]map[string]interface{}{{"id": 1.0}, {"id": 2.0}})
+	rejected, _ := json.Marshal([]ExtrasEntry{{YoutubeId: "fresh"}})
+	_ = client.Set(ctx, MoviesWantedStoreKey, wanted)
+	_ = client.Set(ctx, RejectedExtrasStoreKey, rejected)
+
+	if items, _ := LoadWantedIndex(MoviesStoreKey); len(items) != 1 {
+		t.Fatalf("expected stale cached wanted index before flush, got %v", items)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/debug/flush-caches", DebugEndpointsGuard(), FlushCachesHandler)
+	if w := DoRequest(r, "POST", "/api/debug/flush-caches", nil); w.Code != 403 {
+		t.Fatalf("expected 403 while debug endpoints are disabled, got %d", w.Code)
+	}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["debugEndpoints"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if w := DoRequest(r, "POST", "/api/debug/flush-caches", nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	if items, err := LoadWantedIndex(MoviesStoreKey); err != nil || len(items) != 2 {
+		t.Fatalf("expected wanted index reloaded from store, got %v err=%v", items, err)
+	}
+	if items, err := LoadRejectedIndex(); err != nil || len(items) != 1 || items[0].YoutubeId != "fresh" {
+		t.Fatalf("expected rejected index reloaded from store, got %+v err=%v", items, err)
+	}
+	toolVersionsMu.Lock()
+	cached := toolVersionsCache
+	toolVersionsMu.Unlock()
+	if cached != nil {
+		t.Fatalf("expected tool versions dropped, got %+v", cached)
+	}
+}
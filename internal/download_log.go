@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadLog is the captured yt-dlp output of the last download attempt for
+// a YouTube ID.
+type DownloadLog struct {
+	YoutubeId string    `json:"youtubeId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Error     string    `json:"error,omitempty"`
+	Truncated bool      `json:"truncated"`
+	Log       string    `json:"log"`
+}
+
+// saveDownloadLog persists the last run's yt-dlp output for youtubeId. Only
+// the last DownloadLogMaxBytes are kept (errors are printed last) and the
+// oldest logs are evicted beyond DownloadLogMaxEntries.
+func saveDownloadLog(youtubeId string, output []byte, runErr error) {
+	entry := DownloadLog{YoutubeId: youtubeId, UpdatedAt: time.Now()}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+	if len(output) > DownloadLogMaxBytes {
+		output = output[len(output)-DownloadLogMaxBytes:]
+		entry.Truncated = true
+	}
+	entry.Log = string(output)
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	ctx := context.Background()
+	client := GetStoreClient()
+	if err := client.HSet(ctx, DownloadLogsStoreKey, youtubeId, b); err != nil {
+		TrailarrLog(WARN, "YouTube", "Failed to store download log for %s: %v", youtubeId, err)
+		return
+	}
+	evictDownloadLogs(ctx, DownloadLogMaxEntries)
+}
+
+// evictDownloadLogs removes the oldest logs so at most max remain.
+func evictDownloadLogs(ctx context.Context, max int) {
+	client := GetStoreClient()
+	vals, err := client.HVals(ctx, DownloadLogsStoreKey)
+	if err != nil || len(vals) <= max {
+		return
+	}
+	logs := make([]DownloadLog, 0, len(vals))
+	for _, v := range vals {
+		var l DownloadLog
+		if json.Unmarshal([]byte(v), &l) == nil {
+			logs = append(logs, l)
+		}
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].UpdatedAt.Before(logs[j].UpdatedAt) })
+	for _, l := range logs[:len(logs)-max] {
+		_ = client.HDel(ctx, DownloadLogsStoreKey, l.YoutubeId)
+	}
+}
+
+// GetDownloadLogHandler handles GET /api/download/:youtubeId/log.
+func GetDownloadLogHandler(c *gin.Context) {
+	youtubeId := c.Param("youtubeId")
+	val, err := GetStoreClient().HGet(c.Request.Context(), DownloadLogsStoreKey, youtubeId)
+	if errors.Is(err, ErrNotFound) {
+		respondError(c, http.StatusNotFound, "no download log for "+youtubeId)
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	var entry DownloadLog
+	if err := json.Unmarshal([]byte(val), &entry); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, entry)
+}
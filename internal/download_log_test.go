@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDownloadLogStoredTruncatedAndServed(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadLogsStoreKey)
+	defer client.Del(ctx, DownloadLogsStoreKey)
+
+	out := strings.Repeat("x", DownloadLogMaxBytes) + "ERROR: Video unavailable\n"
+	saveDownloadLog("log-yt", []byte(out), errors.New("exit status 1"))
+
+	r := NewTestRouter()
+	r.GET("/api/download/:youtubeId/log", GetDownloadLogHandler)
+	w := DoRequest(r, "GET", "/api/download/log-yt/log", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var entry DownloadLog
+	if err := json.Unmarshal(w.Body.Bytes(), &entry); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !entry.Truncated || len(entry.Log) != DownloadLogMaxBytes {
+		t.Fatalf("expected log truncated to %d bytes, got truncated=%v len=%d", DownloadLogMaxBytes, entry.Truncated, len(entry.Log))
+	}
+	if !strings.HasSuffix(entry.Log, "ERROR: Video unavailable\n") || entry.Error != "exit status 1" {
+		t.Fatalf("expected the tail of the output and the error to be kept, got error=%q", entry.Error)
+	}
+	if w := DoRequest(r, "GET", "/api/download/unknown/log", nil); w.Code != 404 {
+		t.Fatalf("expected 404 for unknown id, got %d", w.Code)
+	}
+}
+
+func TestDownloadLogEvictsOldest(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadLogsStoreKey)
+	defer client.Del(ctx, DownloadLogsStoreKey)
+
+	for i := 0; i < 5; i++ {
+		saveDownloadLog(fmt.Sprintf("evict-%d", i), []byte("ok"), nil)
+	}
+	evictDownloadLogs(ctx, 3)
+	vals, _ := client.HVals(ctx, DownloadLogsStoreKey)
+	if len(vals) != 3 {
+		t.Fatalf("expected 3 logs after eviction, got %d", len(vals))
+	}
+	for _, id := range []string{"evict-0", "evict-1"} {
+		if _, err := client.HGet(ctx, DownloadLogsStoreKey, id); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected %s to be evicted, got err=%v", id, err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestDownloadMissingQueuesEnabledMissingExtras(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const mediaId = 9371
+	SeedMovieExtraStatuses(t, mediaId, "dm")
+	if err := SetExtraTypesOverride(ctx, MediaTypeMovie, mediaId, ExtraTypesConfig{Trailers: true}); err != nil {
+		t.Fatalf("SetExtraTypesOverride: %v", err)
+	}
+	defer ClearExtraTypesOverride(ctx, MediaTypeMovie, mediaId)
+	_ = GetStoreClient().Del(ctx, DownloadQueue)
+	defer GetStoreClient().Del(ctx, DownloadQueue)
+
+	r := NewTestRouter()
+	r.POST("/api/extras/:mediaType/:id/download-missing", DownloadMissingExtrasHandler)
+	queuedCount := func() int {
+		t.Helper()
+		w := DoRequest(r, "POST", "/api/extras/movie/9371/download-missing", nil)
+		if w.Code != 200 {
+			t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+		}
+		var resp struct {
+			Queued int `json:"queued"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return resp.Queued
+	}
+	if n := queuedCount(); n != 1 {
+		t.Fatalf("expected one extra queued, got %d", n)
+	}
+	queued := map[string]bool{}
+	vals, _ := GetStoreClient().LRange(ctx, DownloadQueue, 0, -1)
+	for _, v := range vals {
+		var item DownloadQueueItem
+		if json.Unmarshal([]byte(v), &item) == nil && item.MediaId == mediaId {
+			queued[item.YouTubeID] = true
+		}
+	}
+	if len(queued) != 1 || !queued["dm-missing"] {
+		t.Fatalf("expected only the missing trailer queued, got %v", queued)
+	}
+	if n := queuedCount(); n != 0 {
+		t.Fatalf("expected nothing new queued on a second call, got %d", n)
+	}
+
+	for _, p := range []string{"/api/extras/music/1/download-missing", "/api/extras/movie/abc/download-missing"} {
+		if w := DoRequest(r, "POST", p, nil); w.Code != 400 {
+			t.Fatalf("%s: expected 400, got %d", p, w.Code)
+		}
+	}
+}
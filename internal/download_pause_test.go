@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPauseStopsPickupAndIsReportedInStatus(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer SetDownloadQueuePaused(ctx, false)
+
+	r := ginDefaultRouterForTests()
+	if w := DoRequest(r, "POST", "/api/download/pause", nil); w.Code != 200 {
+		t.Fatalf("pause: expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if !AddToDownloadQueue(DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 9451, YouTubeID: "paused-yt", Status: "queued"}, "test") {
+		t.Fatalf("expected items to be enqueued while paused")
+	}
+	if _, _, ok := NextQueuedItem(); ok {
+		t.Fatalf("expected no item to be picked up while paused")
+	}
+	if st := GetDownloadStatus("paused-yt"); st.Status != "paused" || st.QueuePosition != 1 {
+		t.Fatalf("expected a paused status with its queue position, got %+v", st)
+	}
+
+	if w := DoRequest(r, "POST", "/api/download/resume", nil); w.Code != 200 {
+		t.Fatalf("resume: expected 200, got %d body=%s", w.Code, w.Body.String())
+	} else {
+		var resp map[string]bool
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp["paused"] {
+			t.Fatalf("expected paused=false, got %s", w.Body.String())
+		}
+	}
+	if _, item, ok := NextQueuedItem(); !ok || item.YouTubeID != "paused-yt" {
+		t.Fatalf("expected the queued item after resuming, got %+v ok=%v", item, ok)
+	}
+	if st := GetDownloadStatus("paused-yt"); st.Status != "queued" {
+		t.Fatalf("expected queued after resuming, got %+v", st)
+	}
+}
+
+func TestEnqueueWantedExtrasStopsWhenPausedOrCancelled(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer SetDownloadQueuePaused(ctx, false)
+	// A queued item nobody picks up keeps the queue from draining.
+	if !AddToDownloadQueue(DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 9452, YouTubeID: "blocking-yt", Status: "queued"}, "test") {
+		t.Fatalf("expected the blocking item to be enqueued")
+	}
+	d := wantedDiscovery{mediaId: 9453, title: "Paused", usedTMDB: true, extras: []Extra{{ExtraType: "Trailers", ExtraTitle: "Trailer", YoutubeId: "waiting-yt"}}}
+	run := func(paused bool) {
+		t.Helper()
+		if err := SetDownloadQueuePaused(ctx, paused); err != nil {
+			t.Fatalf("SetDownloadQueuePaused: %v", err)
+		}
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			enqueueWantedExtras(runCtx, ExtraTypesConfig{Trailers: true}, MediaTypeMovie, d)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("enqueue did not return after cancel (paused=%v)", paused)
+		}
+		if isExtraQueuedOrDownloaded(MediaTypeMovie, d.mediaId, "waiting-yt") {
+			t.Fatalf("expected nothing enqueued (paused=%v)", paused)
+		}
+	}
+	run(true)
+	run(false)
+}
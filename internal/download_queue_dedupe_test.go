@@ -0,0 +1,48 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestAddToDownloadQueueSkipsPendingDuplicate(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer func() {
+		queueMutex.Lock()
+		delete(downloadStatusMap, "dedupe-yt")
+		queueMutex.Unlock()
+	}()
+
+	item := DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 9301, MediaTitle: "Dedupe", ExtraType: "Trailers", ExtraTitle: "Trailer", YouTubeID: "dedupe-yt"}
+	var wg sync.WaitGroup
+	results := make([]bool, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = AddToDownloadQueue(item, "api")
+		}(i)
+	}
+	wg.Wait()
+	if results[0] == results[1] {
+		t.Fatalf("expected exactly one enqueue to succeed, got %v", results)
+	}
+	if queue := loadQueueFromStore(ctx); len(queue) != 1 {
+		t.Fatalf("expected a single queue entry, got %d", len(queue))
+	}
+
+	// The same extra for another media item is not a duplicate.
+	other := item
+	other.MediaId = 9302
+	if !AddToDownloadQueue(other, "api") {
+		t.Fatalf("expected a different media item to enqueue")
+	}
+	if queue := loadQueueFromStore(ctx); len(queue) != 2 {
+		t.Fatalf("expected two queue entries, got %d", len(queue))
+	}
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSingleDownloadStatusMatchesBatchAfterRestart(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	// The queue survives a restart but the in-memory status map does not.
+	b, _ := json.Marshal(DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "restart-yt", Status: "downloaded", QueuedAt: time.Now()})
+	if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+		t.Fatalf("RPush: %v", err)
+	}
+	queueMutex.Lock()
+	delete(downloadStatusMap, "restart-yt")
+	queueMutex.Unlock()
+
+	r := NewTestRouter()
+	r.GET("/api/extras/status/:youtubeId", GetDownloadStatusHandler)
+	r.POST("/api/extras/status/batch", GetBatchDownloadStatusHandler)
+	w := DoRequest(r, "POST", "/api/extras/status/batch", []byte(`{"youtubeIds":["restart-yt","never-seen-yt"]}`))
+	var batch BatchStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("unmarshal batch: %v", err)
+	}
+	for _, id := range []string{"restart-yt", "never-seen-yt"} {
+		w := DoRequest(r, "GET", "/api/extras/status/"+id, nil)
+		if w.Code != 200 {
+			t.Fatalf("%s: expected 200, got %d", id, w.Code)
+		}
+		var single struct {
+			Status DownloadStatus `json:"status"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &single); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		if single.Status.Status != batch.Statuses[id].Status {
+			t.Fatalf("%s: single status %q disagrees with batch %q", id, single.Status.Status, batch.Statuses[id].Status)
+		}
+	}
+	if got := batch.Statuses["restart-yt"].Status; got != "downloaded" {
+		t.Fatalf("expected the queued status to survive the restart, got %q", got)
+	}
+	if got := batch.Statuses["never-seen-yt"].Status; got != "missing" {
+		t.Fatalf("expected missing for an unknown id, got %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// embyTokenHeader carries the Emby API key.
+const embyTokenHeader = "X-Emby-Token"
+
+// RefreshEmbyLibrary asks Emby to rescan mediaPath, the folder of the media
+// an extra was downloaded for. When Emby rejects the path-based update, or
+// mediaPath is empty, a full library refresh is requested instead.
+func RefreshEmbyLibrary(mediaPath string) error {
+	cfg, err := GetEmbyConfig()
+	if err != nil {
+		return err
+	}
+	if !(embyServer{cfg: cfg}).Enabled() {
+		return fmt.Errorf("emby not configured or disabled")
+	}
+	return refreshEmbyLibrary(cfg, mediaPath)
+}
+
+// refreshEmbyLibrary is RefreshEmbyLibrary with an already loaded config.
+func refreshEmbyLibrary(cfg EmbyConfig, mediaPath string) error {
+	base := trimTrailingSlash(cfg.URL)
+	if mediaPath != "" {
+		body, _ := json.Marshal(map[string]interface{}{
+			"Updates": []map[string]string{{"Path": mediaPath, "UpdateType": "Modified"}},
+		})
+		err := embyPost(base+"/Library/Media/Updated", cfg.APIKey, body)
+		if err == nil {
+			return nil
+		}
+		TrailarrLog(DEBUG, "Emby", "Path refresh of %s failed, refreshing the whole library: %v", mediaPath, err)
+	}
+	return embyPost(base+"/Library/Refresh", cfg.APIKey, nil)
+}
+
+// embyPost sends an authenticated POST to Emby and expects a 2xx answer.
+func embyPost(url, apiKey string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(embyTokenHeader, apiKey)
+	if body != nil {
+		req.Header.Set(HeaderContentType, "application/json")
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("emby returned %s for %s", resp.Status, req.URL.Path)
+	}
+	return nil
+}
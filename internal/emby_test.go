@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEmbySettingsAndLibraryRefresh(t *testing.T) {
+	CreateTempConfig(t)
+	var mu sync.Mutex
+	var calls []string
+	pathUpdates := http.StatusNoContent
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.Header.Get(embyTokenHeader)+" "+string(body))
+		mu.Unlock()
+		if r.URL.Path == "/Library/Media/Updated" {
+			w.WriteHeader(pathUpdates)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	if err := RefreshEmbyLibrary("/media/Film"); err == nil {
+		t.Fatalf("expected an error while emby is disabled")
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/settings/emby", GetEmbyConfigHandler)
+	r.POST("/api/settings/emby", SaveEmbyConfigHandler)
+	if w := DoRequest(r, "POST", "/api/settings/emby", []byte(`{"enabled":true}`)); w.Code != 400 {
+		t.Fatalf("expected 400 when enabling without a url, got %d", w.Code)
+	}
+	body := []byte(`{"url":"` + ts.URL + `/","apiKey":"secret","enabled":true}`)
+	if w := DoRequest(r, "POST", "/api/settings/emby", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	// Saving without the key keeps the stored one.
+	body = []byte(`{"url":"` + ts.URL + `","enabled":true}`)
+	if w := DoRequest(r, "POST", "/api/settings/emby", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	w := DoRequest(r, "GET", "/api/settings/emby", nil)
+	var got EmbyConfig
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.URL != ts.URL || !got.Enabled || got.APIKey != "" {
+		t.Fatalf("expected the url without the key, got %+v", got)
+	}
+	if s, err := newEmbyServer(); err != nil || !s.Enabled() {
+		t.Fatalf("expected emby to be enabled with a stored key, err=%v", err)
+	}
+
+	if err := RefreshEmbyLibrary("/media/Film"); err != nil {
+		t.Fatalf("RefreshEmbyLibrary: %v", err)
+	}
+	pathUpdates = http.StatusNotFound
+	if err := RefreshEmbyLibrary("/media/Film"); err != nil {
+		t.Fatalf("RefreshEmbyLibrary with fallback: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 emby requests, got %v", calls)
+	}
+	for i, prefix := range []string{"POST /Library/Media/Updated secret ", "POST /Library/Media/Updated secret ", "POST /Library/Refresh secret"} {
+		if !strings.HasPrefix(calls[i], prefix) {
+			t.Fatalf("call %d = %q, want prefix %q", i, calls[i], prefix)
+		}
+	}
+	if !strings.Contains(calls[0], `"Path":"/media/Film"`) {
+		t.Fatalf("expected the media path in the update, got %q", calls[0])
+	}
+}
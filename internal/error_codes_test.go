@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestErrorResponsesCarryCodes(t *testing.T) {
+	CreateTempConfig(t)
+	r := NewTestRouter()
+	r.POST(radarrSettingsPath, SaveSettingsHandler("radarr"))
+	r.POST("/api/extras/download", downloadExtraHandler)
+	r.POST("/api/extras/delete", deleteExtraHandler)
+
+	decode := func(body []byte) (string, ErrorCode) {
+		var resp struct {
+			Error string    `json:"error"`
+			Code  ErrorCode `json:"code"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("decode %s: %v", body, err)
+		}
+		return resp.Error, resp.Code
+	}
+	for _, tc := range []struct {
+		path   string
+		body   string
+		status int
+		code   ErrorCode
+	}{
+		{radarrSettingsPath, `{`, 400, CodeInvalidRequest},
+		{radarrSettingsPath, `{"providerURL":"http://radarr","pathMappings":[{"from":"^(","to":"/m","regex":true}]}`, 400, CodeInvalidPathMapping},
+		{"/api/extras/download", `{"mediaType":"movie","mediaId":1}`, 400, CodeInvalidRequest},
+		{"/api/extras/delete", `{"mediaType":"movie","mediaId":987654,"youtubeId":"x"}`, 404, CodeMediaNotFound},
+	} {
+		w := DoRequest(r, "POST", tc.path, []byte(tc.body))
+		msg, code := decode(w.Body.Bytes())
+		if w.Code != tc.status || code != tc.code || msg == "" {
+			t.Fatalf("%s %s: expected %d %s with a message, got %d %s", tc.path, tc.body, tc.status, tc.code, w.Code, w.Body.String())
+		}
+	}
+
+	mediaId := 9501
+	SeedMovieWithPath(t, mediaId)
+	w := DoRequest(r, "POST", "/api/extras/delete", []byte(`{"mediaType":"movie","mediaId":9501,"youtubeId":"unknown-yt"}`))
+	if _, code := decode(w.Body.Bytes()); w.Code != 404 || code != CodeExtraNotFound {
+		t.Fatalf("expected EXTRA_NOT_FOUND, got %d %s", w.Code, w.Body.String())
+	}
+
+	if errorCodeForStatus(502) != CodeProviderUnreachable || errorCodeForStatus(500) != CodeInternal || errorCodeForStatus(418) != CodeInvalidRequest {
+		t.Fatalf("unexpected status to code mapping")
+	}
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExcludedRootFoldersSkippedBySyncAndMerge(t *testing.T) {
+	CreateTempConfig(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/movie":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": 9201, "title": "Kept", "hasFile": true, "path": "/movies/Kept"},
+				{"id": 9202, "title": "Excluded", "hasFile": true, "path": "/movies/kids/Excluded"},
+				{"id": 9203, "title": "Sibling", "hasFile": true, "path": "/movies/kidsclub/Sibling"},
+			})
+		case "/api/v3/rootfolder":
+			_, _ = w.Write([]byte(`[{"path":"/movies"},{"path":"/movies/kids"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"url": ts.URL, "apiKey": "x", "pathMappings": []interface{}{}, "excludedRootFolders": []string{"/movies/kids/"}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	defer GetStoreClient().Del(context.Background(), MoviesStoreKey)
+
+	if err := SyncMediaType(MediaTypeMovie); err != nil {
+		t.Fatalf("SyncMediaType: %v", err)
+	}
+	items, err := LoadMediaFromStore(MoviesStoreKey)
+	if err != nil {
+		t.Fatalf("LoadMediaFromStore: %v", err)
+	}
+	got := map[string]bool{}
+	for _, m := range items {
+		got[m["title"].(string)] = true
+	}
+	if len(got) != 2 || !got["Kept"] || !got["Sibling"] {
+		t.Fatalf("expected only media outside the excluded folder, got %v", got)
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/settings/radarr", GetSettingsHandler("radarr"))
+	r.POST("/api/settings/radarr", SaveSettingsHandler("radarr"))
+	w := DoRequest(r, "GET", "/api/settings/radarr", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		PathMappings        []map[string]string `json:"pathMappings"`
+		ExcludedRootFolders []string            `json:"excludedRootFolders"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.PathMappings) != 1 || resp.PathMappings[0]["from"] != "/movies" {
+		t.Fatalf("expected only the non-excluded root folder merged, got %v", resp.PathMappings)
+	}
+	if len(resp.ExcludedRootFolders) != 1 || resp.ExcludedRootFolders[0] != "/movies/kids" {
+		t.Fatalf("expected exclusions in settings response, got %v", resp.ExcludedRootFolders)
+	}
+
+	// Saving without the field keeps the stored exclusions.
+	body, _ := json.Marshal(map[string]interface{}{"providerURL": ts.URL, "apiKey": "x", "pathMappings": []interface{}{}})
+	if w := DoRequest(r, "POST", "/api/settings/radarr", body); w.Code != 200 {
+		t.Fatalf("save: expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if ex := GetExcludedRootFolders("radarr"); len(ex) != 1 || ex[0] != "/movies/kids" {
+		t.Fatalf("expected exclusions preserved on save, got %v", ex)
+	}
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtraDetailMergesStoreAndDiskMetadata(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const mediaId = 9361
+	dir := SeedMovieWithPath(t, mediaId)
+	trailers := filepath.Join(dir, "Trailers")
+	if err := os.MkdirAll(trailers, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	file := filepath.Join(trailers, "Teaser.mkv")
+	if err := os.WriteFile(file, make([]byte, 4096), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	meta := ExtraDownloadMetadata{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Teaser", YouTubeID: "detail-disk", FileName: file, Status: "downloaded"}
+	if err := WriteJSONFile(file+".json", meta); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	rejected := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Bad", YoutubeId: "detail-rejected", Status: "rejected", Reason: "video unavailable"}
+	if err := AddOrUpdateExtra(ctx, rejected); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, rejected.YoutubeId, MediaTypeMovie, mediaId)
+
+	r := NewTestRouter()
+	r.GET("/api/extras/:mediaType/:id/summary", ExtrasSummaryHandler)
+	r.GET("/api/extras/:mediaType/:id/:youtubeId", ExtraDetailHandler)
+	get := func(path string) (int, map[string]interface{}) {
+		t.Helper()
+		w := DoRequest(r, "GET", path, nil)
+		var resp map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	code, resp := get("/api/extras/movie/9361/detail-disk")
+	if code != 200 {
+		t.Fatalf("expected 200, got %d %v", code, resp)
+	}
+	if resp["status"] != "downloaded" || resp["extraTitle"] != "Teaser" || resp["extraType"] != "Trailers" || resp["filePath"] != file || resp["fileSize"] != 4096.0 {
+		t.Fatalf("unexpected on-disk extra %v", resp)
+	}
+
+	code, resp = get("/api/extras/movie/9361/detail-rejected")
+	if code != 200 || resp["status"] != "rejected" || resp["reason"] != "video unavailable" {
+		t.Fatalf("expected the rejected extra with its reason, got %d %v", code, resp)
+	}
+	if _, ok := resp["filePath"]; ok {
+		t.Fatalf("expected no file for a rejected extra, got %v", resp)
+	}
+
+	if code, _ := get("/api/extras/movie/9361/unknown-video"); code != 404 {
+		t.Fatalf("expected 404 for an unknown video, got %d", code)
+	}
+	if code, _ := get("/api/extras/movie/9361/summary"); code != 200 {
+		t.Fatalf("expected the summary route to keep working, got %d", code)
+	}
+	if code, _ := get("/api/extras/music/1/x"); code != 400 {
+		t.Fatalf("expected 400 for an invalid mediaType, got %d", code)
+	}
+}
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtraFilenameTemplateUsedForDownloadDetectionAndDelete(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["extraFilenameTemplate"] = "{title} ({type}) [{youtubeId}]"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	const movieId = 9551
+	mediaPath := SeedMovieWithPath(t, movieId)
+
+	info, err := prepareDownloadInfo(MediaTypeMovie, movieId, "Trailers", "Teaser: Part 1", "tmpl-yt", 0)
+	if err != nil {
+		t.Fatalf("prepareDownloadInfo: %v", err)
+	}
+	defer os.RemoveAll(info.TempDir)
+	want := filepath.Join(mediaPath, "Trailers", "Teaser_ Part 1 (Trailers) [tmpl-yt].mkv")
+	if info.OutFile != want {
+		t.Fatalf("expected %s, got %s", want, info.OutFile)
+	}
+	if err := os.MkdirAll(filepath.Dir(want), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(want, []byte("x"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	extras := []Extra{{ExtraType: "Trailers", ExtraTitle: "Teaser: Part 1", YoutubeId: "tmpl-yt"}}
+	MarkDownloadedExtras(extras, mediaPath, "type", "title")
+	if extras[0].Status != "downloaded" {
+		t.Fatalf("expected the templated file to be detected, got %q", extras[0].Status)
+	}
+	if err := deleteExtraFiles(mediaPath, "Trailers", "Teaser: Part 1", "tmpl-yt"); err != nil {
+		t.Fatalf("deleteExtraFiles: %v", err)
+	}
+	if _, err := os.Stat(want); !os.IsNotExist(err) {
+		t.Fatalf("expected the templated file deleted, stat err=%v", err)
+	}
+
+	cfg["general"].(map[string]interface{})["extraFilenameTemplate"] = "{type} {season}"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if got := GetExtraFilenameTemplate(); got != DefaultExtraFilenameTemplate {
+		t.Fatalf("expected an invalid template to fall back to the default, got %q", got)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/general", saveGeneralSettingsHandler)
+	if w := DoRequest(r, "POST", "/api/settings/general", []byte(`{"extraFilenameTemplate":"{type}"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 for a template without {title} or {youtubeId}, got %d body=%s", w.Code, w.Body.String())
+	}
+	if w := DoRequest(r, "POST", "/api/settings/general", []byte(`{"extraFilenameTemplate":"{title} [{youtubeId}]"}`)); w.Code != 200 {
+		t.Fatalf("expected a valid template saved, got %d body=%s", w.Code, w.Body.String())
+	}
+	if got := GetExtraFilenameTemplate(); got != "{title} [{youtubeId}]" {
+		t.Fatalf("expected the saved template, got %q", got)
+	}
+	if w := DoRequest(r, "POST", "/api/settings/general", []byte(`{"extraFilenameTemplate":""}`)); w.Code != 200 {
+		t.Fatalf("expected an empty template to reset to the default, got %d body=%s", w.Code, w.Body.String())
+	}
+	if got := GetExtraFilenameTemplate(); got != DefaultExtraFilenameTemplate {
+		t.Fatalf("expected the default template after a reset, got %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveExtraThumbnailReusesProxyCache(t *testing.T) {
+	CreateTempConfig(t)
+	oldCoverPath := MediaCoverPath
+	MediaCoverPath = t.TempDir()
+	defer func() { MediaCoverPath = oldCoverPath }()
+	cacheDir := filepath.Join(MediaCoverPath, "YouTube")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	const ytID = "thumb-reuse-yt"
+	cached := filepath.Join(cacheDir, ytID+".jpg")
+	if err := os.WriteFile(cached, []byte("cached-thumb"), 0o644); err != nil {
+		t.Fatalf("write cached thumb: %v", err)
+	}
+
+	mediaPath := t.TempDir()
+	extraDir := filepath.Join(mediaPath, "Trailers")
+	if err := os.MkdirAll(extraDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	extraFile := filepath.Join(extraDir, "Official Trailer [abc].mkv")
+	if err := os.WriteFile(extraFile, []byte("video"), 0o644); err != nil {
+		t.Fatalf("write extra: %v", err)
+	}
+
+	thumb, err := SaveExtraThumbnail(ytID, extraFile)
+	if err != nil {
+		t.Fatalf("SaveExtraThumbnail: %v", err)
+	}
+	if want := filepath.Join(extraDir, "Official Trailer [abc]-thumb.jpg"); thumb != want {
+		t.Fatalf("expected thumbnail at %s, got %s", want, thumb)
+	}
+	if b, err := os.ReadFile(thumb); err != nil || string(b) != "cached-thumb" {
+		t.Fatalf("expected cached thumbnail copied, got %q err=%v", b, err)
+	}
+
+	if err := deleteExtraFiles(mediaPath, "Trailers", "Official Trailer [abc]", ""); err != nil {
+		t.Fatalf("deleteExtraFiles: %v", err)
+	}
+	if _, err := os.Stat(thumb); !os.IsNotExist(err) {
+		t.Fatalf("expected thumbnail removed with the extra, stat err=%v", err)
+	}
+}
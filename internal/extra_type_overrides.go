@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Per-media extra type overrides replace the global extraTypes settings for
+// one movie or series, e.g. to fetch featurettes for a single title while
+// they stay off everywhere else.
+
+// mediaStoreField is the "<mediaType>:<id>" field of per-media state kept in
+// a store hash.
+func mediaStoreField(mediaType MediaType, mediaId int) string {
+	return fmt.Sprintf("%s:%d", mediaType, mediaId)
+}
+
+// GetExtraTypesOverride returns the override of a media item, or nil when it
+// uses the global settings.
+func GetExtraTypesOverride(ctx context.Context, mediaType MediaType, mediaId int) (*ExtraTypesConfig, error) {
+	v, err := GetStoreClient().HGet(ctx, ExtraTypeOverridesStoreKey, mediaStoreField(mediaType, mediaId))
+	if err == ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var cfg ExtraTypesConfig
+	if err := json.Unmarshal([]byte(v), &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SetExtraTypesOverride stores the override of a media item.
+func SetExtraTypesOverride(ctx context.Context, mediaType MediaType, mediaId int, cfg ExtraTypesConfig) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return GetStoreClient().HSet(ctx, ExtraTypeOverridesStoreKey, mediaStoreField(mediaType, mediaId), data)
+}
+
+// ClearExtraTypesOverride returns a media item to the global settings.
+// Missing overrides are not an error.
+func ClearExtraTypesOverride(ctx context.Context, mediaType MediaType, mediaId int) error {
+	err := GetStoreClient().HDel(ctx, ExtraTypeOverridesStoreKey, mediaStoreField(mediaType, mediaId))
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// extraTypesConfigForMedia returns the extra types enabled for a media item:
+// its override when one is stored, otherwise global.
+func extraTypesConfigForMedia(mediaType MediaType, mediaId int, global ExtraTypesConfig) ExtraTypesConfig {
+	override, err := GetExtraTypesOverride(context.Background(), mediaType, mediaId)
+	if err != nil {
+		TrailarrLog(WARN, "Extras", "Failed to load extra types override for %s %d, using global settings: %v", mediaType, mediaId, err)
+		return global
+	}
+	if override == nil {
+		return global
+	}
+	return *override
+}
+
+// GetExtraTypesOverrideHandler handles GET /api/extras/:mediaType/:id/types.
+// override is null when the media uses the global settings.
+func GetExtraTypesOverrideHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	override, err := GetExtraTypesOverride(c.Request.Context(), mediaType, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	effective, err := GetExtraTypesConfig()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if override != nil {
+		effective = *override
+	}
+	respondJSON(c, http.StatusOK, gin.H{"override": override, "effective": effective})
+}
+
+// SetExtraTypesOverrideHandler handles PUT /api/extras/:mediaType/:id/types
+// with the extra types to enable for that media.
+func SetExtraTypesOverrideHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	var req ExtraTypesConfig
+	if err := c.BindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		return
+	}
+	if err := SetExtraTypesOverride(c.Request.Context(), mediaType, id, req); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	TrailarrLog(INFO, "Extras", "Set extra types override for %s %d: %v", mediaType, id, GetEnabledCanonicalExtraTypes(req))
+	if err := refreshWantedForMedia(mediaType, id); err != nil {
+		TrailarrLog(WARN, "Extras", "Failed to update the wanted status of %s %d: %v", mediaType, id, err)
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "saved", "override": req})
+}
+
+// ClearExtraTypesOverrideHandler handles DELETE /api/extras/:mediaType/:id/types.
+func ClearExtraTypesOverrideHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	if err := ClearExtraTypesOverride(c.Request.Context(), mediaType, id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := refreshWantedForMedia(mediaType, id); err != nil {
+		TrailarrLog(WARN, "Extras", "Failed to update the wanted status of %s %d: %v", mediaType, id, err)
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "cleared"})
+}
+
+// refreshWantedForMedia re-evaluates whether a media item still wants extras
+// after its extra types changed, and updates its wanted flag in the media
+// store and its entry in the wanted index. The extras task only visits wanted
+// items, so without this a new override would wait for the next sync.
+func refreshWantedForMedia(mediaType MediaType, mediaId int) error {
+	cacheFile, err := resolveCachePath(mediaType)
+	if err != nil {
+		return err
+	}
+	mediaStoreWriteMu.Lock()
+	defer mediaStoreWriteMu.Unlock()
+	items, err := LoadMediaFromStore(cacheFile)
+	if err != nil {
+		return err
+	}
+	var item map[string]interface{}
+	for _, it := range items {
+		if id, ok := parseMediaID(it["id"]); ok && id == mediaId {
+			item = it
+			break
+		}
+	}
+	if item == nil {
+		return nil
+	}
+	global, _ := GetExtraTypesConfig()
+	wanted := !HasAnyEnabledExtras(mediaType, mediaId, GetEnabledCanonicalExtraTypes(global))
+	if GetOnlyMonitored() && !isMediaMonitored(item) {
+		wanted = false
+	}
+	item["wanted"] = wanted
+	// SaveMediaToStore drops the wanted index, so read it first. Without one
+	// the extras task reads the wanted flags instead.
+	index, indexErr := LoadWantedIndex(cacheFile)
+	if err := SaveMediaToStore(cacheFile, items); err != nil {
+		return err
+	}
+	if indexErr != nil {
+		return nil
+	}
+	index = Filter(index, func(m map[string]interface{}) bool {
+		id, ok := parseMediaID(m["id"])
+		return !ok || id != mediaId
+	})
+	if wanted {
+		index = append(index, buildLightItem(item))
+	}
+	return SaveWantedIndex(cacheFile, index)
+}
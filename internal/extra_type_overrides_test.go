@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestExtraTypesOverrideReplacesGlobalTypesForOneMedia(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const mediaId = 9911
+	defer ClearExtraTypesOverride(ctx, MediaTypeMovie, mediaId)
+
+	r := NewTestRouter()
+	r.GET("/api/extras/:mediaType/:id/types", GetExtraTypesOverrideHandler)
+	r.PUT("/api/extras/:mediaType/:id/types", SetExtraTypesOverrideHandler)
+	r.DELETE("/api/extras/:mediaType/:id/types", ClearExtraTypesOverrideHandler)
+	getOverride := func() *ExtraTypesConfig {
+		t.Helper()
+		w := DoRequest(r, "GET", "/api/extras/movie/9911/types", nil)
+		var resp struct {
+			Override *ExtraTypesConfig `json:"override"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v body=%s", err, w.Body.String())
+		}
+		return resp.Override
+	}
+	if o := getOverride(); o != nil {
+		t.Fatalf("expected no override, got %+v", o)
+	}
+	body, _ := json.Marshal(ExtraTypesConfig{Featurettes: true})
+	if w := DoRequest(r, "PUT", "/api/extras/movie/9911/types", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if o := getOverride(); o == nil || *o != (ExtraTypesConfig{Featurettes: true}) {
+		t.Fatalf("expected the saved override, got %+v", o)
+	}
+
+	_ = GetStoreClient().Del(ctx, DownloadQueue)
+	defer GetStoreClient().Del(ctx, DownloadQueue)
+	filterAndDownloadExtras(MediaTypeMovie, mediaId, []Extra{
+		{ExtraType: "Trailers", ExtraTitle: "Trailer", YoutubeId: "ovr-trailer", Status: "missing"},
+		{ExtraType: "Featurettes", ExtraTitle: "Making Of", YoutubeId: "ovr-feat", Status: "missing"},
+	}, ExtraTypesConfig{Trailers: true})
+	queued := map[string]bool{}
+	vals, _ := GetStoreClient().LRange(ctx, DownloadQueue, 0, -1)
+	for _, v := range vals {
+		var item DownloadQueueItem
+		if json.Unmarshal([]byte(v), &item) == nil && item.MediaId == mediaId {
+			queued[item.YouTubeID] = true
+		}
+	}
+	if !queued["ovr-feat"] || queued["ovr-trailer"] {
+		t.Fatalf("expected only the featurette queued, got %v", queued)
+	}
+
+	entry := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Featurettes", ExtraTitle: "Making Of", YoutubeId: "ovr-feat", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, entry); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, entry.YoutubeId, MediaTypeMovie, mediaId)
+	if !HasAnyEnabledExtras(MediaTypeMovie, mediaId, []string{"Trailers"}) {
+		t.Fatalf("expected the override's featurette to count as the enabled extras")
+	}
+
+	if w := DoRequest(r, "DELETE", "/api/extras/movie/9911/types", nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if o := getOverride(); o != nil {
+		t.Fatalf("expected the override cleared, got %+v", o)
+	}
+	if HasAnyEnabledExtras(MediaTypeMovie, mediaId, []string{"Trailers"}) {
+		t.Fatalf("expected the global types to apply again")
+	}
+	if w := DoRequest(r, "PUT", "/api/extras/music/1/types", body); w.Code != 400 {
+		t.Fatalf("expected 400 for an invalid mediaType, got %d", w.Code)
+	}
+}
+
+func TestExtraTypesOverrideUpdatesWantedIndex(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const mediaId = 9912
+	SeedMovieWithPath(t, mediaId)
+	defer GetStoreClient().Del(ctx, MoviesStoreKey)
+	defer ClearExtraTypesOverride(ctx, MediaTypeMovie, mediaId)
+	trailer := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Trailer", YoutubeId: "ovr-wanted-trailer", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, trailer); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, trailer.YoutubeId, MediaTypeMovie, mediaId)
+	if err := SaveWantedIndex(MoviesStoreKey, []map[string]interface{}{}); err != nil {
+		t.Fatalf("SaveWantedIndex: %v", err)
+	}
+	defer SaveWantedIndex(MoviesStoreKey, []map[string]interface{}{})
+	wanted := func() (bool, bool) {
+		t.Helper()
+		inIndex := false
+		index, _ := LoadWantedIndex(MoviesStoreKey)
+		for _, it := range index {
+			if id, _ := parseMediaID(it["id"]); id == mediaId {
+				inIndex = true
+			}
+		}
+		items, _ := LoadMediaFromStore(MoviesStoreKey)
+		for _, it := range items {
+			if id, _ := parseMediaID(it["id"]); id == mediaId {
+				return isMediaWanted(it), inIndex
+			}
+		}
+		t.Fatalf("seeded movie missing from the store")
+		return false, false
+	}
+
+	r := NewTestRouter()
+	r.PUT("/api/extras/:mediaType/:id/types", SetExtraTypesOverrideHandler)
+	r.DELETE("/api/extras/:mediaType/:id/types", ClearExtraTypesOverrideHandler)
+	body, _ := json.Marshal(ExtraTypesConfig{Featurettes: true})
+	if w := DoRequest(r, "PUT", "/api/extras/movie/9912/types", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if flag, inIndex := wanted(); !flag || !inIndex {
+		t.Fatalf("expected the movie wanted for its featurettes, got wanted=%v inIndex=%v", flag, inIndex)
+	}
+	if w := DoRequest(r, "DELETE", "/api/extras/movie/9912/types", nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if flag, inIndex := wanted(); flag || inIndex {
+		t.Fatalf("expected the downloaded trailer to satisfy the global types again, got wanted=%v inIndex=%v", flag, inIndex)
+	}
+}
@@ -3,11 +3,13 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -118,6 +120,18 @@ func SanitizeFilename(name string) string {
 	return name
 }
 
+// extraFileBaseName renders general.extraFilenameTemplate for an extra and
+// sanitizes the result. It is the file name, without extension, an extra is
+// downloaded to and looked up or deleted by.
+func extraFileBaseName(extraType, extraTitle, youtubeID string) string {
+	name := strings.NewReplacer(
+		"{title}", extraTitle,
+		"{type}", canonicalizeExtraType(extraType),
+		"{youtubeId}", youtubeID,
+	).Replace(GetExtraFilenameTemplate())
+	return SanitizeFilename(name)
+}
+
 // ExtrasEntry is the flat structure for each extra in the new collection
 type ExtrasEntry struct {
 	MediaType  MediaType `json:"mediaType"`
@@ -129,6 +143,8 @@ type ExtrasEntry struct {
 	YoutubeId  string    `json:"youtubeId"`
 	Status     string    `json:"status"`
 	Reason     string    `json:"reason,omitempty"`
+	// SeasonNumber is set for season-level TV extras; 0 means the whole series.
+	SeasonNumber int `json:"seasonNumber,omitempty"`
 }
 
 // Note: JSON marshalling uses the `reason` json tag; do not duplicate keys.
@@ -268,6 +284,12 @@ type Extra struct {
 	YoutubeId  string
 	Status     string
 	Reason     string
+	// Official mirrors TMDB's official flag; always false for non-TMDB extras.
+	Official bool
+	// Pinned marks the video the user pinned for this extra type.
+	Pinned bool
+	// SeasonNumber is set for season-level TV extras; 0 means the whole series.
+	SeasonNumber int
 }
 
 // GetRejectedExtrasForMedia returns rejected extras for a given media type and id, using the store cache
@@ -336,18 +358,32 @@ func SetExtraRejectedPersistent(mediaType MediaType, mediaId int, extraType, ext
 	return nil
 }
 
-// UnmarkExtraRejected clears the Status of an extra if it is "rejected" in the store, but keeps the extra in the array
+// UnmarkExtraRejected clears the Status of an extra if it is "rejected" in the store, but keeps the extra in the array.
+// The entry is kept with status "missing" so the next extras run re-enqueues it, and the
+// rejected index is rebuilt synchronously so callers never observe the stale rejection.
 func UnmarkExtraRejected(mediaType MediaType, mediaId int, extraType, extraTitle, youtubeId string) error {
 	ctx := context.Background()
-	if err := RemoveExtra(ctx, youtubeId, mediaType, mediaId); err != nil {
+	entry, err := GetExtraByYoutubeId(ctx, youtubeId, mediaType, mediaId)
+	if err != nil {
 		return err
 	}
-	// Update rejected-index async
-	go func() {
-		if err := SaveRejectedIndex(); err != nil {
-			TrailarrLog(WARN, "UnmarkExtraRejected", rejectedIndexSaveErrFmt, err)
-		}
-	}()
+	if entry == nil || entry.Status != "rejected" {
+		return nil
+	}
+	entry.Status = "missing"
+	entry.Reason = ""
+	if entry.ExtraType == "" {
+		entry.ExtraType = extraType
+	}
+	if entry.ExtraTitle == "" {
+		entry.ExtraTitle = extraTitle
+	}
+	if err := AddOrUpdateExtra(ctx, *entry); err != nil {
+		return err
+	}
+	if err := SaveRejectedIndex(); err != nil {
+		TrailarrLog(WARN, "UnmarkExtraRejected", rejectedIndexSaveErrFmt, err)
+	}
 	return nil
 }
 
@@ -397,42 +433,96 @@ func MarkExtraDeleted(mediaType MediaType, mediaId int, extraType, extraTitle, y
 	return nil
 }
 
+// Errors returned by deleteExtra when the media or the extra is unknown.
+var (
+	errDeleteMediaNotFound = errors.New("Media not found")
+	errDeleteExtraNotFound = errors.New("Extra not found in collection")
+)
+
+// deleteExtraRequest identifies an extra to delete.
+type deleteExtraRequest struct {
+	MediaType MediaType `json:"mediaType"`
+	MediaId   int       `json:"mediaId"`
+	YoutubeId string    `json:"youtubeId"`
+}
+
 // Handler to delete an extra and record history
 func deleteExtraHandler(c *gin.Context) {
-	var req struct {
-		MediaType MediaType `json:"mediaType"`
-		MediaId   int       `json:"mediaId"`
-		YoutubeId string    `json:"youtubeId"`
+	var req deleteExtraRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, ErrInvalidRequest)
+		return
 	}
+	if err := deleteExtra(context.Background(), req); err != nil {
+		code := CodeExtraNotFound
+		if errors.Is(err, errDeleteMediaNotFound) {
+			code = CodeMediaNotFound
+		}
+		respondErrorCode(c, http.StatusNotFound, code, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "deleted"})
+}
+
+// deleteExtraBatchResult is the outcome of one item of a batch delete.
+type deleteExtraBatchResult struct {
+	deleteExtraRequest
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// deleteExtraBatchHandler deletes every listed extra like deleteExtraHandler
+// and reports a result per item; a failing item does not stop the rest.
+func deleteExtraBatchHandler(c *gin.Context) {
+	var req []deleteExtraRequest
 	if err := c.BindJSON(&req); err != nil {
 		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
 		return
 	}
+	ctx := context.Background()
+	results := make([]deleteExtraBatchResult, 0, len(req))
+	deleted := 0
+	for _, item := range req {
+		res := deleteExtraBatchResult{deleteExtraRequest: item, Status: "deleted"}
+		if err := deleteExtra(ctx, item); err != nil {
+			res.Status = "failed"
+			res.Error = err.Error()
+		} else {
+			deleted++
+		}
+		results = append(results, res)
+	}
+	TrailarrLog(INFO, "Extras", "Batch delete: %d of %d extras deleted", deleted, len(req))
+	respondJSON(c, http.StatusOK, gin.H{"results": results, "deleted": deleted, "failed": len(req) - deleted})
+}
 
+// deleteExtra removes an extra's files, its store entry and records the
+// delete in the history. Missing files, store and history failures are
+// logged; only an unknown media or extra is returned as an error.
+func deleteExtra(ctx context.Context, req deleteExtraRequest) error {
 	cacheFile, _ := resolveCachePath(req.MediaType)
 	mediaPath, err := FindMediaPathByID(cacheFile, req.MediaId)
 	if err != nil || mediaPath == "" {
-		respondError(c, http.StatusNotFound, "Media not found")
-		return
+		return errDeleteMediaNotFound
 	}
 
 	// Find the extra's extraType and extraTitle by YoutubeId from the unified collection
-	ctx := context.Background()
 	entry, err := GetExtraByYoutubeId(ctx, req.YoutubeId, req.MediaType, req.MediaId)
 	if err != nil || entry == nil {
-		respondError(c, http.StatusNotFound, "Extra not found in collection")
-		return
+		return errDeleteExtraNotFound
 	}
 	// Try to delete files, but do not fail if missing
-	_ = deleteExtraFiles(mediaPath, entry.ExtraType, entry.ExtraTitle)
+	_ = deleteExtraFiles(mediaPath, entry.ExtraType, entry.ExtraTitle, req.YoutubeId)
 
 	// Remove from the unified collection in the store
 	if err := RemoveExtra(ctx, req.YoutubeId, req.MediaType, req.MediaId); err != nil {
 		TrailarrLog(WARN, "Extras", "Failed to remove extra from store: %v", err)
 	}
 
-	recordDeleteHistory(req.MediaType, req.MediaId, entry.ExtraType, entry.ExtraTitle)
-	respondJSON(c, http.StatusOK, gin.H{"status": "deleted"})
+	if err := recordDeleteHistory(req.MediaType, req.MediaId, entry.ExtraType, entry.ExtraTitle); err != nil {
+		TrailarrLog(WARN, "Extras", "Delete history for %s: %v", req.YoutubeId, err)
+	}
+	return nil
 }
 
 func resolveCachePath(mediaType MediaType) (string, error) {
@@ -462,23 +552,39 @@ func lookupMediaTitle(cacheFile string, mediaId int) string {
 	return ""
 }
 
-func deleteExtraFiles(mediaPath, extraType, extraTitle string) error {
+func deleteExtraFiles(mediaPath, extraType, extraTitle, youtubeID string) error {
 	extraDir := mediaPath + "/" + extraType
-	extraFile := extraDir + "/" + SanitizeFilename(extraTitle) + ".mkv"
-	metaFile := extraDir + "/" + SanitizeFilename(extraTitle) + mkvJSONSuffix
+	base := extraFileBaseName(extraType, extraTitle, youtubeID)
+	extraFile := extraDir + "/" + base + ".mkv"
+	metaFile := extraDir + "/" + base + mkvJSONSuffix
 	err1 := os.Remove(extraFile)
 	err2 := os.Remove(metaFile)
+	if err := os.Remove(extraNFOPath(extraFile)); err == nil {
+		err2 = nil
+	}
+	// Titles may contain glob metacharacters, so the thumbnail extensions
+	// are removed one by one instead of globbing for them.
+	thumbBase := extraThumbnailBase(extraFile)
+	for _, ext := range extraThumbnailExts {
+		_ = os.Remove(thumbBase + ext)
+	}
 	if err1 != nil && err2 != nil {
 		return fmt.Errorf("file error: %v, meta error: %v", err1, err2)
 	}
 	return nil
 }
 
-func recordDeleteHistory(mediaType MediaType, mediaId int, extraType, extraTitle string) {
+// recordDeleteHistory appends a delete event to the history. When the media
+// title cannot be resolved the event is still recorded with "Unknown" as the
+// title, like recordDownloadHistory, and an error is returned.
+func recordDeleteHistory(mediaType MediaType, mediaId int, extraType, extraTitle string) error {
 	cacheFile, _ := resolveCachePath(mediaType)
 	mediaTitle := lookupMediaTitle(cacheFile, mediaId)
+	var titleErr error
 	if mediaTitle == "" {
-		panic(fmt.Errorf("recordDeleteHistory: could not find media title for mediaType=%v, mediaId=%v", mediaType, mediaId))
+		titleErr = fmt.Errorf("could not find media title for mediaType=%v, mediaId=%v", mediaType, mediaId)
+		TrailarrLog(WARN, "Extras", "recordDeleteHistory: %v; recording as Unknown", titleErr)
+		mediaTitle = "Unknown"
 	}
 	event := HistoryEvent{
 		Action:     "delete",
@@ -489,7 +595,10 @@ func recordDeleteHistory(mediaType MediaType, mediaId int, extraType, extraTitle
 		ExtraTitle: extraTitle,
 		Date:       time.Now(),
 	}
-	_ = AppendHistoryEvent(event)
+	if err := AppendHistoryEvent(event); err != nil {
+		return err
+	}
+	return titleErr
 }
 
 func canonicalizeExtraType(extraType string) string {
@@ -502,8 +611,95 @@ func canonicalizeExtraType(extraType string) string {
 	return extraType
 }
 
-// FetchTMDBExtrasForMedia fetches extras from TMDB for a given media item
+// FetchTMDBExtrasForMedia fetches extras from TMDB for a given media item for
+// the automated pipeline. Non-official trailers are dropped when
+// general.officialTrailersOnly is enabled.
 func FetchTMDBExtrasForMedia(mediaType MediaType, id int) ([]Extra, error) {
+	return fetchTMDBExtrasForMedia(mediaType, id, GetOfficialTrailersOnly())
+}
+
+// tmdbRateLimitRetries is how many TMDBRateLimitErrors background callers wait
+// out for one media before giving up on it for this run.
+const tmdbRateLimitRetries = 3
+
+// fetchTMDBExtrasWithBackoff is FetchTMDBExtrasForMedia for background
+// callers: on a TMDBRateLimitError it waits RetryAfter and tries again, up to
+// tmdbRateLimitRetries times, stopping early when ctx is cancelled.
+func fetchTMDBExtrasWithBackoff(ctx context.Context, mediaType MediaType, id int) ([]Extra, error) {
+	for attempt := 0; ; attempt++ {
+		extras, err := FetchTMDBExtrasForMedia(mediaType, id)
+		var rl *TMDBRateLimitError
+		if !errors.As(err, &rl) || attempt >= tmdbRateLimitRetries {
+			return extras, err
+		}
+		TrailarrLog(INFO, "TMDB", "Rate limited fetching extras for %s id=%d, retrying in %v", mediaType, id, rl.RetryAfter)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(rl.RetryAfter):
+		}
+	}
+}
+
+// fetchTMDBSeasonExtrasForSeries fetches the season videos of every season
+// Sonarr reports for the series, skipping specials. A failed season is logged
+// and skipped so the series-level extras are still returned.
+func fetchTMDBSeasonExtrasForSeries(seriesId, tmdbId int, tmdbKey string) []Extra {
+	var out []Extra
+	for _, season := range getSeriesSeasonNumbers(seriesId) {
+		tmdbExtrasLimiter.acquire(GetMaxConcurrentTMDBFetches())
+		extras, err := FetchTMDBSeasonExtras(tmdbId, season, tmdbKey)
+		tmdbExtrasLimiter.release()
+		if err != nil {
+			TrailarrLog(WARN, "TMDB", "Failed to fetch season %d extras for series id=%d: %v", season, seriesId, err)
+			continue
+		}
+		out = append(out, extras...)
+	}
+	return out
+}
+
+// getSeriesSeasonNumbers returns the non-special season numbers of a cached
+// Sonarr series.
+func getSeriesSeasonNumbers(seriesId int) []int {
+	items, err := LoadMediaFromStore(SeriesStoreKey)
+	if err != nil {
+		return nil
+	}
+	for _, it := range items {
+		if idInt, ok := parseMediaID(it["id"]); !ok || idInt != seriesId {
+			continue
+		}
+		seasons, _ := it["seasons"].([]interface{})
+		var out []int
+		for _, s := range seasons {
+			sm, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if n, ok := sm["seasonNumber"].(float64); ok && n > 0 {
+				out = append(out, int(n))
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// filterOfficialTrailers drops trailers TMDB does not mark as official. Other
+// extra types are kept regardless of the flag.
+func filterOfficialTrailers(extras []Extra) []Extra {
+	out := extras[:0]
+	for _, e := range extras {
+		if e.ExtraType == "Trailer" && !e.Official {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+func fetchTMDBExtrasForMedia(mediaType MediaType, id int, officialTrailersOnly bool) ([]Extra, error) {
 	tmdbKey, err := GetTMDBKey()
 	if err != nil {
 		return nil, err
@@ -514,10 +710,18 @@ func FetchTMDBExtrasForMedia(mediaType MediaType, id int) ([]Extra, error) {
 		return nil, err
 	}
 
+	tmdbExtrasLimiter.acquire(GetMaxConcurrentTMDBFetches())
 	extras, err := FetchTMDBExtras(mediaType, tmdbId, tmdbKey)
+	tmdbExtrasLimiter.release()
 	if err != nil {
 		return nil, err
 	}
+	if mediaType == MediaTypeTV && GetSeasonExtrasEnabled() {
+		extras = append(extras, fetchTMDBSeasonExtrasForSeries(id, tmdbId, tmdbKey)...)
+	}
+	if officialTrailersOnly {
+		extras = filterOfficialTrailers(extras)
+	}
 
 	// Canonicalize ExtraType for each extra before returning
 	for i := range extras {
@@ -526,6 +730,56 @@ func FetchTMDBExtrasForMedia(mediaType MediaType, id int) ([]Extra, error) {
 	return extras, nil
 }
 
+// tmdbExtrasCacheEntry is the stored form of a media's cached TMDB extras.
+type tmdbExtrasCacheEntry struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Extras    []Extra   `json:"extras"`
+}
+
+// cachedTMDBExtrasForMedia returns the TMDB extras listed in the UI for a
+// media, served from the store while younger than
+// general.tmdbExtrasCacheTtlMinutes. refresh skips the cached copy. Failed
+// fetches are not cached.
+func cachedTMDBExtrasForMedia(mediaType MediaType, id int, refresh bool) ([]Extra, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf(TMDBExtrasCacheKeyFmt, mediaType, id)
+	ttl := GetTMDBExtrasCacheTTL()
+	if ttl > 0 && !refresh {
+		if raw, err := GetStoreClient().Get(ctx, key); err == nil && raw != "" {
+			var entry tmdbExtrasCacheEntry
+			if err := json.Unmarshal([]byte(raw), &entry); err == nil && time.Since(entry.FetchedAt) < ttl {
+				return entry.Extras, nil
+			}
+		}
+	}
+	extras, err := fetchTMDBExtrasForMedia(mediaType, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		b, _ := json.Marshal(tmdbExtrasCacheEntry{FetchedAt: time.Now(), Extras: extras})
+		if err := GetStoreClient().Set(ctx, key, b); err != nil {
+			TrailarrLog(WARN, "TMDB", "Failed to cache TMDB extras for %s id=%d: %v", mediaType, id, err)
+		}
+	}
+	return extras, nil
+}
+
+// RefreshTMDBExtrasHandler re-fetches the TMDB extras of a media, replacing
+// the cached copy served by the extras listing.
+func RefreshTMDBExtrasHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	extras, err := cachedTMDBExtrasForMedia(mediaType, id, true)
+	if err != nil {
+		respondError(c, http.StatusBadGateway, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "refreshed", "count": len(extras)})
+}
+
 // Handler to list existing extras for a movie path
 func collectExistingFromSubdir(subdir string, dupCount map[string]int) []map[string]interface{} {
 	var results []map[string]interface{}
@@ -549,6 +803,10 @@ func collectExistingFromSubdir(subdir string, dupCount map[string]int) []map[str
 			if status == "" {
 				status = "downloaded"
 			}
+		} else if nfo, err := readExtraNFO(extraNFOPath(filepath.Join(subdir, f.Name()))); err == nil {
+			meta.ExtraType, meta.ExtraTitle, meta.YoutubeId = nfo.Genre, nfo.Title, nfo.UniqueID.Value
+			meta.FileName = f.Name()
+			status = "downloaded"
 		}
 		key := dirName + "|" + meta.ExtraTitle
 		dupCount[key]++
@@ -593,24 +851,37 @@ func downloadExtraHandler(c *gin.Context) {
 		ExtraType  string    `json:"extraType"`
 		ExtraTitle string    `json:"extraTitle"`
 		YoutubeId  string    `json:"youtubeId"`
+		// SeasonNumber places a TV extra under its Season XX folder.
+		SeasonNumber int `json:"seasonNumber"`
+		// Force re-downloads the extra even if its file already exists.
+		Force bool `json:"force"`
 	}
 	if err := c.BindJSON(&req); err != nil {
-		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, ErrInvalidRequest)
+		return
+	}
+	if req.YoutubeId == "" {
+		respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, "youtubeId required")
 		return
 	}
-	TrailarrLog(INFO, "Extras", "[downloadExtraHandler] Download request: mediaType=%s, mediaId=%d, extraType=%s, extraTitle=%s, youtubeId=%s",
-		req.MediaType, req.MediaId, req.ExtraType, req.ExtraTitle, req.YoutubeId)
+	TrailarrLog(INFO, "Extras", "[downloadExtraHandler] Download request: mediaType=%s, mediaId=%d, extraType=%s, extraTitle=%s, youtubeId=%s, force=%v",
+		req.MediaType, req.MediaId, req.ExtraType, req.ExtraTitle, req.YoutubeId, req.Force)
 
 	// Enqueue the download request
 	item := DownloadQueueItem{
-		MediaType:  req.MediaType,
-		MediaId:    req.MediaId,
-		ExtraType:  req.ExtraType,
-		ExtraTitle: req.ExtraTitle,
-		YouTubeID:  req.YoutubeId,
-		QueuedAt:   time.Now(),
-	}
-	AddToDownloadQueue(item, "api")
+		MediaType:    req.MediaType,
+		MediaId:      req.MediaId,
+		ExtraType:    req.ExtraType,
+		ExtraTitle:   req.ExtraTitle,
+		YouTubeID:    req.YoutubeId,
+		SeasonNumber: req.SeasonNumber,
+		Force:        req.Force,
+		QueuedAt:     time.Now(),
+	}
+	if !AddToDownloadQueue(item, "api") {
+		respondJSON(c, http.StatusOK, gin.H{"status": "already_queued"})
+		return
+	}
 	TrailarrLog(INFO, "Extras", "[downloadExtraHandler] Enqueued download: mediaType=%s, mediaId=%d, extraType=%s, extraTitle=%s, youtubeId=%s", req.MediaType, req.MediaId, req.ExtraType, req.ExtraTitle, req.YoutubeId)
 
 	// Write .mkv.json meta file for manual download
@@ -619,7 +890,8 @@ func downloadExtraHandler(c *gin.Context) {
 	if err == nil && mediaPath != "" {
 		extraDir := mediaPath + "/" + req.ExtraType
 		if err := os.MkdirAll(extraDir, 0775); err == nil {
-			metaFile := extraDir + "/" + SanitizeFilename(req.ExtraTitle) + mkvJSONSuffix
+			base := extraFileBaseName(req.ExtraType, req.ExtraTitle, req.YoutubeId)
+			metaFile := extraDir + "/" + base + mkvJSONSuffix
 			meta := struct {
 				ExtraType  string `json:"extraType"`
 				ExtraTitle string `json:"extraTitle"`
@@ -629,7 +901,7 @@ func downloadExtraHandler(c *gin.Context) {
 			}{
 				ExtraType:  req.ExtraType,
 				ExtraTitle: req.ExtraTitle,
-				FileName:   SanitizeFilename(req.ExtraTitle) + ".mkv",
+				FileName:   base + ".mkv",
 				YoutubeId:  req.YoutubeId,
 				Status:     "queued",
 			}
@@ -660,12 +932,13 @@ func handleExtraDownload(mediaType MediaType, mediaId int, extra Extra) error {
 	}
 	// Enqueue the extra for download using the queue system
 	item := DownloadQueueItem{
-		MediaType:  mediaType,
-		MediaId:    mediaId,
-		ExtraType:  extra.ExtraType,
-		ExtraTitle: extra.ExtraTitle,
-		YouTubeID:  extra.YoutubeId,
-		QueuedAt:   time.Now(),
+		MediaType:    mediaType,
+		MediaId:      mediaId,
+		ExtraType:    extra.ExtraType,
+		ExtraTitle:   extra.ExtraTitle,
+		YouTubeID:    extra.YoutubeId,
+		SeasonNumber: extra.SeasonNumber,
+		QueuedAt:     time.Now(),
 	}
 	AddToDownloadQueue(item, "task")
 	TrailarrLog(INFO, "QUEUE", "[handleExtraDownload] Enqueued extra: mediaType=%v, mediaId=%v, extraType=%s, extraTitle=%s, youtubeId=%s", mediaType, mediaId, extra.ExtraType, extra.ExtraTitle, extra.YoutubeId)
@@ -704,7 +977,7 @@ func MarkDownloadedExtras(extras []Extra, mediaPath string, typeKey, titleKey st
 	for i := range extras {
 		typeStr := canonicalizeExtraType(extras[i].ExtraType)
 		extras[i].ExtraType = typeStr
-		title := SanitizeFilename(extras[i].ExtraTitle)
+		title := extraFileBaseName(typeStr, extras[i].ExtraTitle, extras[i].YoutubeId)
 		extras[i].Status = "missing"
 
 		// Iterate existing extras and match by sanitized title and by
@@ -750,7 +1023,7 @@ func DownloadMissingExtras(mediaType MediaType, cacheFile string) error {
 			TrailarrLog(WARN, "DownloadMissingExtras", "Missing or invalid id in item: %v", m)
 			return false
 		}
-		_, err := FetchTMDBExtrasForMedia(mediaType, idInt)
+		_, err := fetchTMDBExtrasWithBackoff(context.Background(), mediaType, idInt)
 		if err != nil {
 			TrailarrLog(WARN, "DownloadMissingExtras", "SearchExtras error: %v", err)
 			return false
@@ -764,7 +1037,7 @@ func DownloadMissingExtras(mediaType MediaType, cacheFile string) error {
 	})
 	mapped := Map(filtered, func(media map[string]interface{}) downloadItem {
 		idInt, _ := parseMediaID(media["id"])
-		extras, _ := FetchTMDBExtrasForMedia(mediaType, idInt)
+		extras, _ := fetchTMDBExtrasWithBackoff(context.Background(), mediaType, idInt)
 		mediaPath, _ := FindMediaPathByID(cacheFile, idInt)
 		MarkDownloadedExtras(extras, mediaPath, "type", "title")
 		// Defensive: mark rejected extras before any download
@@ -782,8 +1055,10 @@ func DownloadMissingExtras(mediaType MediaType, cacheFile string) error {
 	return nil
 }
 
-// filterAndDownloadExtras filters extras and downloads them if enabled
+// filterAndDownloadExtras filters extras and downloads them if enabled for the
+// media (its extra types override, or config)
 func filterAndDownloadExtras(mediaType MediaType, mediaId int, extras []Extra, config ExtraTypesConfig) {
+	config = extraTypesConfigForMedia(mediaType, mediaId, config)
 	// Mark extras as rejected if their YouTube ID matches any in rejected_extras.json
 	rejectedExtras := GetRejectedExtrasForMedia(mediaType, mediaId)
 	rejectedYoutubeIds := make(map[string]struct{})
@@ -791,6 +1066,11 @@ func filterAndDownloadExtras(mediaType MediaType, mediaId int, extras []Extra, c
 		rejectedYoutubeIds[r.YoutubeId] = struct{}{}
 	}
 	MarkRejectedExtrasInMemory(extras, rejectedYoutubeIds)
+	if GetMaxTrailersPerType() > 0 {
+		cacheFile, _ := resolveCachePath(mediaType)
+		extras = addSearchedTrailers(mediaType, mediaId, lookupMediaTitle(cacheFile, mediaId), extras, config)
+		extras = limitExtrasPerType(mediaType, mediaId, extras)
+	}
 	// Filter extras according to config and status
 	filtered := Filter(extras, func(extra Extra) bool {
 		return shouldDownloadExtra(extra, config)
@@ -809,6 +1089,10 @@ func filterAndDownloadExtras(mediaType MediaType, mediaId int, extras []Extra, c
 		}
 	}
 	for _, extra := range filtered {
+		if isExtraQueuedOrDownloaded(mediaType, mediaId, extra.YoutubeId) {
+			TrailarrLog(INFO, "Extras", "Skipping youtubeId=%s for mediaType=%v id=%d type=%s: already queued or downloaded", extra.YoutubeId, mediaType, mediaId, extra.ExtraType)
+			continue
+		}
 		TrailarrLog(INFO, "Extras", "Enqueuing extra for mediaType=%v id=%d youtubeId=%s title=%s", mediaType, mediaId, extra.YoutubeId, extra.ExtraTitle)
 		err := handleExtraDownload(mediaType, mediaId, extra)
 		if err != nil {
@@ -817,6 +1101,139 @@ func filterAndDownloadExtras(mediaType MediaType, mediaId int, extras []Extra, c
 	}
 }
 
+// limitExtrasPerType keeps at most general.maxTrailersPerType extras to
+// download per canonical type, counting those already downloaded or queued.
+// Extras not eligible for download are returned unchanged.
+func limitExtrasPerType(mediaType MediaType, mediaId int, extras []Extra) []Extra {
+	limit := GetMaxTrailersPerType()
+	if limit <= 0 {
+		return extras
+	}
+	candidate := func(e Extra) bool {
+		return e.YoutubeId != "" && e.Status != "downloaded" && e.Status != "rejected"
+	}
+	have := map[string]int{}
+	done := map[int]bool{}
+	for i, e := range extras {
+		if e.Status == "downloaded" || candidate(e) && isExtraQueuedOrDownloaded(mediaType, mediaId, e.YoutubeId) {
+			done[i] = true
+			have[canonicalizeExtraType(e.ExtraType)]++
+		}
+	}
+	out := make([]Extra, 0, len(extras))
+	for i, e := range extras {
+		if done[i] || !candidate(e) {
+			out = append(out, e)
+			continue
+		}
+		typ := canonicalizeExtraType(e.ExtraType)
+		if have[typ] >= limit {
+			TrailarrLog(DEBUG, "Extras", "Skipping youtubeId=%s for mediaType=%v id=%d: %d %s already selected", e.YoutubeId, mediaType, mediaId, have[typ], typ)
+			continue
+		}
+		have[typ]++
+		out = append(out, e)
+	}
+	return out
+}
+
+// addSearchedTrailers appends YouTube search results for title when extras
+// hold fewer than general.maxTrailersPerType trailers. When the search cannot
+// make up the difference the media is marked with markTrailerSearchExhausted,
+// so it is not searched again on every run. It only reads the queue and
+// store, so the extras task runs it in its concurrent discovery phase.
+func addSearchedTrailers(mediaType MediaType, mediaId int, title string, extras []Extra, config ExtraTypesConfig) []Extra {
+	limit := GetMaxTrailersPerType()
+	trailers := canonicalizeExtraType("Trailer")
+	if limit <= 0 || title == "" || !isExtraTypeEnabled(config, trailers) {
+		return extras
+	}
+	have := 0
+	seen := map[string]bool{}
+	for _, e := range extras {
+		seen[e.YoutubeId] = true
+		if canonicalizeExtraType(e.ExtraType) == trailers && (e.Status == "downloaded" || e.YoutubeId != "" && e.Status != "rejected") {
+			have++
+		}
+	}
+	missing := limit - have
+	if missing <= 0 {
+		return extras
+	}
+	found := searchTrailerExtras(mediaType, mediaId, title, missing, seen)
+	if len(found) < missing {
+		markTrailerSearchExhausted(mediaType, mediaId)
+	}
+	return append(extras, found...)
+}
+
+// TrailerSearchExhaustedStoreKey is the store hash recording when the
+// trailer search of a media item came up short.
+const TrailerSearchExhaustedStoreKey = "trailarr:trailer_search_exhausted"
+
+// trailerSearchRetryInterval is how long a media item whose YouTube search
+// came up short of general.maxTrailersPerType trailers counts as complete
+// with fewer trailers before it is searched again.
+const trailerSearchRetryInterval = 7 * 24 * time.Hour
+
+// markTrailerSearchExhausted records that the trailer search of a media item
+// found no more trailers.
+func markTrailerSearchExhausted(mediaType MediaType, mediaId int) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := GetStoreClient().HSet(context.Background(), TrailerSearchExhaustedStoreKey, mediaStoreField(mediaType, mediaId), []byte(now)); err != nil {
+		TrailarrLog(WARN, "Extras", "Failed to record the trailer search of %s %d: %v", mediaType, mediaId, err)
+	}
+}
+
+// trailerSearchExhausted reports whether the trailer search of a media item
+// came up short within trailerSearchRetryInterval.
+func trailerSearchExhausted(mediaType MediaType, mediaId int) bool {
+	v, err := GetStoreClient().HGet(context.Background(), TrailerSearchExhaustedStoreKey, mediaStoreField(mediaType, mediaId))
+	if err != nil {
+		return false
+	}
+	at, err := strconv.ParseInt(v, 10, 64)
+	return err == nil && time.Since(time.Unix(at, 0)) < trailerSearchRetryInterval
+}
+
+// clearTrailerSearchExhausted forgets the trailer search of a media item.
+func clearTrailerSearchExhausted(ctx context.Context, mediaType MediaType, mediaId int) {
+	_ = GetStoreClient().HDel(ctx, TrailerSearchExhaustedStoreKey, mediaStoreField(mediaType, mediaId))
+}
+
+// searchTrailerExtras returns up to n trailers for title from a YouTube
+// search, skipping the IDs in seen and those rejected for the media.
+func searchTrailerExtras(mediaType MediaType, mediaId int, title string, n int, seen map[string]bool) []Extra {
+	for _, r := range GetRejectedExtrasForMedia(mediaType, mediaId) {
+		seen[r.YoutubeId] = true
+	}
+	// Over-fetch so results already known still leave n new ones.
+	results, _ := searchYtDlpForTerms([]string{title}, n+len(seen))
+	var out []Extra
+	for _, r := range results {
+		if len(out) >= n {
+			break
+		}
+		idMap, _ := r["id"].(gin.H)
+		id, _ := idMap["videoId"].(string)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		name := id
+		if snippet, ok := r["snippet"].(gin.H); ok {
+			if t, ok := snippet["title"].(string); ok && t != "" {
+				name = t
+			}
+		}
+		out = append(out, Extra{ExtraType: "Trailer", ExtraTitle: name, YoutubeId: id, Status: "missing"})
+	}
+	if len(out) > 0 {
+		TrailarrLog(INFO, "Extras", "Media %v:%d — added %d trailers from YouTube search", mediaType, mediaId, len(out))
+	}
+	return out
+}
+
 func canonicalizeMeta(meta map[string]interface{}) map[string]interface{} {
 	canonical := make(map[string]interface{})
 	for k, v := range meta {
@@ -853,10 +1270,26 @@ func scanExtrasInfo(mediaPath string) map[string][]map[string]interface{} {
 				continue
 			}
 			name := f.Name()
+			filePath := filepath.Join(subdir, name)
+			if strings.HasSuffix(name, nfoSuffix) {
+				// An NFO only describes the extra when no .mkv.json does.
+				if _, err := os.Stat(strings.TrimSuffix(filePath, nfoSuffix) + mkvJSONSuffix); err == nil {
+					continue
+				}
+				if nfo, err := readExtraNFO(filePath); err == nil {
+					extrasInfo[extraType] = append(extrasInfo[extraType], canonicalizeMeta(map[string]interface{}{
+						"extraType":  nfo.Genre,
+						"extraTitle": nfo.Title,
+						"fileName":   strings.TrimSuffix(name, nfoSuffix) + ".mkv",
+						"youtubeId":  nfo.UniqueID.Value,
+						"status":     "downloaded",
+					}))
+				}
+				continue
+			}
 			if !strings.HasSuffix(name, mkvJSONSuffix) {
 				continue
 			}
-			filePath := filepath.Join(subdir, name)
 			var meta map[string]interface{}
 			if err := ReadJSONFile(filePath, &meta); err == nil {
 				extrasInfo[extraType] = append(extrasInfo[extraType], canonicalizeMeta(meta))
@@ -869,6 +1302,7 @@ func scanExtrasInfo(mediaPath string) map[string][]map[string]interface{} {
 // In-memory cache for the rejected extras index to avoid store reads under load.
 var rejectedIndexMu sync.RWMutex
 var rejectedIndexMem []ExtrasEntry
+var rejectedIndexSaveMu sync.Mutex
 
 func loadRejectedIndexFromMemory() []ExtrasEntry {
 	rejectedIndexMu.RLock()
@@ -892,6 +1326,9 @@ func storeRejectedIndexInMemory(items []ExtrasEntry) {
 // SaveRejectedIndex builds a lightweight list of rejected extras and persists it
 // to the store so the blacklist handler can serve it quickly.
 func SaveRejectedIndex() error {
+	// Serialize rebuilds so an older async rebuild cannot overwrite a newer one.
+	rejectedIndexSaveMu.Lock()
+	defer rejectedIndexSaveMu.Unlock()
 	ctx := context.Background()
 	extras, err := GetAllExtras(ctx)
 	if err != nil {
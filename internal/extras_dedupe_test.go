@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestFilterAndDownloadExtrasSkipsDuplicateYoutubeIds(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	const mediaId = 9351
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	downloaded := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Done", YoutubeId: "dedupe-done", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, downloaded); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, downloaded.YoutubeId, MediaTypeMovie, mediaId)
+
+	extras := []Extra{
+		{ExtraType: "Trailers", ExtraTitle: "Teaser", YoutubeId: "dedupe-dup", Status: "missing"},
+		{ExtraType: "Featurettes", ExtraTitle: "Teaser", YoutubeId: "dedupe-dup", Status: "missing"},
+		{ExtraType: "Featurettes", ExtraTitle: "Done Again", YoutubeId: "dedupe-done", Status: "missing"},
+	}
+	filterAndDownloadExtras(MediaTypeMovie, mediaId, extras, ExtraTypesConfig{Trailers: true, Featurettes: true})
+
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		t.Fatalf("LRange: %v", err)
+	}
+	if len(vals) != 1 {
+		t.Fatalf("expected a single queued download, got %v", vals)
+	}
+	var q DownloadQueueItem
+	if err := json.Unmarshal([]byte(vals[0]), &q); err != nil || q.YouTubeID != "dedupe-dup" || q.ExtraType != "Trailers" {
+		t.Fatalf("expected the first dedupe-dup entry queued, got %+v err=%v", q, err)
+	}
+}
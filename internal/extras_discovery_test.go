@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExtrasDiscoveryRunsItemsConcurrently(t *testing.T) {
+	var inFlight, peak int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		key := strings.Split(r.URL.Path, "/")[3]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"results":[{"id":"v","name":"Trailer","key":"yt-disc-%s","site":"YouTube","type":"Trailer"}]}`, key)
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["tmdbKey"] = "dummy"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+
+	var items []map[string]interface{}
+	for i := 0; i < 4; i++ {
+		items = append(items, map[string]interface{}{"id": 9501 + i, "tmdbId": 7501 + i, "title": "Disc", "path": t.TempDir()})
+	}
+	if err := SaveMediaToStore(MoviesStoreKey, items); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+
+	found := map[int]string{}
+	for d := range discoverWantedItems(context.Background(), MediaTypeMovie, MoviesStoreKey, items, nil, GetExtrasDiscoveryConcurrency()) {
+		if len(d.extras) != 1 || !d.usedTMDB {
+			t.Fatalf("unexpected discovery for media %d: %+v", d.mediaId, d)
+		}
+		found[d.mediaId] = d.extras[0].YoutubeId
+	}
+	if len(found) != 4 || found[9502] != "yt-disc-7502" {
+		t.Fatalf("expected all 4 items discovered, got %v", found)
+	}
+	if p := atomic.LoadInt32(&peak); p < 2 {
+		t.Fatalf("expected TMDB lookups to overlap, peak concurrency %d", p)
+	}
+
+	// A cancelled run closes the stream without discovering anything.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for d := range discoverWantedItems(ctx, MediaTypeMovie, MoviesStoreKey, items, nil, 2) {
+		t.Fatalf("expected no results after cancel, got %+v", d)
+	}
+}
@@ -0,0 +1,162 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExtraPin records the YouTube video a user chose for one extra type of a
+// media item. Pins live in their own hash so removing rejected extras never
+// drops them.
+type ExtraPin struct {
+	MediaType  MediaType `json:"mediaType"`
+	MediaId    int       `json:"mediaId"`
+	ExtraType  string    `json:"extraType"`
+	ExtraTitle string    `json:"extraTitle"`
+	YoutubeId  string    `json:"youtubeId"`
+	PinnedAt   time.Time `json:"pinnedAt"`
+}
+
+func extraPinField(mediaType MediaType, mediaId int, extraType string) string {
+	return fmt.Sprintf("%s:%d:%s", mediaType, mediaId, canonicalizeExtraType(extraType))
+}
+
+// SetExtraPin stores pin, replacing any previous pin for the same extra type.
+func SetExtraPin(ctx context.Context, pin ExtraPin) error {
+	pin.ExtraType = canonicalizeExtraType(pin.ExtraType)
+	data, err := json.Marshal(pin)
+	if err != nil {
+		return err
+	}
+	return GetStoreClient().HSet(ctx, ExtraPinsStoreKey, extraPinField(pin.MediaType, pin.MediaId, pin.ExtraType), data)
+}
+
+// RemoveExtraPin deletes the pin for an extra type. Missing pins are not an error.
+func RemoveExtraPin(ctx context.Context, mediaType MediaType, mediaId int, extraType string) error {
+	err := GetStoreClient().HDel(ctx, ExtraPinsStoreKey, extraPinField(mediaType, mediaId, extraType))
+	if err == ErrNotFound {
+		return nil
+	}
+	return err
+}
+
+// GetExtraPinsForMedia returns the pins of one media item.
+func GetExtraPinsForMedia(ctx context.Context, mediaType MediaType, mediaId int) ([]ExtraPin, error) {
+	vals, err := GetStoreClient().HVals(ctx, ExtraPinsStoreKey)
+	if err == ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var pins []ExtraPin
+	for _, v := range vals {
+		var p ExtraPin
+		if err := json.Unmarshal([]byte(v), &p); err != nil {
+			continue
+		}
+		if p.MediaType == mediaType && p.MediaId == mediaId {
+			pins = append(pins, p)
+		}
+	}
+	return pins, nil
+}
+
+// markPinnedExtras flags pinned extras and appends pinned videos missing from
+// extras, leaving other candidates in place.
+func markPinnedExtras(extras []Extra, pins []ExtraPin) []Extra {
+	for _, p := range pins {
+		found := false
+		for i := range extras {
+			if extras[i].YoutubeId == p.YoutubeId && canonicalizeExtraType(extras[i].ExtraType) == p.ExtraType {
+				extras[i].Pinned = true
+				found = true
+			}
+		}
+		if !found {
+			extras = append(extras, Extra{ExtraType: p.ExtraType, ExtraTitle: p.ExtraTitle, YoutubeId: p.YoutubeId, Status: "missing", Pinned: true})
+		}
+	}
+	return extras
+}
+
+// applyExtraPins replaces the candidates of every pinned extra type with the
+// pinned video.
+func applyExtraPins(extras []Extra, pins []ExtraPin) []Extra {
+	if len(pins) == 0 {
+		return extras
+	}
+	pinned := make(map[string]string, len(pins))
+	for _, p := range pins {
+		pinned[p.ExtraType] = p.YoutubeId
+	}
+	out := make([]Extra, 0, len(extras)+len(pins))
+	for _, e := range extras {
+		if id, ok := pinned[canonicalizeExtraType(e.ExtraType)]; ok && id != e.YoutubeId {
+			continue
+		}
+		out = append(out, e)
+	}
+	return markPinnedExtras(out, pins)
+}
+
+type extraPinRequest struct {
+	MediaType  MediaType `json:"mediaType"`
+	MediaId    int       `json:"mediaId"`
+	ExtraType  string    `json:"extraType"`
+	ExtraTitle string    `json:"extraTitle"`
+	YoutubeId  string    `json:"youtubeId"`
+}
+
+func bindExtraPinRequest(c *gin.Context, needYoutubeId bool) (extraPinRequest, bool) {
+	var req extraPinRequest
+	if err := c.BindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		return req, false
+	}
+	if req.MediaType != MediaTypeMovie && req.MediaType != MediaTypeTV {
+		respondError(c, http.StatusBadRequest, "Invalid mediaType")
+		return req, false
+	}
+	if req.MediaId <= 0 || req.ExtraType == "" || (needYoutubeId && req.YoutubeId == "") {
+		respondError(c, http.StatusBadRequest, "mediaId, extraType and youtubeId are required")
+		return req, false
+	}
+	return req, true
+}
+
+// PinExtraHandler handles POST /api/extras/pin. Pinning a video the user
+// previously rejected clears the rejection, since the pin is an explicit choice.
+func PinExtraHandler(c *gin.Context) {
+	req, ok := bindExtraPinRequest(c, true)
+	if !ok {
+		return
+	}
+	pin := ExtraPin{MediaType: req.MediaType, MediaId: req.MediaId, ExtraType: req.ExtraType, ExtraTitle: req.ExtraTitle, YoutubeId: req.YoutubeId, PinnedAt: time.Now()}
+	if err := SetExtraPin(c.Request.Context(), pin); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err := UnmarkExtraRejected(req.MediaType, req.MediaId, req.ExtraType, req.ExtraTitle, req.YoutubeId); err != nil {
+		TrailarrLog(WARN, "Extras", "Failed to clear rejection of pinned extra %s: %v", req.YoutubeId, err)
+	}
+	TrailarrLog(INFO, "Extras", "Pinned %s for %s %d type=%s", req.YoutubeId, req.MediaType, req.MediaId, pin.ExtraType)
+	respondJSON(c, http.StatusOK, gin.H{"status": "pinned"})
+}
+
+// UnpinExtraHandler handles DELETE /api/extras/pin.
+func UnpinExtraHandler(c *gin.Context) {
+	req, ok := bindExtraPinRequest(c, false)
+	if !ok {
+		return
+	}
+	if err := RemoveExtraPin(c.Request.Context(), req.MediaType, req.MediaId, req.ExtraType); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "unpinned"})
+}
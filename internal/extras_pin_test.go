@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPinnedExtraReplacesSearchCandidates(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const mediaId = 9331
+	for _, e := range []ExtrasEntry{
+		{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Fan Cut", YoutubeId: "pin-other", Status: "missing"},
+		{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Featurettes", ExtraTitle: "Making Of", YoutubeId: "pin-featurette", Status: "missing"},
+		{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Chosen", YoutubeId: "pin-chosen", Status: "rejected", Reason: "manual"},
+	} {
+		if err := AddOrUpdateExtra(ctx, e); err != nil {
+			t.Fatalf("AddOrUpdateExtra: %v", err)
+		}
+		defer RemoveExtra(ctx, e.YoutubeId, MediaTypeMovie, mediaId)
+	}
+	defer SaveRejectedIndex()
+	defer RemoveExtraPin(ctx, MediaTypeMovie, mediaId, "Trailers")
+
+	r := NewTestRouter()
+	r.POST("/api/extras/pin", PinExtraHandler)
+	if w := DoRequest(r, "POST", "/api/extras/pin", []byte(`{"mediaType":"movie","mediaId":9331,"extraType":"Trailers"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 without youtubeId, got %d", w.Code)
+	}
+	body := []byte(`{"mediaType":"movie","mediaId":9331,"extraType":"Trailer","extraTitle":"Chosen","youtubeId":"pin-chosen"}`)
+	if w := DoRequest(r, "POST", "/api/extras/pin", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if e, _ := GetExtraByYoutubeId(ctx, "pin-chosen", MediaTypeMovie, mediaId); e == nil || e.Status == "rejected" {
+		t.Fatalf("expected pinning to clear the rejection, got %+v", e)
+	}
+
+	extras, usedTMDB, err := fetchExtrasOrTMDB(context.Background(), MediaTypeMovie, mediaId, "Pinned Movie", nil)
+	if err != nil || usedTMDB {
+		t.Fatalf("expected local extras, got usedTMDB=%v err=%v", usedTMDB, err)
+	}
+	got := map[string]bool{}
+	for _, e := range extras {
+		got[e.YoutubeId] = e.Pinned
+	}
+	if len(got) != 2 || !got["pin-chosen"] || got["pin-featurette"] {
+		t.Fatalf("expected the pinned trailer plus the featurette, got %+v", extras)
+	}
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtrasSummaryCountsByType(t *testing.T) {
+	CreateTempConfig(t)
+	const mediaId = 9341
+	SeedMovieExtraStatuses(t, mediaId, "sum")
+
+	r := NewTestRouter()
+	r.GET("/api/extras/:mediaType/:id/summary", ExtrasSummaryHandler)
+	w := DoRequest(r, "GET", "/api/extras/movie/9341/summary", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Types map[string]extrasStatusCounts `json:"types"`
+		Total extrasStatusCounts            `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got := resp.Types["Trailers"]; got != (extrasStatusCounts{Downloaded: 1, Missing: 1, Rejected: 1}) {
+		t.Fatalf("unexpected Trailers counts %+v (all: %s)", got, w.Body.String())
+	}
+	if got := resp.Types["Featurettes"]; got != (extrasStatusCounts{Missing: 1}) {
+		t.Fatalf("unexpected Featurettes counts %+v (all: %s)", got, w.Body.String())
+	}
+	if resp.Total != (extrasStatusCounts{Downloaded: 1, Missing: 2, Rejected: 1}) {
+		t.Fatalf("unexpected total %+v", resp.Total)
+	}
+
+	for _, p := range []string{"/api/extras/music/1/summary", "/api/extras/movie/abc/summary"} {
+		if w := DoRequest(r, "GET", p, nil); w.Code != 400 {
+			t.Fatalf("%s: expected 400, got %d", p, w.Code)
+		}
+	}
+}
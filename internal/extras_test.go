@@ -113,12 +113,12 @@ func TestDeleteExtraFiles(t *testing.T) {
 	meta := filepath.Join(extraDir, "File.mkv.json")
 	_ = os.WriteFile(meta, []byte("{}"), 0o644)
 	// deleteExtraFiles should succeed even if mkv missing
-	if err := deleteExtraFiles(mediaPath, "Type", "File"); err != nil {
+	if err := deleteExtraFiles(mediaPath, "Type", "File", ""); err != nil {
 		t.Fatalf("deleteExtraFiles failed: %v", err)
 	}
 	// now remove both files to provoke error
 	_ = os.Remove(meta)
-	if err := deleteExtraFiles(mediaPath, "Type", "File"); err == nil {
+	if err := deleteExtraFiles(mediaPath, "Type", "File", ""); err == nil {
 		t.Fatalf("deleteExtraFiles expected error when both missing")
 	}
 }
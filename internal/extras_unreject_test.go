@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Full un-rejection cycle: reject -> remove from blacklist -> next extras run
+// enqueues the extra -> the queue worker downloads it.
+func TestUnrejectedExtraIsReenqueuedAndDownloaded(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	const mediaId = 4711
+	const ytID = "unreject-yt"
+
+	mediaDir := filepath.Join(TrailarrRoot, "media", "Unreject Movie")
+	if err := os.MkdirAll(mediaDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"pathMappings": []map[string]string{{"from": mediaDir, "to": mediaDir}}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	item := map[string]interface{}{"id": mediaId, "title": "Unreject Movie", "path": mediaDir, "wanted": true}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{item}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer RemoveExtra(ctx, ytID, MediaTypeMovie, mediaId)
+
+	// 1) reject
+	if err := SetExtraRejectedPersistent(MediaTypeMovie, mediaId, "Trailers", "Cycle Trailer", ytID, "bad upload"); err != nil {
+		t.Fatalf("SetExtraRejectedPersistent: %v", err)
+	}
+	if err := SaveRejectedIndex(); err != nil {
+		t.Fatalf("SaveRejectedIndex: %v", err)
+	}
+	if len(GetRejectedExtrasForMedia(MediaTypeMovie, mediaId)) != 1 {
+		t.Fatalf("expected extra to be in rejected index")
+	}
+
+	// 2) remove from blacklist through the API
+	r := NewTestRouter()
+	r.POST("/api/blacklist/extras/remove", RemoveBlacklistExtraHandler)
+	body := `{"mediaType":"movie","mediaId":4711,"extraType":"Trailers","extraTitle":"Cycle Trailer","youtubeId":"unreject-yt"}`
+	if w := DoRequest(r, "POST", "/api/blacklist/extras/remove", []byte(body)); w.Code != 200 {
+		t.Fatalf("expected 200 removing blacklist entry, got %d body=%s", w.Code, w.Body.String())
+	}
+	// The rejected index must be clean immediately, not after an async rebuild.
+	if rej := GetRejectedExtrasForMedia(MediaTypeMovie, mediaId); len(rej) != 0 {
+		t.Fatalf("expected no rejected extras after unblacklist, got %+v", rej)
+	}
+	info := &downloadInfo{MediaType: MediaTypeMovie, MediaId: mediaId}
+	if meta := checkRejectedExtras(info, ytID); meta != nil {
+		t.Fatalf("download path still treats extra as rejected: %+v", meta)
+	}
+
+	// 3) next extras run enqueues it
+	processWantedItem(ctx, ExtraTypesConfig{Trailers: true}, MediaTypeMovie, MoviesStoreKey, item, []string{"Trailers"})
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil || len(vals) != 1 {
+		t.Fatalf("expected exactly one queued item, got %v err=%v", vals, err)
+	}
+	var queued DownloadQueueItem
+	if err := json.Unmarshal([]byte(vals[0]), &queued); err != nil {
+		t.Fatalf("unmarshal queue item: %v", err)
+	}
+	if queued.YouTubeID != ytID {
+		t.Fatalf("expected %s to be enqueued, got %+v", ytID, queued)
+	}
+
+	// 4) the worker downloads it
+	if err := processQueueItem(ctx, 0, queued); err != nil {
+		t.Fatalf("processQueueItem: %v", err)
+	}
+	entry, err := GetExtraByYoutubeId(ctx, ytID, MediaTypeMovie, mediaId)
+	if err != nil || entry == nil || entry.Status != "downloaded" {
+		t.Fatalf("expected extra persisted as downloaded, got %+v err=%v", entry, err)
+	}
+	if _, err := os.Stat(filepath.Join(mediaDir, "Trailers", "Cycle Trailer.mkv")); err != nil {
+		t.Fatalf("expected downloaded file in media dir: %v", err)
+	}
+}
@@ -40,6 +40,10 @@ func (f *fakeRunner) CombinedOutput(name string, args []string, dir string) ([]b
 		}
 	}
 	if outPath == "" {
+		if dir == "" {
+			// probes such as --version write no file; never litter the cwd
+			return []byte("[info] download complete\n"), nil
+		}
 		// fallback: create a file in dir
 		outPath = filepath.Join(dir, "fake-output.mkv")
 	} else if !filepath.IsAbs(outPath) && dir != "" {
@@ -75,8 +79,9 @@ func TestPerformDownloadWithFakeRunner(t *testing.T) {
 	ytDlpRunner = &fakeRunner{}
 	defer func() { ytDlpRunner = oldRunner }()
 
+	SeedMovieWithPath(t, 1)
 	// prepare a downloadInfo via prepareDownloadInfo but override temp dir to temp test dir
-	info, err := prepareDownloadInfo("movie", 1, "Trailer", "T", testYtID)
+	info, err := prepareDownloadInfo("movie", 1, "Trailer", "T", testYtID, 0)
 	if err != nil {
 		t.Fatalf("prepareDownloadInfo failed: %v", err)
 	}
@@ -98,3 +103,61 @@ func TestPerformDownloadWithFakeRunner(t *testing.T) {
 		t.Fatalf("expected output file at %s, stat error: %v", info.OutFile, err)
 	}
 }
+
+// fakeProbeRunner answers ffprobe with a canned JSON document.
+type fakeProbeRunner struct {
+	DefaultYtDlpRunner
+	out string
+}
+
+func (f *fakeProbeRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	return []byte(f.out), nil
+}
+
+func TestPerformDownloadVerifiesFileWithFfprobe(t *testing.T) {
+	CreateTempConfig(t)
+	oldRunner, oldFfmpeg := ytDlpRunner, ffmpegRunner
+	ytDlpRunner = &fakeRunner{}
+	defer func() { ytDlpRunner, ffmpegRunner = oldRunner, oldFfmpeg }()
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["verifyDownloads"] = true
+	general["saveExtraThumbnails"] = false
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	SeedMovieWithPath(t, 1)
+	download := func(title string) (*downloadInfo, error) {
+		info, err := prepareDownloadInfo("movie", 1, "Trailer", title, testYtID, 0)
+		if err != nil {
+			t.Fatalf("prepareDownloadInfo failed: %v", err)
+		}
+		defer os.RemoveAll(info.TempDir)
+		_ = os.MkdirAll(info.TempDir, 0o755)
+		_, err = performDownload(info, testYtID)
+		return info, err
+	}
+
+	ffmpegRunner = &fakeProbeRunner{out: `{"streams":[{"codec_type":"audio"}],"format":{"duration":"0.000000"}}`}
+	info, err := download("Truncated")
+	if err == nil || !strings.Contains(err.Error(), "failed verification") {
+		t.Fatalf("expected verification failure, got %v", err)
+	}
+	if _, statErr := os.Stat(info.OutFile); !os.IsNotExist(statErr) {
+		t.Fatalf("expected no file moved to %s", info.OutFile)
+	}
+
+	ffmpegRunner = &fakeProbeRunner{out: `{"streams":[{"codec_type":"video"},{"codec_type":"audio"}],"format":{"duration":"93.5"}}`}
+	info, err = download("Complete")
+	if err != nil {
+		t.Fatalf("expected verified download, got %v", err)
+	}
+	defer os.Remove(info.OutFile)
+	if _, err := os.Stat(info.OutFile); err != nil {
+		t.Fatalf("expected output file at %s: %v", info.OutFile, err)
+	}
+}
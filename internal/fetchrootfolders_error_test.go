@@ -3,6 +3,7 @@ package internal
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 )
 
@@ -31,3 +32,50 @@ func TestFetchRootFoldersMalformedJSON(t *testing.T) {
 		t.Fatalf("expected error for malformed JSON")
 	}
 }
+
+func TestFetchRootFoldersRetriesAndSkipsFoldersWithoutPath(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != arrAPIPath("rootfolder") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":1,"path":"/movies"},{"id":2},{"id":3,"path":""},{"id":4,"path":"/movies-4k"},{"id":5,"path":"/kids"}]`))
+	}))
+	defer ts.Close()
+
+	folders, err := FetchRootFolders(ts.URL, "key")
+	if err != nil {
+		t.Fatalf("FetchRootFolders: %v", err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("expected one retry after the 503, got %d requests", n)
+	}
+	var paths []string
+	for _, f := range folders {
+		paths = append(paths, f["path"].(string))
+	}
+	if len(paths) != 3 || paths[0] != "/movies" || paths[1] != "/movies-4k" || paths[2] != "/kids" {
+		t.Fatalf("expected the three folders with a path, got %v", paths)
+	}
+}
+
+func TestFetchRootFoldersDoesNotRetryClientErrors(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	if _, err := FetchRootFolders(ts.URL, "bad"); err == nil {
+		t.Fatalf("expected an error for 401")
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected a single request for a 401, got %d", n)
+	}
+}
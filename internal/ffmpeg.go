@@ -1,17 +1,35 @@
 package internal
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 // FfmpegCmd is the binary name for ffmpeg
 const FfmpegCmd = "ffmpeg"
 
+// Post-processing modes for downloaded extras (ytdlpFlags.postProcessing).
+// Remux only rewraps the streams into mkv; re-encode converts them, which is
+// needed on some players for embedded subtitles but slow without hwaccel.
+const (
+	PostProcessRemux    = "remux"
+	PostProcessReencode = "reencode"
+)
+
 // FfmpegPath is the full path to the ffmpeg binary managed by Trailarr.
 // It defaults to `TrailarrRoot/bin/ffmpeg` (or `ffmpeg.exe` on Windows).
 var FfmpegPath string
 
+// ffmpegRunner runs ffmpeg probes; tests can replace it like ytDlpRunner.
+var ffmpegRunner YtDlpRunner = &DefaultYtDlpRunner{}
+
 // UpdateFfmpegPath recomputes FfmpegPath based on the current TrailarrRoot.
 func UpdateFfmpegPath() {
 	exe := FfmpegCmd
@@ -24,3 +42,148 @@ func UpdateFfmpegPath() {
 func init() {
 	UpdateFfmpegPath()
 }
+
+// ValidatePostProcessing checks a ytdlpFlags.postProcessing value. Empty means
+// remux.
+func ValidatePostProcessing(mode string) error {
+	switch mode {
+	case "", PostProcessRemux, PostProcessReencode:
+		return nil
+	}
+	return fmt.Errorf("invalid postProcessing %q (allowed: %s, %s)", mode, PostProcessRemux, PostProcessReencode)
+}
+
+// ffmpegBinary returns the ffmpeg yt-dlp runs, mirroring buildYtDlpArgs:
+// ytdlpFlags.ffmpegLocation (a binary or its directory), then the
+// Trailarr-managed FfmpegPath, then ffmpeg from PATH.
+func ffmpegBinary(location string) string {
+	if location != "" {
+		if fi, err := os.Stat(location); err == nil && fi.IsDir() {
+			return filepath.Join(location, filepath.Base(FfmpegPath))
+		}
+		return location
+	}
+	if fi, err := os.Stat(FfmpegPath); err == nil && !fi.IsDir() {
+		return FfmpegPath
+	}
+	return FfmpegCmd
+}
+
+// ffmpegHwaccelsCache holds the methods listed by each ffmpeg binary, so
+// re-encode downloads do not fork `ffmpeg -hwaccels` every time. Failures are
+// not cached.
+var (
+	ffmpegHwaccelsMu    sync.Mutex
+	ffmpegHwaccelsCache = map[string][]string{}
+)
+
+// invalidateFfmpegHwaccels drops the cached methods, e.g. after ffmpeg was
+// replaced.
+func invalidateFfmpegHwaccels() {
+	ffmpegHwaccelsMu.Lock()
+	ffmpegHwaccelsCache = map[string][]string{}
+	ffmpegHwaccelsMu.Unlock()
+}
+
+// FfmpegHwaccels returns the hardware acceleration methods listed by
+// `ffmpeg -hwaccels` for the given binary.
+func FfmpegHwaccels(bin string) ([]string, error) {
+	ffmpegHwaccelsMu.Lock()
+	methods, ok := ffmpegHwaccelsCache[bin]
+	ffmpegHwaccelsMu.Unlock()
+	if ok {
+		return methods, nil
+	}
+	out, err := ffmpegRunner.CombinedOutput(bin, []string{"-hide_banner", "-hwaccels"}, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s -hwaccels failed: %w", bin, err)
+	}
+	methods = parseHwaccels(string(out))
+	ffmpegHwaccelsMu.Lock()
+	ffmpegHwaccelsCache[bin] = methods
+	ffmpegHwaccelsMu.Unlock()
+	return methods, nil
+}
+
+// parseHwaccels extracts the method names following the
+// "Hardware acceleration methods:" header of `ffmpeg -hwaccels`.
+func parseHwaccels(out string) []string {
+	var methods []string
+	inList := false
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Hardware acceleration methods") {
+			inList = true
+			continue
+		}
+		if inList && line != "" {
+			methods = append(methods, line)
+		}
+	}
+	return methods
+}
+
+// checkHwaccel reports whether the ffmpeg yt-dlp will use supports the
+// ytdlpFlags.hwaccel method of cfg. An empty method needs no support.
+func checkHwaccel(cfg YtdlpFlagsConfig) error {
+	if cfg.Hwaccel == "" {
+		return nil
+	}
+	bin := ffmpegBinary(cfg.FfmpegLocation)
+	methods, err := FfmpegHwaccels(bin)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(methods, cfg.Hwaccel) {
+		return fmt.Errorf("ffmpeg at %s does not support hwaccel %q (available: %s)", bin, cfg.Hwaccel, strings.Join(methods, ", "))
+	}
+	return nil
+}
+
+// ffprobeBinary returns the ffprobe installed next to the ffmpeg yt-dlp runs
+// (see ffmpegBinary), or ffprobe from PATH when that is ffmpeg from PATH.
+func ffprobeBinary(location string) string {
+	bin := ffmpegBinary(location)
+	if bin == FfmpegCmd {
+		return "ffprobe"
+	}
+	name := strings.Replace(filepath.Base(bin), FfmpegCmd, "ffprobe", 1)
+	return filepath.Join(filepath.Dir(bin), name)
+}
+
+// ProbeVideoFile runs ffprobe on path and returns an error unless the file
+// has a video stream and a positive duration. It catches truncated or
+// corrupt downloads yt-dlp exited 0 on.
+func ProbeVideoFile(bin, path string) error {
+	out, err := ffmpegRunner.CombinedOutput(bin, []string{
+		"-v", "error",
+		"-show_entries", "stream=codec_type:format=duration",
+		"-of", "json",
+		path,
+	}, "")
+	if err != nil {
+		return fmt.Errorf("ffprobe failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	var probe struct {
+		Streams []struct {
+			CodecType string `json:"codec_type"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	hasVideo := false
+	for _, st := range probe.Streams {
+		hasVideo = hasVideo || st.CodecType == "video"
+	}
+	if !hasVideo {
+		return fmt.Errorf("no video stream in %s", filepath.Base(path))
+	}
+	if d, err := strconv.ParseFloat(probe.Format.Duration, 64); err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q in %s", probe.Format.Duration, filepath.Base(path))
+	}
+	return nil
+}
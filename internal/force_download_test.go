@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestForceDownloadOverwritesExistingFile(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	oldRunner := ytDlpRunner
+	ytDlpRunner = &fakeRunner{}
+	defer func() { ytDlpRunner = oldRunner }()
+	const mediaId = 4871
+	const ytID = "force-yt"
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["queueItemRemoveDelaySeconds"] = 0
+	general["saveExtraThumbnails"] = false
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer RemoveExtra(ctx, ytID, MediaTypeMovie, mediaId)
+	SeedMovieWithPath(t, mediaId)
+
+	info, err := prepareDownloadInfo(MediaTypeMovie, mediaId, "Trailers", "Force Trailer", ytID, 0)
+	if err != nil {
+		t.Fatalf("prepareDownloadInfo: %v", err)
+	}
+	_ = os.RemoveAll(info.TempDir)
+	if err := os.MkdirAll(filepath.Dir(info.OutFile), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(info.OutFile, []byte("old"), 0o644); err != nil {
+		t.Fatalf("write existing file: %v", err)
+	}
+	defer os.Remove(info.OutFile)
+
+	// Without force the existing file short-circuits the download.
+	meta, err := DownloadYouTubeExtra(MediaTypeMovie, mediaId, "Trailers", "Force Trailer", ytID)
+	if err != nil || meta == nil || meta.Status != "exists" {
+		t.Fatalf("expected exists without force, got %+v err=%v", meta, err)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/extras/download", downloadExtraHandler)
+	body := []byte(`{"mediaType":"movie","mediaId":4871,"extraType":"Trailers","extraTitle":"Force Trailer","youtubeId":"force-yt","force":true}`)
+	if w := DoRequest(r, "POST", "/api/extras/download", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil || len(vals) != 1 {
+		t.Fatalf("expected one queued item, got %v err=%v", vals, err)
+	}
+	var item DownloadQueueItem
+	if err := json.Unmarshal([]byte(vals[0]), &item); err != nil || !item.Force {
+		t.Fatalf("expected queued item with force set, got %+v err=%v", item, err)
+	}
+
+	if err := processQueueItem(ctx, 0, item); err != nil {
+		t.Fatalf("processQueueItem: %v", err)
+	}
+	if b, err := os.ReadFile(info.OutFile); err != nil || string(b) != "dummy" {
+		t.Fatalf("expected file overwritten by forced download, got %q err=%v", b, err)
+	}
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -142,3 +143,80 @@ func TestProviderHealthExecuteFailure(t *testing.T) {
 		t.Fatalf("expected persisted Radarr issue after failed provider execute")
 	}
 }
+
+// versionFailRunner behaves like fakeRunner but fails `--version` calls.
+// Other commands run in dir.
+type versionFailRunner struct {
+	fakeRunner
+	dir string
+}
+
+func (f *versionFailRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	if len(args) == 1 && args[0] == "--version" {
+		return nil, errors.New("executable file not found")
+	}
+	return f.fakeRunner.CombinedOutput(name, args, f.dir)
+}
+
+// Test that the health check task records TMDB and yt-dlp issues.
+func TestHealthCheckRecordsTMDBAndYtdlpIssues(t *testing.T) {
+	ctx := context.Background()
+	_ = GetStoreClient().Del(ctx, HealthIssuesStoreKey)
+	defer GetStoreClient().Del(ctx, HealthIssuesStoreKey)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	oldConfig := Config
+	Config = map[string]interface{}{"general": map[string]interface{}{"tmdbKey": "bad"}}
+	defer func() { Config = oldConfig }()
+	oldRunner := ytDlpRunner
+	ytDlpRunner = &versionFailRunner{dir: t.TempDir()}
+	defer func() { ytDlpRunner = oldRunner }()
+
+	runHealthCheckTask()
+
+	vals, err := GetStoreClient().LRange(ctx, HealthIssuesStoreKey, 0, -1)
+	if err != nil {
+		t.Fatalf("failed to read health issues from store: %v", err)
+	}
+	sources := map[string]string{}
+	for _, v := range vals {
+		var hm HealthMsg
+		_ = json.Unmarshal([]byte(v), &hm)
+		sources[hm.Source] = hm.Message
+	}
+	if msg := sources["TMDB"]; msg != "TMDB API key is invalid" {
+		t.Fatalf("expected invalid TMDB key issue, got %q (all=%v)", msg, sources)
+	}
+	if _, ok := sources["yt-dlp"]; !ok {
+		t.Fatalf("expected yt-dlp issue, got %v", sources)
+	}
+}
+
+func TestLivenessAndReadinessProbes(t *testing.T) {
+	r := NewTestRouter()
+	r.GET("/health/live", LivenessHandler)
+	r.GET("/health/ready", ReadinessHandler)
+
+	if w := DoRequest(r, "GET", "/health/live", nil); w.Code != 200 {
+		t.Fatalf("expected 200 from liveness, got %d", w.Code)
+	}
+
+	_ = GetStoreClient()
+	oldConfig := Config
+	defer func() { Config = oldConfig }()
+	Config = nil
+	if w := DoRequest(r, "GET", "/health/ready", nil); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 before config is loaded, got %d", w.Code)
+	}
+	Config = map[string]interface{}{"general": map[string]interface{}{}}
+	if w := DoRequest(r, "GET", "/health/ready", nil); w.Code != 200 {
+		t.Fatalf("expected 200 once store and config are ready, got %d body=%s", w.Code, w.Body.String())
+	}
+}
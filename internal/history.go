@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,6 +18,11 @@ type HistoryEvent struct {
 	ExtraType  string    `json:"extraType"`
 	ExtraTitle string    `json:"extraTitle"`
 	Date       time.Time `json:"date"`
+	// DurationSeconds and FileSizeBytes are set on download events: the time
+	// from the start of the yt-dlp run to the extra landing in the library,
+	// and the size of the downloaded file.
+	DurationSeconds float64 `json:"durationSeconds,omitempty"`
+	FileSizeBytes   int64   `json:"fileSizeBytes,omitempty"`
 }
 
 func historyHandler(c *gin.Context) {
@@ -42,8 +48,44 @@ func AppendHistoryEvent(event HistoryEvent) error {
 	if err := client.RPush(ctx, HistoryStoreKey, b); err != nil {
 		return err
 	}
-	// Trim list to maximum length
-	return client.LTrim(ctx, HistoryStoreKey, -HistoryMaxLen, -1)
+	// Trim list to the configured retention
+	return client.LTrim(ctx, HistoryStoreKey, -int64(GetHistoryMaxLen()), -1)
+}
+
+// clearHistoryHandler handles DELETE /api/history and removes every event.
+func clearHistoryHandler(c *gin.Context) {
+	if err := GetStoreClient().Del(context.Background(), HistoryStoreKey); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "cleared"})
+}
+
+// deleteHistoryEventHandler handles DELETE /api/history/:index. The index is
+// the position in the newest-first list returned by GET /api/history.
+func deleteHistoryEventHandler(c *gin.Context) {
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 {
+		respondError(c, http.StatusBadRequest, "invalid history index")
+		return
+	}
+	client := GetStoreClient()
+	ctx := context.Background()
+	vals, err := client.LRange(ctx, HistoryStoreKey, 0, -1)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if index >= len(vals) {
+		respondError(c, http.StatusNotFound, "history event not found")
+		return
+	}
+	// events are stored newest-last
+	if err := client.LRem(ctx, HistoryStoreKey, 1, []byte(vals[len(vals)-1-index])); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "deleted"})
 }
 
 func LoadHistoryEvents() ([]HistoryEvent, error) {
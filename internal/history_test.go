@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistoryTrimmedToConfiguredLengthAndDeletable(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, HistoryStoreKey)
+	defer client.Del(ctx, HistoryStoreKey)
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["historyMaxLen"] = 3
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	appendN := func(from, to int) {
+		for i := from; i <= to; i++ {
+			if err := AppendHistoryEvent(HistoryEvent{Action: "download", MediaId: i, ExtraTitle: fmt.Sprintf("E%d", i)}); err != nil {
+				t.Fatalf("AppendHistoryEvent: %v", err)
+			}
+		}
+	}
+	appendN(1, 3)
+	if events, _ := LoadHistoryEvents(); len(events) != 3 || events[0].MediaId != 1 {
+		t.Fatalf("expected all 3 events kept at the limit, got %+v", events)
+	}
+	appendN(4, 4)
+	events, _ := LoadHistoryEvents()
+	if len(events) != 3 || events[0].MediaId != 2 || events[2].MediaId != 4 {
+		t.Fatalf("expected the oldest event dropped past the limit, got %+v", events)
+	}
+
+	r := NewTestRouter()
+	r.DELETE("/api/history", clearHistoryHandler)
+	r.DELETE("/api/history/:index", deleteHistoryEventHandler)
+	// Index 1 of the newest-first list is MediaId 3.
+	if w := DoRequest(r, "DELETE", "/api/history/1", nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if events, _ := LoadHistoryEvents(); len(events) != 2 || events[0].MediaId != 2 || events[1].MediaId != 4 {
+		t.Fatalf("expected event 3 removed, got %+v", events)
+	}
+	if w := DoRequest(r, "DELETE", "/api/history/5", nil); w.Code != 404 {
+		t.Fatalf("expected 404 for an out-of-range index, got %d", w.Code)
+	}
+	if w := DoRequest(r, "DELETE", "/api/history/x", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for a bad index, got %d", w.Code)
+	}
+	if w := DoRequest(r, "DELETE", "/api/history", nil); w.Code != 200 {
+		t.Fatalf("expected 200 clearing history, got %d", w.Code)
+	}
+	if events, _ := LoadHistoryEvents(); len(events) != 0 {
+		t.Fatalf("expected empty history, got %+v", events)
+	}
+}
+
+func TestDownloadHistoryRecordsDurationAndFileSize(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, HistoryStoreKey)
+	defer client.Del(ctx, HistoryStoreKey)
+
+	outFile := filepath.Join(t.TempDir(), "Trailer.mkv")
+	if err := os.WriteFile(outFile, make([]byte, 2048), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	recordDownloadHistory(&downloadInfo{
+		MediaType: MediaTypeMovie, MediaId: 1, ExtraType: "Trailers", ExtraTitle: "Trailer",
+		OutFile: outFile, StartedAt: time.Now().Add(-3 * time.Second),
+	})
+
+	r := NewTestRouter()
+	r.GET("/api/history", historyHandler)
+	w := DoRequest(r, "GET", "/api/history", nil)
+	var resp struct {
+		History []map[string]interface{} `json:"history"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.History) != 1 {
+		t.Fatalf("expected one event, got %s err=%v", w.Body.String(), err)
+	}
+	ev := resp.History[0]
+	if d, _ := ev["durationSeconds"].(float64); d < 3 || d > 60 {
+		t.Fatalf("expected durationSeconds of about 3, got %v", ev["durationSeconds"])
+	}
+	if n, _ := ev["fileSizeBytes"].(float64); n != 2048 {
+		t.Fatalf("expected fileSizeBytes 2048, got %v", ev["fileSizeBytes"])
+	}
+}
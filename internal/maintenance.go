@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WantedIndexCounts reports the outcome of rebuilding one wanted index.
+type WantedIndexCounts struct {
+	Items  int `json:"items"`
+	Wanted int `json:"wanted"`
+}
+
+// RebuildWantedIndexes drops the in-memory wanted indexes and recomputes the
+// movie and series wanted indexes from the main store.
+func RebuildWantedIndexes() (map[string]WantedIndexCounts, error) {
+	wantedIndexMu.Lock()
+	wantedIndexMem = map[string][]map[string]interface{}{}
+	wantedIndexMu.Unlock()
+
+	counts := map[string]WantedIndexCounts{}
+	for name, storeKey := range map[string]string{"movies": MoviesStoreKey, "series": SeriesStoreKey} {
+		if err := updateWantedStatusInStore(storeKey); err != nil {
+			return nil, err
+		}
+		items, err := LoadMediaFromStore(storeKey)
+		if err != nil {
+			return nil, err
+		}
+		wanted, err := LoadWantedIndex(storeKey)
+		if err != nil {
+			return nil, err
+		}
+		counts[name] = WantedIndexCounts{Items: len(items), Wanted: len(wanted)}
+	}
+	return counts, nil
+}
+
+// RebuildWantedIndexHandler handles POST /api/maintenance/rebuild-wanted-index.
+func RebuildWantedIndexHandler(c *gin.Context) {
+	counts, err := RebuildWantedIndexes()
+	if err != nil {
+		TrailarrLog(ERROR, "Maintenance", "Failed to rebuild wanted index: %v", err)
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	TrailarrLog(INFO, "Maintenance", "Rebuilt wanted index: %+v", counts)
+	respondJSON(c, http.StatusOK, counts)
+}
+
+// RebuildRejectedIndex drops the in-memory rejected index and rebuilds it from
+// the extras collection. Returns the number of rejected entries found.
+func RebuildRejectedIndex() (int, error) {
+	rejectedIndexMu.Lock()
+	rejectedIndexMem = nil
+	rejectedIndexMu.Unlock()
+
+	if err := SaveRejectedIndex(); err != nil {
+		return 0, err
+	}
+	idx, err := LoadRejectedIndex()
+	if err != nil {
+		return 0, err
+	}
+	return len(idx), nil
+}
+
+// RebuildRejectedIndexHandler handles POST /api/maintenance/rebuild-rejected-index.
+func RebuildRejectedIndexHandler(c *gin.Context) {
+	n, err := RebuildRejectedIndex()
+	if err != nil {
+		TrailarrLog(ERROR, "Maintenance", "Failed to rebuild rejected index: %v", err)
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	TrailarrLog(INFO, "Maintenance", "Rebuilt rejected index: %d entries", n)
+	respondJSON(c, http.StatusOK, gin.H{"rejected": n})
+}
+
+// MediaCoverCleanupResult counts the cached images removed by CleanupMediaCover.
+type MediaCoverCleanupResult struct {
+	Expired  int `json:"expired"`
+	Orphaned int `json:"orphaned"`
+}
+
+// CleanupMediaCover removes cached images under MediaCoverPath that are older
+// than ttl (0 disables age-based removal) and the poster directories of movies
+// and series no longer present in the store, along with expired
+// missing-thumbnail markers. Removed posters are fetched again by the next
+// sync, fanart and thumbnails on next use.
+func CleanupMediaCover(ttl time.Duration) (MediaCoverCleanupResult, error) {
+	var res MediaCoverCleanupResult
+	cutoff := time.Time{}
+	if ttl > 0 {
+		cutoff = time.Now().Add(-ttl)
+	}
+	for dir, storeKey := range map[string]string{"Movies": MoviesStoreKey, "Series": SeriesStoreKey} {
+		items, err := LoadMediaFromStore(storeKey)
+		if err != nil {
+			return res, err
+		}
+		known := make(map[string]bool, len(items))
+		for _, item := range items {
+			if id, ok := parseMediaID(item["id"]); ok {
+				known[fmt.Sprintf("%d", id)] = true
+			}
+		}
+		baseDir := filepath.Join(MediaCoverPath, dir)
+		entries, err := os.ReadDir(baseDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return res, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			idDir := filepath.Join(baseDir, e.Name())
+			if !known[e.Name()] {
+				n, err := countFiles(idDir)
+				if err != nil {
+					return res, err
+				}
+				if err := os.RemoveAll(idDir); err != nil {
+					return res, err
+				}
+				res.Orphaned += n
+				continue
+			}
+			n, err := removeFilesOlderThan(idDir, cutoff)
+			if err != nil {
+				return res, err
+			}
+			res.Expired += n
+		}
+	}
+	// YouTube thumbnails are also cached for search results that are never
+	// stored, so only their age is considered.
+	n, err := removeFilesOlderThan(filepath.Join(MediaCoverPath, "YouTube"), cutoff)
+	if err != nil && !os.IsNotExist(err) {
+		return res, err
+	}
+	res.Expired += n
+	// Missing-thumbnail markers for videos never requested again would
+	// otherwise stay in the store forever.
+	purged, err := purgeYouTubeThumbMissing()
+	if err != nil {
+		return res, err
+	}
+	TrailarrLog(INFO, "Maintenance", "MediaCover cleanup removed %d expired and %d orphaned images and %d missing-thumbnail markers", res.Expired, res.Orphaned, purged)
+	return res, nil
+}
+
+// removeFilesOlderThan removes the regular files in dir last modified before
+// cutoff. A zero cutoff removes nothing.
+func removeFilesOlderThan(dir string, cutoff time.Time) (int, error) {
+	if cutoff.IsZero() {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return removed, err
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// countFiles returns the number of regular files directly in dir.
+func countFiles(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// CleanupMediaCoverHandler handles POST /api/maintenance/cleanup-mediacover,
+// running the MediaCover cleanup with the configured general.mediaCoverTtlDays.
+func CleanupMediaCoverHandler(c *gin.Context) {
+	res, err := CleanupMediaCover(GetMediaCoverTTL())
+	if err != nil {
+		TrailarrLog(ERROR, "Maintenance", "MediaCover cleanup failed: %v", err)
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, res)
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRebuildWantedIndexReplacesStaleIndex(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	defer client.Del(ctx, MoviesStoreKey)
+	defer client.Del(ctx, MoviesWantedStoreKey)
+	defer client.Del(ctx, SeriesStoreKey)
+	defer client.Del(ctx, SeriesWantedStoreKey)
+	_ = client.Del(ctx, SeriesStoreKey)
+
+	items := []map[string]interface{}{{"id": 1.0, "title": "One"}, {"id": 2.0, "title": "Two"}}
+	if err := SaveMediaToStore(MoviesStoreKey, items); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	// Simulate drift: the wanted index lists nothing although both movies lack trailers.
+	if err := SaveWantedIndex(MoviesStoreKey, []map[string]interface{}{}); err != nil {
+		t.Fatalf("SaveWantedIndex: %v", err)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/maintenance/rebuild-wanted-index", RebuildWantedIndexHandler)
+	w := DoRequest(r, "POST", "/api/maintenance/rebuild-wanted-index", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var counts map[string]WantedIndexCounts
+	if err := json.Unmarshal(w.Body.Bytes(), &counts); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if counts["movies"] != (WantedIndexCounts{Items: 2, Wanted: 2}) || counts["series"] != (WantedIndexCounts{}) {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+	if wanted, err := LoadWantedIndex(MoviesStoreKey); err != nil || len(wanted) != 2 {
+		t.Fatalf("expected rebuilt wanted index with 2 items, got %v err=%v", wanted, err)
+	}
+}
+
+func TestRebuildRejectedIndexMatchesBlacklist(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	const mediaId = 9321
+	entry := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Rejected", YoutubeId: "rebuild-rejected-yt", Status: "rejected"}
+	defer SaveRejectedIndex()
+	if err := AddOrUpdateExtra(ctx, entry); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, entry.YoutubeId, MediaTypeMovie, mediaId)
+
+	// Out-of-sync index in memory and store, as after a manual store edit.
+	stale := []ExtrasEntry{{YoutubeId: "stale"}}
+	data, _ := json.Marshal(stale)
+	_ = client.Set(ctx, RejectedExtrasStoreKey, data)
+	storeRejectedIndexInMemory(stale)
+
+	r := NewTestRouter()
+	r.POST("/api/maintenance/rebuild-rejected-index", RebuildRejectedIndexHandler)
+	r.GET("/api/blacklist/extras", BlacklistExtrasHandler)
+	w := DoRequest(r, "POST", "/api/maintenance/rebuild-rejected-index", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Rejected int `json:"rejected"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	all, err := GetAllExtras(ctx)
+	if err != nil {
+		t.Fatalf("GetAllExtras: %v", err)
+	}
+	want := map[string]bool{}
+	for _, e := range all {
+		if e.Status == "rejected" {
+			want[e.YoutubeId] = true
+		}
+	}
+	if resp.Rejected != len(want) || !want[entry.YoutubeId] {
+		t.Fatalf("expected %d rejected entries including %s, got %d", len(want), entry.YoutubeId, resp.Rejected)
+	}
+
+	w = DoRequest(r, "GET", "/api/blacklist/extras", nil)
+	var served []ExtrasEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &served); err != nil {
+		t.Fatalf("decode blacklist: %v", err)
+	}
+	if len(served) != len(want) {
+		t.Fatalf("expected blacklist to serve %d entries, got %+v", len(want), served)
+	}
+	for _, e := range served {
+		if !want[e.YoutubeId] {
+			t.Fatalf("blacklist served unexpected entry %+v", e)
+		}
+	}
+}
+
+func TestCleanupMediaCoverRemovesExpiredAndOrphanedImages(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	defer client.Del(ctx, MoviesStoreKey)
+	defer client.Del(ctx, SeriesStoreKey)
+	_ = client.Del(ctx, SeriesStoreKey)
+	oldPath := MediaCoverPath
+	MediaCoverPath = t.TempDir()
+	defer func() { MediaCoverPath = oldPath }()
+
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": 1.0, "title": "Kept"}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	write := func(rel string, mtime time.Time) string {
+		p := filepath.Join(MediaCoverPath, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte("img"), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+		return p
+	}
+	fresh := write("Movies/1/poster-500.jpg", time.Now())
+	stale := write("Movies/1/fanart-1280.jpg", old)
+	orphan := write("Movies/2/poster-500.jpg", time.Now())
+	staleThumb := write("YouTube/abc.jpg", old)
+	freshThumb := write("YouTube/def.jpg", time.Now())
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["mediaCoverTtlDays"] = 1
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/maintenance/cleanup-mediacover", CleanupMediaCoverHandler)
+	w := DoRequest(r, "POST", "/api/maintenance/cleanup-mediacover", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var res MediaCoverCleanupResult
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if res != (MediaCoverCleanupResult{Expired: 2, Orphaned: 1}) {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	for _, p := range []string{stale, orphan, staleThumb} {
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			t.Fatalf("expected %s removed, stat err=%v", p, err)
+		}
+	}
+	for _, p := range []string{fresh, freshThumb} {
+		if _, err := os.Stat(p); err != nil {
+			t.Fatalf("expected %s kept: %v", p, err)
+		}
+	}
+}
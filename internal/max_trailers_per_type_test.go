@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestMaxTrailersPerTypeCapsAndTopsUpFromSearch(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	oldTestMode := YtDlpTestMode
+	YtDlpTestMode = true
+	defer func() { YtDlpTestMode = oldTestMode }()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["maxTrailersPerType"] = 2
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	const capped, toppedUp = 9401, 9402
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": capped, "title": "Capped"}, {"id": toppedUp, "title": "Sparse"}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	defer client.Del(ctx, MoviesStoreKey)
+
+	queued := func(mediaId int) []string {
+		vals, _ := client.LRange(ctx, DownloadQueue, 0, -1)
+		var ids []string
+		for _, v := range vals {
+			var q DownloadQueueItem
+			if json.Unmarshal([]byte(v), &q) == nil && q.MediaId == mediaId {
+				ids = append(ids, q.YouTubeID)
+			}
+		}
+		return ids
+	}
+	trailers := ExtraTypesConfig{Trailers: true}
+
+	filterAndDownloadExtras(MediaTypeMovie, capped, []Extra{
+		{ExtraType: "Trailers", ExtraTitle: "Old", YoutubeId: "cap-done", Status: "downloaded"},
+		{ExtraType: "Trailers", ExtraTitle: "A", YoutubeId: "cap-a", Status: "missing"},
+		{ExtraType: "Trailers", ExtraTitle: "B", YoutubeId: "cap-b", Status: "missing"},
+	}, trailers)
+	if ids := queued(capped); len(ids) != 1 || ids[0] != "cap-a" {
+		t.Fatalf("expected only cap-a queued next to the downloaded trailer, got %v", ids)
+	}
+
+	filterAndDownloadExtras(MediaTypeMovie, toppedUp, []Extra{
+		{ExtraType: "Trailers", ExtraTitle: "Only", YoutubeId: "sparse-a", Status: "missing"},
+	}, trailers)
+	if ids := queued(toppedUp); len(ids) != 2 || ids[0] != "sparse-a" || ids[1] != "test-Sparse-trailer-0" {
+		t.Fatalf("expected the TMDB trailer and one search result queued, got %v", ids)
+	}
+
+	one := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: capped, ExtraType: "Trailers", ExtraTitle: "One", YoutubeId: "cap-one", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, one); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, one.YoutubeId, MediaTypeMovie, capped)
+	if HasAnyEnabledExtras(MediaTypeMovie, capped, []string{"Trailers"}) {
+		t.Fatalf("expected one trailer to be short of the configured two")
+	}
+	two := one
+	two.YoutubeId = "cap-two"
+	if err := AddOrUpdateExtra(ctx, two); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, two.YoutubeId, MediaTypeMovie, capped)
+	if !HasAnyEnabledExtras(MediaTypeMovie, capped, []string{"Trailers"}) {
+		t.Fatalf("expected two trailers to satisfy the limit")
+	}
+}
+
+func TestTrailerSearchRunsInDiscoveryAndMarksShortResults(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	oldRunner := ytDlpRunner
+	ytDlpRunner = &fakeRunner{}
+	defer func() { ytDlpRunner = oldRunner }()
+	oldTestMode := YtDlpTestMode
+	YtDlpTestMode = false
+	defer func() { YtDlpTestMode = oldTestMode }()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["maxTrailersPerType"] = 4
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	const mediaId = 9403
+	SeedMovieWithPath(t, mediaId)
+	defer GetStoreClient().Del(ctx, MoviesStoreKey)
+	have := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Have", YoutubeId: "short-have", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, have); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, have.YoutubeId, MediaTypeMovie, mediaId)
+	defer clearTrailerSearchExhausted(ctx, MediaTypeMovie, mediaId)
+	if HasAnyEnabledExtras(MediaTypeMovie, mediaId, []string{"Trailers"}) {
+		t.Fatalf("expected one trailer to be short of the configured four")
+	}
+
+	// The fake runner finds two videos, one short of the three missing.
+	d, ok := discoverWantedItem(ctx, MediaTypeMovie, MoviesStoreKey, map[string]interface{}{"id": mediaId, "title": "Seeded"}, []string{"Trailers"})
+	if !ok {
+		t.Fatalf("expected the item discovered")
+	}
+	found := map[string]bool{}
+	for _, e := range d.extras {
+		found[e.YoutubeId] = true
+	}
+	if !found["vid1"] || !found["vid2"] {
+		t.Fatalf("expected the search results in the discovered extras, got %+v", d.extras)
+	}
+	if !trailerSearchExhausted(MediaTypeMovie, mediaId) {
+		t.Fatalf("expected the short search recorded")
+	}
+	if !HasAnyEnabledExtras(MediaTypeMovie, mediaId, []string{"Trailers"}) {
+		t.Fatalf("expected the media to count as done once the search is exhausted")
+	}
+}
@@ -3,11 +3,15 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -46,10 +50,11 @@ func SearchExtras(mediaType MediaType, mediaId int) ([]Extra, error) {
 	result := make([]Extra, 0, len(entries))
 	for _, e := range entries {
 		result = append(result, Extra{
-			ExtraType:  e.ExtraType,
-			ExtraTitle: e.ExtraTitle,
-			YoutubeId:  e.YoutubeId,
-			Status:     e.Status,
+			ExtraType:    e.ExtraType,
+			ExtraTitle:   e.ExtraTitle,
+			YoutubeId:    e.YoutubeId,
+			Status:       e.Status,
+			SeasonNumber: e.SeasonNumber,
 		})
 	}
 	return result, nil
@@ -71,13 +76,16 @@ func ProxyYouTubeImageHandler(c *gin.Context) {
 		serveCachedFile(c, path, ct)
 		return
 	}
-
-	thumbUrls := []string{
-		"https://i.ytimg.com/vi/" + youtubeId + "/maxresdefault.jpg",
-		"https://i.ytimg.com/vi/" + youtubeId + "/hqdefault.jpg",
+	if isYouTubeThumbMissing(youtubeId) {
+		serveFallbackSVG(c)
+		return
 	}
-	resp, err := fetchFirstSuccessful(thumbUrls)
+
+	resp, err := fetchFirstSuccessful(youTubeThumbnailURLs(youtubeId))
 	if err != nil || resp == nil {
+		if errors.Is(err, errThumbnailNotFound) {
+			markYouTubeThumbMissing(youtubeId)
+		}
 		serveFallbackSVG(c)
 		return
 	}
@@ -97,6 +105,106 @@ func ProxyYouTubeImageHandler(c *gin.Context) {
 	serveCachedFile(c, finalPath, ct)
 }
 
+// youTubeThumbnailURLs lists the thumbnail candidates of a video, best first.
+func youTubeThumbnailURLs(youtubeId string) []string {
+	return []string{
+		"https://i.ytimg.com/vi/" + youtubeId + "/maxresdefault.jpg",
+		"https://i.ytimg.com/vi/" + youtubeId + "/hqdefault.jpg",
+	}
+}
+
+// extraThumbnailBase returns the path of an extra's thumbnail without the
+// image extension: "<dir>/<title>-thumb".
+func extraThumbnailBase(extraFile string) string {
+	return strings.TrimSuffix(extraFile, filepath.Ext(extraFile)) + "-thumb"
+}
+
+// extraThumbnailExts are the extensions SaveExtraThumbnail can give a
+// thumbnail (see detectImageExt and cachedYouTubeImage).
+var extraThumbnailExts = []string{".jpg", ".jpeg", ".png", ".webp", ".svg"}
+
+// SaveExtraThumbnail stores the thumbnail of youtubeId next to extraFile as
+// "<title>-thumb.<ext>" and returns its path. An image already cached by
+// ProxyYouTubeImageHandler is copied instead of fetched again.
+func SaveExtraThumbnail(youtubeId, extraFile string) (string, error) {
+	base := extraThumbnailBase(extraFile)
+	if cached, _ := cachedYouTubeImage(filepath.Join(MediaCoverPath, "YouTube"), youtubeId); cached != "" {
+		in, err := os.Open(cached)
+		if err != nil {
+			return "", err
+		}
+		defer in.Close()
+		dest := base + filepath.Ext(cached)
+		if err := saveToTmp(in, dest+".tmp"); err != nil {
+			return "", err
+		}
+		return dest, os.Rename(dest+".tmp", dest)
+	}
+	resp, err := fetchFirstSuccessful(youTubeThumbnailURLs(youtubeId))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	dest := base + detectImageExt(resp.Header.Get(HeaderContentType))
+	if err := saveToTmp(resp.Body, dest+".tmp"); err != nil {
+		_ = os.Remove(dest + ".tmp")
+		return "", err
+	}
+	return dest, os.Rename(dest+".tmp", dest)
+}
+
+// MediaFanartHandler handles GET /api/media/:mediaType/:id/fanart?size=N. It
+// fetches the fanart of the requested width from the Arr server on first use,
+// caches it next to the synced posters and serves the cached copy afterwards.
+func MediaFanartHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	section, baseDir := "radarr", MediaCoverPath+"/Movies"
+	if mediaType == MediaTypeTV {
+		section, baseDir = "sonarr", MediaCoverPath+"/Series"
+	}
+	sizes := GetFanartSizes()
+	if len(sizes) == 0 {
+		respondError(c, http.StatusNotFound, "no fanart sizes configured (general.fanartSizes)")
+		return
+	}
+	size := sizes[0]
+	if v := c.Query("size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || !slices.Contains(sizes, n) {
+			respondError(c, http.StatusBadRequest, fmt.Sprintf("unsupported fanart size %q (allowed: %v)", v, sizes))
+			return
+		}
+		size = n
+	}
+
+	suffix := fmt.Sprintf("/fanart-%d.jpg", size)
+	idDir := fmt.Sprintf("%s/%d", baseDir, id)
+	localPath := idDir + suffix
+	if _, err := os.Stat(localPath); err == nil {
+		serveCachedFile(c, localPath, "image/jpeg")
+		return
+	}
+	settings, err := loadMediaSettings(section)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	job := posterJob{
+		id:        strconv.Itoa(id),
+		idDir:     idDir,
+		localPath: localPath,
+		posterUrl: trimTrailingSlash(settings.ProviderURL) + RemoteMediaCoverPath + strconv.Itoa(id) + suffix,
+	}
+	if _, err := handlePosterJob(job, section); err != nil {
+		respondError(c, http.StatusBadGateway, err.Error())
+		return
+	}
+	serveCachedFile(c, localPath, "image/jpeg")
+}
+
 // helper: ensure directory exists but don't fail the whole handler
 func ensureDirIfNeeded(path, context string) {
 	if err := os.MkdirAll(path, 0775); err != nil {
@@ -135,14 +243,29 @@ func serveCachedFile(c *gin.Context, path, contentType string) {
 	c.File(path)
 }
 
-// helper: fetch the first successful response from candidate URLs
+// errThumbnailNotFound is returned by fetchFirstSuccessful when every URL
+// answered without an image, as opposed to timing out or failing to connect.
+var errThumbnailNotFound = errors.New("no successful response")
+
+// thumbnailFetchLimiter caps simultaneous upstream thumbnail fetches
+// (general.thumbnailFetchConcurrency).
+var thumbnailFetchLimiter = newConcurrencyLimiter()
+
+// helper: fetch the first successful response from candidate URLs. Each URL
+// gets its own general.thumbnailFetchTimeoutSeconds deadline, covering the
+// body as well, so a slow maxres image does not hold the request forever.
 func fetchFirstSuccessful(urls []string) (*http.Response, error) {
+	thumbnailFetchLimiter.acquire(GetThumbnailFetchConcurrency())
+	defer thumbnailFetchLimiter.release()
+	client := &http.Client{Timeout: GetThumbnailFetchTimeout()}
+	answered := true
 	for _, u := range urls {
-		resp, err := http.Get(u)
+		resp, err := client.Get(u)
 		if err != nil {
 			if resp != nil {
 				resp.Body.Close()
 			}
+			answered = false
 			continue
 		}
 		if resp.StatusCode == 200 {
@@ -150,9 +273,82 @@ func fetchFirstSuccessful(urls []string) (*http.Response, error) {
 		}
 		resp.Body.Close()
 	}
+	if answered {
+		return nil, errThumbnailNotFound
+	}
 	return nil, fmt.Errorf("no successful response")
 }
 
+// YouTubeThumbMissingStoreKey is a hash of videos without a thumbnail, keyed
+// by YouTube id.
+const YouTubeThumbMissingStoreKey = "trailarr:youtube_thumb_missing"
+
+// youTubeThumbMissing is a YouTubeThumbMissingStoreKey entry.
+type youTubeThumbMissing struct {
+	YouTubeID string    `json:"youtubeId"`
+	Checked   time.Time `json:"checked"`
+}
+
+// isYouTubeThumbMissing reports whether youtubeId was found without a
+// thumbnail within general.thumbnailNegativeCacheHours. Expired entries are
+// dropped.
+func isYouTubeThumbMissing(youtubeId string) bool {
+	ttl := GetThumbnailNegativeCacheTTL()
+	if ttl <= 0 {
+		return false
+	}
+	ctx := context.Background()
+	raw, err := GetStoreClient().HGet(ctx, YouTubeThumbMissingStoreKey, youtubeId)
+	if err != nil || raw == "" {
+		return false
+	}
+	var entry youTubeThumbMissing
+	if err := json.Unmarshal([]byte(raw), &entry); err == nil && time.Since(entry.Checked) < ttl {
+		return true
+	}
+	_ = GetStoreClient().HDel(ctx, YouTubeThumbMissingStoreKey, youtubeId)
+	return false
+}
+
+// markYouTubeThumbMissing records that youtubeId has no thumbnail. Timeouts
+// and connection errors are never recorded, only definitive answers.
+func markYouTubeThumbMissing(youtubeId string) {
+	if GetThumbnailNegativeCacheTTL() <= 0 {
+		return
+	}
+	b, _ := json.Marshal(youTubeThumbMissing{YouTubeID: youtubeId, Checked: time.Now()})
+	if err := GetStoreClient().HSet(context.Background(), YouTubeThumbMissingStoreKey, youtubeId, b); err != nil {
+		TrailarrLog(WARN, "Media", "Failed to record missing thumbnail for %s: %v", youtubeId, err)
+	}
+}
+
+// purgeYouTubeThumbMissing removes the missing-thumbnail entries older than
+// general.thumbnailNegativeCacheHours, or all of them when the negative cache
+// is disabled, and returns how many were removed.
+func purgeYouTubeThumbMissing() (int, error) {
+	ctx := context.Background()
+	vals, err := GetStoreClient().HVals(ctx, YouTubeThumbMissingStoreKey)
+	if err != nil {
+		return 0, err
+	}
+	ttl := GetThumbnailNegativeCacheTTL()
+	removed := 0
+	for _, raw := range vals {
+		var entry youTubeThumbMissing
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil || entry.YouTubeID == "" {
+			continue
+		}
+		if ttl > 0 && time.Since(entry.Checked) < ttl {
+			continue
+		}
+		if err := GetStoreClient().HDel(ctx, YouTubeThumbMissingStoreKey, entry.YouTubeID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
 func serveFallbackSVG(c *gin.Context) {
 	svg := `<?xml version="1.0" encoding="UTF-8"?>
 			<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 128 128" width="128" height="128" role="img" aria-label="Unavailable">
@@ -212,27 +408,9 @@ func SyncMedia(provider, apiPath, cacheFile string, filter func(map[string]inter
 	// Skip extras scanning, poster caching and new-item background processing to keep this fast.
 	start := time.Now()
 
-	allItems, err := fetchProviderItems(provider, apiPath)
+	filtered, prevItems, err := syncProviderItems(provider, apiPath, cacheFile, filter)
 	if err != nil {
-		TrailarrLog(WARN, "SyncMedia", "Failed to fetch items from provider=%s apiPath=%s: %v", provider, apiPath, err)
-		return err
-	}
-
-	filtered := make([]map[string]interface{}, 0, len(allItems))
-	for _, m := range allItems {
-		if filter == nil || filter(m) {
-			filtered = append(filtered, m)
-		}
-	}
-	prevItems, _ := loadCache(cacheFile)
-	TrailarrLog(DEBUG, "SyncMedia", "Previous cache size for %s: %d", cacheFile, len(prevItems))
-
-	// Save items to the appropriate backend
-	if err := saveItems(cacheFile, filtered); err != nil {
-		TrailarrLog(WARN, "SyncMedia", "Failed to save cache %s: %v", cacheFile, err)
 		return err
-	} else {
-		TrailarrLog(DEBUG, "SyncMedia", "Saved %d items to %s", len(filtered), cacheFile)
 	}
 	// Cache poster images for the filtered items as part of sync (best-effort).
 	// Run poster caching synchronously here so the cache is populated immediately.
@@ -252,6 +430,11 @@ func SyncMedia(provider, apiPath, cacheFile string, filter func(map[string]inter
 		TrailarrLog(DEBUG, "SyncMediaCache", "updateWantedStatusInStore completed for %s", cacheFile)
 	}
 
+	// Clean up extras of items the provider no longer returns (opt-in)
+	if GetSyncCleanupRemovedMedia() {
+		handleRemovedItems(provider, filtered, prevItems)
+	}
+
 	// Handle new items (best-effort, background tasks)
 	handleNewItems(provider, filtered, prevItems)
 	TrailarrLog(DEBUG, "SyncMedia", "Triggered background processing for new items (provider=%s)", provider)
@@ -260,13 +443,55 @@ func SyncMedia(provider, apiPath, cacheFile string, filter func(map[string]inter
 	return nil
 }
 
+// syncProviderItems fetches the provider's items, filters them and replaces
+// the media store at cacheFile with them, returning the saved and the previous
+// items. It holds mediaStoreWriteMu from the fetch to the save, so an item a
+// webhook removes meanwhile is either not returned anymore or removed after.
+func syncProviderItems(provider, apiPath, cacheFile string, filter func(map[string]interface{}) bool) ([]map[string]interface{}, []map[string]interface{}, error) {
+	mediaStoreWriteMu.Lock()
+	defer mediaStoreWriteMu.Unlock()
+	allItems, err := fetchProviderItems(provider, apiPath)
+	if err != nil {
+		TrailarrLog(WARN, "SyncMedia", "Failed to fetch items from provider=%s apiPath=%s: %v", provider, apiPath, err)
+		return nil, nil, err
+	}
+
+	excluded := GetExcludedRootFolders(provider)
+	filtered := make([]map[string]interface{}, 0, len(allItems))
+	for _, m := range allItems {
+		if path, _ := m["path"].(string); isUnderExcludedFolder(path, excluded) {
+			continue
+		}
+		if filter == nil || filter(m) {
+			filtered = append(filtered, m)
+		}
+	}
+	prevItems, _ := loadCache(cacheFile)
+	TrailarrLog(DEBUG, "SyncMedia", "Previous cache size for %s: %d", cacheFile, len(prevItems))
+
+	// Save items to the appropriate backend
+	if err := saveItems(cacheFile, filtered); err != nil {
+		TrailarrLog(WARN, "SyncMedia", "Failed to save cache %s: %v", cacheFile, err)
+		return nil, nil, err
+	}
+	TrailarrLog(DEBUG, "SyncMedia", "Saved %d items to %s", len(filtered), cacheFile)
+	return filtered, prevItems, nil
+}
+
 // Generic handler for listing media (movies/series)
 func GetMediaHandler(cacheFile, key string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		query, err := parseMediaListQuery(c)
+		if err != nil {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if notModified(c, mediaStoreETag(cacheFile)) {
+			return
+		}
 		// If id query param present, load only that item for efficiency
 		idParam := c.Query("id")
 		var items []map[string]interface{}
-		var err error
 		if idParam != "" {
 			var idInt int
 			if _, scanErr := fmt.Sscanf(idParam, "%d", &idInt); scanErr == nil {
@@ -295,10 +520,217 @@ func GetMediaHandler(cacheFile, key string) gin.HandlerFunc {
 				return
 			}
 		}
-		// idParam already handled, simply filter if any additional filtering + safeguard
-		filtered := items
-		respondJSON(c, http.StatusOK, gin.H{"items": filtered})
+		filtered := Filter(items, query.matches)
+		query.sortItems(filtered)
+		c.Header(HeaderTotalCount, strconv.Itoa(len(filtered)))
+		respondJSON(c, http.StatusOK, gin.H{"items": query.page(filtered)})
+	}
+}
+
+// mediaListQuery holds the search, sort and pagination parameters of the
+// media list endpoints: q (substring of title or sortTitle,
+// case-insensitive), year, wanted, sort, order, limit and offset.
+type mediaListQuery struct {
+	text   string
+	year   int
+	wanted *bool
+	sort   string
+	desc   bool
+	limit  int
+	offset int
+}
+
+// mediaSortKeys are the accepted values of the sort parameter. airDate is the
+// next air date of a series: Sonarr's nextAiring, or airDate when set.
+var mediaSortKeys = []string{"title", "sortTitle", "year", "wanted", "airDate"}
+
+// parseMediaListQuery reads the media list query parameters, rejecting
+// malformed values.
+func parseMediaListQuery(c *gin.Context) (mediaListQuery, error) {
+	q := mediaListQuery{text: strings.ToLower(strings.TrimSpace(c.Query("q")))}
+	if v := c.Query("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return q, fmt.Errorf("invalid year")
+		}
+		q.year = n
+	}
+	if v := c.Query("wanted"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return q, fmt.Errorf("invalid wanted")
+		}
+		q.wanted = &b
+	}
+	if v := c.Query("sort"); v != "" {
+		if !slices.Contains(mediaSortKeys, v) {
+			return q, fmt.Errorf("invalid sort, expected one of %s", strings.Join(mediaSortKeys, ", "))
+		}
+		q.sort = v
+	}
+	switch strings.ToLower(c.Query("order")) {
+	case "", "asc":
+	case "desc":
+		q.desc = true
+	default:
+		return q, fmt.Errorf("invalid order, expected asc or desc")
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return q, fmt.Errorf("invalid limit")
+		}
+		q.limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return q, fmt.Errorf("invalid offset")
+		}
+		q.offset = n
 	}
+	return q, nil
+}
+
+// matches reports whether a media item passes the query's filters.
+func (q mediaListQuery) matches(m map[string]interface{}) bool {
+	if q.text != "" {
+		title, _ := m["title"].(string)
+		sortTitle, _ := m["sortTitle"].(string)
+		if !strings.Contains(strings.ToLower(title), q.text) && !strings.Contains(strings.ToLower(sortTitle), q.text) {
+			return false
+		}
+	}
+	if q.year != 0 {
+		if year, ok := parseMediaID(m["year"]); !ok || year != q.year {
+			return false
+		}
+	}
+	if q.wanted != nil {
+		wanted, _ := m["wanted"].(bool)
+		if wanted != *q.wanted {
+			return false
+		}
+	}
+	return true
+}
+
+// sortItems orders items by the query's sort key, keeping store order for
+// ties. Items without a value for the key go last in either direction.
+func (q mediaListQuery) sortItems(items []map[string]interface{}) {
+	if q.sort == "" {
+		return
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, aok := mediaSortValue(items[i], q.sort)
+		b, bok := mediaSortValue(items[j], q.sort)
+		if !aok || !bok {
+			return aok && !bok
+		}
+		if q.desc {
+			return a > b
+		}
+		return a < b
+	})
+}
+
+// mediaSortValue returns the value of key in m as a comparable string.
+// Titles compare case-insensitively, years are zero-padded and wanted sorts
+// false before true.
+func mediaSortValue(m map[string]interface{}, key string) (string, bool) {
+	switch key {
+	case "year":
+		year, ok := parseMediaID(m["year"])
+		if !ok || year <= 0 {
+			return "", false
+		}
+		return fmt.Sprintf("%06d", year), true
+	case "wanted":
+		if wanted, _ := m["wanted"].(bool); wanted {
+			return "1", true
+		}
+		return "0", true
+	case "airDate":
+		// Sonarr's /series lists the next episode as nextAiring (RFC 3339,
+		// so it sorts as a string); series that have ended have none.
+		v, _ := m["nextAiring"].(string)
+		if v == "" {
+			v, _ = m["airDate"].(string)
+		}
+		return v, v != ""
+	default:
+		v, _ := m[key].(string)
+		if v == "" {
+			return "", false
+		}
+		return strings.ToLower(v), true
+	}
+}
+
+// page returns the offset/limit window of items; limit 0 means no limit.
+func (q mediaListQuery) page(items []map[string]interface{}) []map[string]interface{} {
+	if q.offset >= len(items) {
+		return []map[string]interface{}{}
+	}
+	items = items[q.offset:]
+	if q.limit > 0 && q.limit < len(items) {
+		items = items[:q.limit]
+	}
+	return items
+}
+
+// mediaStoreVersions counts the saves of each media store key. The media
+// handlers use it as their ETag so clients can revalidate large lists
+// without downloading them again.
+var (
+	mediaStoreVersionsMu sync.Mutex
+	mediaStoreVersions   = map[string]uint64{}
+)
+
+// mediaETagEpoch keeps ETags from before a restart, when the counters start
+// over, from matching.
+var mediaETagEpoch = strconv.FormatInt(time.Now().UnixNano(), 36)
+
+// mediaConfigVersion counts saves of provider settings and path mappings.
+// Media reads apply the path mappings at read time, so those saves
+// invalidate the ETags of every media store as well.
+var mediaConfigVersion atomic.Uint64
+
+// invalidateMediaETags changes the ETags of all media stores.
+func invalidateMediaETags() {
+	mediaConfigVersion.Add(1)
+}
+
+// bumpMediaStoreVersion invalidates the ETags of the media store at cacheFile.
+func bumpMediaStoreVersion(cacheFile string) {
+	mediaStoreVersionsMu.Lock()
+	mediaStoreVersions[cacheFile]++
+	mediaStoreVersionsMu.Unlock()
+}
+
+// mediaStoreETag returns the current ETag of responses built from the media
+// store at cacheFile.
+func mediaStoreETag(cacheFile string) string {
+	mediaStoreVersionsMu.Lock()
+	v := mediaStoreVersions[cacheFile]
+	mediaStoreVersionsMu.Unlock()
+	return fmt.Sprintf(`"%s-%s-%d-%d"`, mediaETagEpoch, strings.TrimPrefix(cacheFile, "trailarr:"), v, mediaConfigVersion.Load())
+}
+
+// notModified sets the ETag header and answers 304 when the request's
+// If-None-Match already names etag. Callers must take etag before loading the
+// data, so a concurrent save can only make the validator older, not newer.
+func notModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "no-cache")
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == etag || tag == "*" {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
 }
 
 // parseMediaID parses an id from interface{} to int
@@ -375,8 +807,10 @@ func CacheMediaPosters(
 		return
 	}
 
-	// Run worker pool and process jobs
-	maxWorkers := 8
+	// Run worker pool and process jobs. Downloads also wait for a slot of
+	// posterCacheLimiter, so concurrent radarr and sonarr syncs share the
+	// general.posterCacheWorkers budget.
+	maxWorkers := GetPosterCacheWorkers()
 	if len(jobsList) < maxWorkers {
 		maxWorkers = len(jobsList)
 	}
@@ -385,9 +819,14 @@ func CacheMediaPosters(
 	TrailarrLog(INFO, "CacheMediaPosters", "Finished poster caching for section=%s workers=%d jobs=%d success=%d failed=%d", section, maxWorkers, len(jobsList), success, failed)
 }
 
+// posterCacheLimiter caps poster downloads across all running syncs
+// (general.posterCacheWorkers).
+var posterCacheLimiter = newConcurrencyLimiter()
+
 // processPosterJobs runs a worker pool to process poster download jobs and returns success/failed counts
 func processPosterJobs(jobsList []posterJob, maxWorkers int, section string) (int64, int64) {
 	jobs := make(chan posterJob, len(jobsList))
+	limit := GetPosterCacheWorkers()
 	var wg sync.WaitGroup
 	var success int64
 	var failed int64
@@ -397,7 +836,9 @@ func processPosterJobs(jobsList []posterJob, maxWorkers int, section string) (in
 		go func(workerID int) {
 			defer wg.Done()
 			for job := range jobs {
+				posterCacheLimiter.acquire(limit)
 				_, err := handlePosterJob(job, section)
+				posterCacheLimiter.release()
 				if err != nil {
 					atomic.AddInt64(&failed, 1)
 					TrailarrLog(WARN, "CacheMediaPosters", "worker=%d failed to cache poster for %s id=%s: %v", workerID, section, job.id, err)
@@ -637,6 +1078,11 @@ func LoadMediaFromStore(path string) ([]map[string]interface{}, error) {
 	return items, nil
 }
 
+// mediaStoreWriteMu serializes the read-modify-write cycles of the media
+// stores (sync, webhook removals and wanted flag updates), so one writer
+// cannot save back an item another writer just removed or changed.
+var mediaStoreWriteMu sync.Mutex
+
 // SaveMediaToStore saves movies or series to the persistent store.
 // Expects path to be MoviesStoreKey or SeriesStoreKey.
 func SaveMediaToStore(path string, items []map[string]interface{}) error {
@@ -659,6 +1105,7 @@ func SaveMediaToStore(path string, items []map[string]interface{}) error {
 	if err := client.Set(ctx, storeKey, data); err != nil {
 		return err
 	}
+	bumpMediaStoreVersion(path)
 	// Invalidate any lightweight wanted index for this section so subsequent
 	// reads will rebuild it from the authoritative main store. This ensures
 	// tests and callers that directly manipulate the main cache see fresh
@@ -722,9 +1169,8 @@ func updateItemPath(item map[string]interface{}, mappings [][]string, mediaType
 	if !ok || p == "" || mappings == nil {
 		return
 	}
-	for _, m := range mappings {
-		if strings.HasPrefix(p, m[0]) {
-			newPath := m[1] + p[len(m[0]):]
+	for _, m := range byPathMappingSpecificity(mappings) {
+		if newPath, ok := applyPathMapping(p, m); ok {
 			// Sanity check: avoid mapping a TV path to a Movies path (and vice versa)
 			tvKeywords := []string{"/tv/", "/series/"}
 			movieKeywords := []string{"/movie/", "/movies/"}
@@ -785,38 +1231,113 @@ func saveItems(cacheFile string, items []map[string]interface{}) error {
 	return fmt.Errorf("unsupported cacheFile %s; only store-backed caches are supported", cacheFile)
 }
 
-// handleNewItems detects newly added items and triggers background processing for each.
-func handleNewItems(provider string, items, prevItems []map[string]interface{}) {
-	if len(prevItems) == 0 {
-		return
-	}
+// diffMediaItems compares a sync's items with the previous cache: added are
+// the items whose id was not in prevItems, removed the ids of prevItems
+// missing from items.
+func diffMediaItems(items, prevItems []map[string]interface{}) (added []map[string]interface{}, removed []int) {
 	prevIDs := make(map[int]struct{}, len(prevItems))
 	for _, pi := range prevItems {
-		if idRaw, ok := pi["id"]; ok {
-			if idInt, ok2 := parseMediaID(idRaw); ok2 {
-				prevIDs[idInt] = struct{}{}
-			}
+		if idInt, ok := parseMediaID(pi["id"]); ok {
+			prevIDs[idInt] = struct{}{}
 		}
 	}
+	current := make(map[int]struct{}, len(items))
+	for _, it := range items {
+		idInt, ok := parseMediaID(it["id"])
+		if !ok {
+			continue
+		}
+		current[idInt] = struct{}{}
+		if _, existed := prevIDs[idInt]; !existed {
+			added = append(added, it)
+		}
+	}
+	for id := range prevIDs {
+		if _, still := current[id]; !still {
+			removed = append(removed, id)
+		}
+	}
+	sort.Ints(removed)
+	return added, removed
+}
 
+// handleRemovedItems removes the stored extras of media present in prevItems
+// but absent from items. The wanted index is rebuilt from the saved cache by
+// updateWantedStatusInStore, so removed items drop out of it as well. Returns
+// the number of removed media items.
+func handleRemovedItems(provider string, items, prevItems []map[string]interface{}) int {
+	_, removedIDs := diffMediaItems(items, prevItems)
 	mediaType := MediaTypeMovie
 	if provider == "sonarr" {
 		mediaType = MediaTypeTV
 	}
-
-	cfg, _ := GetExtraTypesConfig()
-
-	for _, it := range items {
-		idRaw, ok := it["id"]
-		if !ok {
+	ctx := context.Background()
+	for _, idInt := range removedIDs {
+		n, err := removeMediaExtras(ctx, mediaType, idInt)
+		if err != nil {
+			TrailarrLog(WARN, "SyncMedia", "Failed to load extras of removed %s id=%d: %v", mediaType, idInt, err)
 			continue
 		}
-		idInt, ok2 := parseMediaID(idRaw)
-		if !ok2 {
-			continue
+		TrailarrLog(INFO, "SyncMedia", "Removed %d extras of %s id=%d no longer returned by %s", n, mediaType, idInt, provider)
+	}
+	if len(removedIDs) > 0 {
+		if err := SaveRejectedIndex(); err != nil {
+			TrailarrLog(WARN, "SyncMedia", "failed to save rejected index: %v", err)
 		}
-		if _, existed := prevIDs[idInt]; existed {
-			continue
+	}
+	return len(removedIDs)
+}
+
+// removeMediaExtras deletes every stored extra of a media item, along with
+// its pins, extra types override and cached TMDB extras, and returns how many
+// extras there were. Files on disk are left alone. Callers save the rejected
+// index afterwards.
+func removeMediaExtras(ctx context.Context, mediaType MediaType, mediaId int) (int, error) {
+	entries, err := GetExtrasForMedia(ctx, mediaType, mediaId)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range entries {
+		if err := RemoveExtra(ctx, e.YoutubeId, mediaType, mediaId); err != nil {
+			TrailarrLog(WARN, "SyncMedia", "Failed to remove extra %s of %s id=%d: %v", e.YoutubeId, mediaType, mediaId, err)
+		}
+	}
+	client := GetStoreClient()
+	_ = client.Del(ctx, fmt.Sprintf(perMediaKeyFmt, mediaType, mediaId))
+	_ = client.Del(ctx, fmt.Sprintf(TMDBExtrasCacheKeyFmt, mediaType, mediaId))
+	if pins, err := GetExtraPinsForMedia(ctx, mediaType, mediaId); err == nil {
+		for _, p := range pins {
+			_ = RemoveExtraPin(ctx, mediaType, mediaId, p.ExtraType)
+		}
+	}
+	if err := ClearExtraTypesOverride(ctx, mediaType, mediaId); err != nil {
+		TrailarrLog(WARN, "SyncMedia", "Failed to clear extra types override of %s id=%d: %v", mediaType, mediaId, err)
+	}
+	clearTrailerSearchExhausted(ctx, mediaType, mediaId)
+	return len(entries), nil
+}
+
+// handleNewItems records the items that were not in prevItems as recently
+// added and looks up their extras in the background. Nothing counts as new on
+// the first sync, when prevItems is empty.
+func handleNewItems(provider string, items, prevItems []map[string]interface{}) {
+	if len(prevItems) == 0 {
+		return
+	}
+	added, _ := diffMediaItems(items, prevItems)
+
+	mediaType := MediaTypeMovie
+	if provider == "sonarr" {
+		mediaType = MediaTypeTV
+	}
+
+	cfg, _ := GetExtraTypesConfig()
+
+	for _, it := range added {
+		idInt, _ := parseMediaID(it["id"])
+		title, _ := it["title"].(string)
+		if err := recordRecentMedia(RecentMedia{ID: idInt, Title: title, MediaType: mediaType, Provider: provider, AddedAt: time.Now()}); err != nil {
+			TrailarrLog(WARN, "SyncMedia", "Failed to record recently added %s id=%d: %v", provider, idInt, err)
 		}
 
 		// New item detected — trigger TMDB search + enqueue downloads in background
@@ -824,10 +1345,67 @@ func handleNewItems(provider string, items, prevItems []map[string]interface{})
 	}
 }
 
+// RecentMedia is an entry of the recently added feed, recorded when a sync
+// finds media that was not in the previous sync.
+type RecentMedia struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	MediaType MediaType `json:"mediaType"`
+	Provider  string    `json:"provider"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+// recordRecentMedia appends item to RecentMediaStoreKey, keeping the newest
+// RecentMediaMaxLen entries.
+func recordRecentMedia(item RecentMedia) error {
+	client := GetStoreClient()
+	ctx := context.Background()
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := client.RPush(ctx, RecentMediaStoreKey, b); err != nil {
+		return err
+	}
+	return client.LTrim(ctx, RecentMediaStoreKey, -RecentMediaMaxLen, -1)
+}
+
+// RecentMediaHandler handles GET /api/media/recent and returns recently
+// added media newest first. The optional limit query parameter caps the
+// number of entries.
+func RecentMediaHandler(c *gin.Context) {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			respondError(c, http.StatusBadRequest, "invalid limit")
+			return
+		}
+		limit = n
+	}
+	vals, err := GetStoreClient().LRange(context.Background(), RecentMediaStoreKey, 0, -1)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	recent := make([]RecentMedia, 0, len(vals))
+	for i := len(vals) - 1; i >= 0; i-- {
+		var item RecentMedia
+		if err := json.Unmarshal([]byte(vals[i]), &item); err != nil {
+			continue
+		}
+		recent = append(recent, item)
+		if limit > 0 && len(recent) == limit {
+			break
+		}
+	}
+	respondJSON(c, http.StatusOK, gin.H{"recent": recent})
+}
+
 // processNewMediaExtras fetches TMDB extras, marks downloaded state and enqueues downloads according to config.
 func processNewMediaExtras(mediaType MediaType, mediaID int, cfg interface{}) {
 	TrailarrLog(INFO, "processNewMediaExtras", "New media detected, triggering extras search: mediaType=%v, id=%d", mediaType, mediaID)
-	extras, err := FetchTMDBExtrasForMedia(mediaType, mediaID)
+	extras, err := fetchTMDBExtrasWithBackoff(context.Background(), mediaType, mediaID)
 	if err != nil {
 		TrailarrLog(WARN, "processNewMediaExtras", "Failed to fetch TMDB extras for mediaType=%v id=%d: %v", mediaType, mediaID, err)
 		return
@@ -972,9 +1550,22 @@ func buildLightItem(m map[string]interface{}) map[string]interface{} {
 	if ad, ok := m["airDate"]; ok {
 		lm["airDate"] = ad
 	}
+	if na, ok := m["nextAiring"]; ok {
+		lm["nextAiring"] = na
+	}
+	if mon, ok := m["monitored"].(bool); ok {
+		lm["monitored"] = mon
+	}
 	return lm
 }
 
+// isMediaMonitored reports the Radarr/Sonarr monitored flag synced with the
+// media item. Items synced before the flag was tracked count as monitored.
+func isMediaMonitored(media map[string]interface{}) bool {
+	mon, ok := media["monitored"].(bool)
+	return !ok || mon
+}
+
 // SaveWantedIndex saves a lightweight wanted list for fast reads.
 func SaveWantedIndex(cacheFile string, items []map[string]interface{}) error {
 	client := GetStoreClient()
@@ -999,7 +1590,11 @@ func SaveWantedIndex(cacheFile string, items []map[string]interface{}) error {
 	}
 	// update in-memory cache for immediate subsequent reads
 	storeWantedIndexInMemory(storeKey, items)
-	return client.Set(ctx, storeKey, data)
+	if err := client.Set(ctx, storeKey, data); err != nil {
+		return err
+	}
+	broadcastWantedCounts()
+	return nil
 }
 
 // In-memory cache for wanted index to avoid store reads under load.
@@ -1069,43 +1664,252 @@ func sharedExtrasHandler(mediaType MediaType) gin.HandlerFunc {
 		var id int
 		fmt.Sscanf(idStr, "%d", &id)
 
-		// 1. Load persistent extras
-		extras, err := SearchExtras(mediaType, id)
+		finalExtras, err := mediaExtras(mediaType, id)
 		if err != nil {
 			respondError(c, http.StatusInternalServerError, err.Error())
 			return
 		}
+		respondJSON(c, http.StatusOK, gin.H{"extras": finalExtras})
+	}
+}
 
-		// 2. Load TMDB extras (best-effort)
-		tmdbExtras, err := FetchTMDBExtrasForMedia(mediaType, id)
-		if err != nil {
-			TrailarrLog(WARN, "sharedExtrasHandler", "Failed to fetch TMDB extras: %v", err)
-			tmdbExtras = nil
+// mediaExtras returns the extras of a media item as shown in the UI: stored
+// and TMDB extras merged, with downloaded, rejected and pinned flags applied.
+func mediaExtras(mediaType MediaType, id int) ([]Extra, error) {
+	// 1. Load persistent extras
+	extras, err := SearchExtras(mediaType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Load TMDB extras (best-effort, cached per media); manual browsing
+	// is never restricted to official trailers.
+	tmdbExtras, err := cachedTMDBExtrasForMedia(mediaType, id, false)
+	if err != nil {
+		TrailarrLog(WARN, "sharedExtrasHandler", "Failed to fetch TMDB extras: %v", err)
+		tmdbExtras = nil
+	}
+
+	// 3. Merge sources with persistent taking precedence
+	finalExtras := mergeExtrasPrioritizePersistent(extras, tmdbExtras)
+
+	// 4. Mark downloaded extras
+	cacheFile, _ := resolveCachePath(mediaType)
+	mediaPath, err := FindMediaPathByID(cacheFile, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s cache not found", mediaType)
+	}
+	if mediaPath == "" {
+		TrailarrLog(DEBUG, "sharedExtrasHandler", "could not resolve mediaPath for mediaType=%v id=%d cacheFile=%s; finalExtrasCount=%d", mediaType, id, cacheFile, len(finalExtras))
+	}
+	// Use the raw mediaPath from store; do not apply runtime path corrections.
+	MarkDownloadedExtras(finalExtras, mediaPath, "type", "title")
+
+	// 5. Apply rejected extras (preserve reason and include missing rejected entries)
+	rejectedExtras := GetRejectedExtrasForMedia(mediaType, id)
+	TrailarrLog(DEBUG, "sharedExtrasHandler", "Rejected extras: %+v", rejectedExtras)
+	finalExtras = applyRejectedExtras(finalExtras, rejectedExtras)
+
+	// 6. Flag pinned extras
+	if pins, err := GetExtraPinsForMedia(context.Background(), mediaType, id); err == nil {
+		finalExtras = markPinnedExtras(finalExtras, pins)
+	}
+	return finalExtras, nil
+}
+
+// extrasStatusCounts counts the extras of one canonical type by status.
+type extrasStatusCounts struct {
+	Downloaded int `json:"downloaded"`
+	Missing    int `json:"missing"`
+	Rejected   int `json:"rejected"`
+}
+
+// summarizeExtras groups extras by canonical extra type and counts them by
+// status.
+func summarizeExtras(extras []Extra) (map[string]*extrasStatusCounts, extrasStatusCounts) {
+	mapping := map[string]string{}
+	if cfg, err := GetCanonicalizeExtraTypeConfig(); err == nil && cfg.Mapping != nil {
+		mapping = cfg.Mapping
+	}
+	types := map[string]*extrasStatusCounts{}
+	var total extrasStatusCounts
+	for _, e := range extras {
+		extraType := e.ExtraType
+		if mapped, ok := mapping[extraType]; ok {
+			extraType = mapped
+		}
+		counts := types[extraType]
+		if counts == nil {
+			counts = &extrasStatusCounts{}
+			types[extraType] = counts
 		}
+		switch e.Status {
+		case "downloaded":
+			counts.Downloaded++
+			total.Downloaded++
+		case "rejected":
+			counts.Rejected++
+			total.Rejected++
+		default:
+			counts.Missing++
+			total.Missing++
+		}
+	}
+	return types, total
+}
 
-		// 3. Merge sources with persistent taking precedence
-		finalExtras := mergeExtrasPrioritizePersistent(extras, tmdbExtras)
+// parseMediaParams reads the :mediaType and :id route params, responding 400
+// and returning false when either is invalid.
+func parseMediaParams(c *gin.Context) (MediaType, int, bool) {
+	mediaType := MediaType(c.Param("mediaType"))
+	if mediaType != MediaTypeMovie && mediaType != MediaTypeTV {
+		respondError(c, http.StatusBadRequest, "invalid mediaType")
+		return "", 0, false
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		respondError(c, http.StatusBadRequest, "invalid id")
+		return "", 0, false
+	}
+	return mediaType, id, true
+}
 
-		// 4. Mark downloaded extras
-		cacheFile, _ := resolveCachePath(mediaType)
-		mediaPath, err := FindMediaPathByID(cacheFile, id)
-		if err != nil {
-			respondError(c, http.StatusInternalServerError, fmt.Sprintf("%s cache not found", mediaType))
-			return
+// ExtrasSummaryHandler returns the per-type downloaded/missing/rejected counts
+// of a media item's extras, a compact form of the extras list for dashboards.
+func ExtrasSummaryHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	extras, err := mediaExtras(mediaType, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	types, total := summarizeExtras(extras)
+	respondJSON(c, http.StatusOK, gin.H{"mediaType": mediaType, "id": id, "types": types, "total": total})
+}
+
+// DownloadMissingExtrasHandler handles POST
+// /api/extras/:mediaType/:id/download-missing and enqueues every missing
+// extra of one media item whose type is enabled, by its extra types override
+// or the global settings. Rejected extras are skipped and extras already
+// queued are not counted again. It returns the number of extras queued.
+func DownloadMissingExtrasHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	// mediaExtras marks downloaded extras (MarkDownloadedExtras) and rejected ones.
+	extras, err := mediaExtras(mediaType, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	global, _ := GetExtraTypesConfig()
+	enabledTypes := GetEnabledCanonicalExtraTypes(extraTypesConfigForMedia(mediaType, id, global))
+	queued := 0
+	for _, e := range extras {
+		if e.Status != "missing" || e.YoutubeId == "" {
+			continue
+		}
+		if _, ok := matchEnabledType(e.ExtraType, enabledTypes); !ok {
+			continue
 		}
-		if mediaPath == "" {
-			TrailarrLog(DEBUG, "sharedExtrasHandler", "could not resolve mediaPath for mediaType=%v id=%d cacheFile=%s; finalExtrasCount=%d", mediaType, id, cacheFile, len(finalExtras))
+		item := DownloadQueueItem{
+			MediaType:    mediaType,
+			MediaId:      id,
+			ExtraType:    e.ExtraType,
+			ExtraTitle:   e.ExtraTitle,
+			YouTubeID:    e.YoutubeId,
+			SeasonNumber: e.SeasonNumber,
+			QueuedAt:     time.Now(),
 		}
-		// Use the raw mediaPath from store; do not apply runtime path corrections.
-		MarkDownloadedExtras(finalExtras, mediaPath, "type", "title")
+		if AddToDownloadQueue(item, "api") {
+			queued++
+		}
+	}
+	TrailarrLog(INFO, "Extras", "Queued %d missing extras for mediaType=%s id=%d", queued, mediaType, id)
+	respondJSON(c, http.StatusOK, gin.H{"mediaType": mediaType, "id": id, "queued": queued})
+}
 
-		// 5. Apply rejected extras (preserve reason and include missing rejected entries)
-		rejectedExtras := GetRejectedExtrasForMedia(mediaType, id)
-		TrailarrLog(DEBUG, "sharedExtrasHandler", "Rejected extras: %+v", rejectedExtras)
-		finalExtras = applyRejectedExtras(finalExtras, rejectedExtras)
+// ExtraDetailHandler handles GET /api/extras/:mediaType/:id/:youtubeId and
+// returns one extra: its stored entry merged with the .mkv.json (or NFO)
+// metadata found next to the media, plus the file path and size when the
+// file exists. It answers 404 when neither source knows the video.
+func ExtraDetailHandler(c *gin.Context) {
+	mediaType, id, ok := parseMediaParams(c)
+	if !ok {
+		return
+	}
+	youtubeId := c.Param("youtubeId")
+	entry, err := GetExtraByYoutubeId(c.Request.Context(), youtubeId, mediaType, id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	cacheFile, _ := resolveCachePath(mediaType)
+	mediaPath, _ := FindMediaPathByID(cacheFile, id)
+	extraDir, meta := findExtraMeta(mediaPath, youtubeId)
+	if entry == nil && meta == nil {
+		respondError(c, http.StatusNotFound, "extra not found")
+		return
+	}
 
-		respondJSON(c, http.StatusOK, gin.H{"extras": finalExtras})
+	resp := gin.H{"mediaType": mediaType, "id": id, "youtubeId": youtubeId, "status": "missing"}
+	fileName := ""
+	if meta != nil {
+		resp["extraType"] = extraDir
+		for _, k := range []string{"ExtraType", "extraType"} {
+			if v, ok := meta[k].(string); ok && v != "" {
+				resp["extraType"] = v
+			}
+		}
+		resp["extraTitle"] = meta["Title"]
+		if v, ok := meta["Status"].(string); ok && v != "" {
+			resp["status"] = v
+		}
+		fileName, _ = meta["FileName"].(string)
+		if fileName != "" && !filepath.IsAbs(fileName) {
+			fileName = filepath.Join(mediaPath, extraDir, fileName)
+		}
+	}
+	if entry != nil {
+		resp["extraType"] = entry.ExtraType
+		resp["extraTitle"] = entry.ExtraTitle
+		if entry.Status != "" {
+			resp["status"] = entry.Status
+		}
+		if entry.Reason != "" {
+			resp["reason"] = entry.Reason
+		}
+		if entry.SeasonNumber > 0 {
+			resp["seasonNumber"] = entry.SeasonNumber
+		}
+		if fileName == "" {
+			fileName = entry.FileName
+		}
+	}
+	if fileName != "" {
+		if fi, err := os.Stat(fileName); err == nil {
+			resp["filePath"] = fileName
+			resp["fileSize"] = fi.Size()
+		}
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+// findExtraMeta returns the on-disk metadata of youtubeId under mediaPath, as
+// read by scanExtrasInfo, and the extras folder it was found in.
+func findExtraMeta(mediaPath, youtubeId string) (string, map[string]interface{}) {
+	for dir, metas := range scanExtrasInfo(mediaPath) {
+		for _, meta := range metas {
+			if id, _ := meta["YoutubeId"].(string); id == youtubeId {
+				return dir, meta
+			}
+		}
 	}
+	return "", nil
 }
 
 // mergeExtrasPrioritizePersistent merges persistent and TMDB extras using YoutubeId+ExtraType+ExtraTitle as key,
@@ -1167,9 +1971,61 @@ func applyRejectedExtras(finalExtras []Extra, rejectedExtras []RejectedExtra) []
 	return finalExtras
 }
 
-// respondError is a helper for Gin error responses
-func respondError(c *gin.Context, code int, msg string) {
-	c.JSON(code, gin.H{"error": msg})
+// ErrorCode is the machine-readable "code" of an error response, next to the
+// human-readable "error" message.
+type ErrorCode string
+
+const (
+	CodeInvalidRequest      ErrorCode = "INVALID_REQUEST"
+	CodeInvalidPathMapping  ErrorCode = "INVALID_PATH_MAPPING"
+	CodeUnauthorized        ErrorCode = "UNAUTHORIZED"
+	CodeForbidden           ErrorCode = "FORBIDDEN"
+	CodeNotFound            ErrorCode = "NOT_FOUND"
+	CodeMediaNotFound       ErrorCode = "MEDIA_NOT_FOUND"
+	CodeExtraNotFound       ErrorCode = "EXTRA_NOT_FOUND"
+	CodeConflict            ErrorCode = "CONFLICT"
+	CodeRateLimited         ErrorCode = "RATE_LIMITED"
+	CodeProviderUnreachable ErrorCode = "PROVIDER_UNREACHABLE"
+	CodeUnavailable         ErrorCode = "UNAVAILABLE"
+	CodeConfigWriteFailed   ErrorCode = "CONFIG_WRITE_FAILED"
+	CodeInternal            ErrorCode = "INTERNAL_ERROR"
+)
+
+// errorCodeForStatus is the code respondError sends for an HTTP status.
+func errorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return CodeProviderUnreachable
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	}
+	if status < http.StatusInternalServerError {
+		return CodeInvalidRequest
+	}
+	return CodeInternal
+}
+
+// respondError is a helper for Gin error responses. The code is derived from
+// the HTTP status; use respondErrorCode for a more specific one.
+func respondError(c *gin.Context, status int, msg string) {
+	respondErrorCode(c, status, errorCodeForStatus(status), msg)
+}
+
+// respondErrorCode sends {"error": msg, "code": code}.
+func respondErrorCode(c *gin.Context, status int, code ErrorCode, msg string) {
+	c.JSON(status, gin.H{"error": msg, "code": code})
 }
 
 // respondJSON is a helper for Gin JSON responses
@@ -1184,6 +2040,8 @@ func updateWantedStatusInStore(cacheFile string) error {
 		return fmt.Errorf("updateWantedStatusInStore: unsupported cacheFile %s; only store-backed caches are supported", cacheFile)
 	}
 
+	mediaStoreWriteMu.Lock()
+	defer mediaStoreWriteMu.Unlock()
 	// Load items directly from the store; use raw values for sync computations
 	items, err := LoadMediaFromStore(cacheFile)
 	if err != nil {
@@ -1207,17 +2065,23 @@ func updateWantedStatusInStore(cacheFile string) error {
 }
 
 // computeWantedIndexAndSetWants iterates the provided items, sets the "wanted"
-// flag based on presence of trailer files, logs a few debug lines and returns
-// the number of trailer-containing items and the lightweight wanted index.
+// flag based on presence of trailer files (unmonitored items are never wanted
+// with general.onlyMonitored), logs a few debug lines and returns the number
+// of trailer-containing items and the lightweight wanted index.
 func computeWantedIndexAndSetWants(items []map[string]interface{}) (int, []map[string]interface{}) {
 	trailerCount := 0
 	logged := 0
+	onlyMonitored := GetOnlyMonitored()
 	for _, item := range items {
 		mediaId, ok := getMediaID(item)
 		if !ok {
 			item["wanted"] = false
 			continue
 		}
+		if onlyMonitored && !isMediaMonitored(item) {
+			item["wanted"] = false
+			continue
+		}
 		mediaPath := ""
 		if p, ok := item["path"].(string); ok {
 			mediaPath = p
@@ -1298,6 +2162,9 @@ func GetMediaByIdHandler(cacheFile, key string) gin.HandlerFunc {
 		start := time.Now()
 		idParam := c.Param("id")
 		TrailarrLog(DEBUG, "GetMediaByIdHandler", "HTTP %s %s, idParam: %s", c.Request.Method, c.Request.URL.String(), idParam)
+		if notModified(c, mediaStoreETag(cacheFile)) {
+			return
+		}
 		// If we're querying for a single id, load only that item from store to
 		// avoid applying mappings and processing to the full cache.
 		var filtered []map[string]interface{}
@@ -1341,42 +2208,68 @@ func GetMediaByIdHandler(cacheFile, key string) gin.HandlerFunc {
 	}
 }
 
-// Returns true if the media has any extras of the enabled types (case/plural robust)
+// Returns true if the media has, for any of the enabled types (case/plural
+// robust), general.maxTrailersPerType extras (at least one when unlimited, or
+// when its trailer search recently came up short).
+// The media's extra types override, when set, replaces enabledTypes.
 func HasAnyEnabledExtras(mediaType MediaType, mediaId int, enabledTypes []string) bool {
-	if hasPersistedDownloadedExtras(mediaType, mediaId, enabledTypes) {
-		return true
+	if override, err := GetExtraTypesOverride(context.Background(), mediaType, mediaId); err == nil && override != nil {
+		enabledTypes = GetEnabledCanonicalExtraTypes(*override)
+	}
+	want := max(GetMaxTrailersPerType(), 1)
+	if want > 1 && trailerSearchExhausted(mediaType, mediaId) {
+		want = 1
 	}
-	if hasFilesystemExtras(mediaType, mediaId, enabledTypes) {
+	if maxCount(persistedDownloadedExtraCounts(mediaType, mediaId, enabledTypes)) >= want {
 		return true
 	}
-	return false
+	return maxCount(filesystemExtraCounts(mediaType, mediaId, enabledTypes)) >= want
+}
+
+func maxCount(counts map[string]int) int {
+	n := 0
+	for _, c := range counts {
+		n = max(n, c)
+	}
+	return n
 }
 
-// helper: check persisted extras for downloaded status matching enabled types
-func hasPersistedDownloadedExtras(mediaType MediaType, mediaId int, enabledTypes []string) bool {
+// matchEnabledType returns the enabled type et matches, ignoring case and a
+// plural "s".
+func matchEnabledType(et string, enabledTypes []string) (string, bool) {
+	for _, typ := range enabledTypes {
+		if strings.EqualFold(et, typ) || strings.EqualFold(et+"s", typ) || strings.EqualFold(et, typ+"s") {
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+// helper: count persisted downloaded extras per enabled type
+func persistedDownloadedExtraCounts(mediaType MediaType, mediaId int, enabledTypes []string) map[string]int {
+	counts := map[string]int{}
 	extras, _ := SearchExtras(mediaType, mediaId)
 	for _, e := range extras {
 		if !strings.EqualFold(e.Status, "downloaded") {
 			continue
 		}
-		for _, typ := range enabledTypes {
-			if strings.EqualFold(e.ExtraType, typ) || strings.EqualFold(e.ExtraType+"s", typ) || strings.EqualFold(e.ExtraType, typ+"s") {
-				return true
-			}
+		if typ, ok := matchEnabledType(e.ExtraType, enabledTypes); ok {
+			counts[typ]++
 		}
 	}
-	return false
+	return counts
 }
 
-// helper: scan filesystem for existing extras matching enabled types
-func hasFilesystemExtras(mediaType MediaType, mediaId int, enabledTypes []string) bool {
+// helper: count existing extras on the filesystem per enabled type
+func filesystemExtraCounts(mediaType MediaType, mediaId int, enabledTypes []string) map[string]int {
+	counts := map[string]int{}
 	cacheFile, err := resolveCachePath(mediaType)
 	if err != nil {
-		return false
+		return counts
 	}
 	mediaPath, _ := FindMediaPathByID(cacheFile, mediaId)
 	if mediaPath == "" {
-		return false
+		return counts
 	}
 	existing := ScanExistingExtras(mediaPath)
 	for key := range existing {
@@ -1384,14 +2277,11 @@ func hasFilesystemExtras(mediaType MediaType, mediaId int, enabledTypes []string
 		if len(parts) == 0 {
 			continue
 		}
-		et := parts[0]
-		for _, typ := range enabledTypes {
-			if strings.EqualFold(et, typ) || strings.EqualFold(et+"s", typ) || strings.EqualFold(et, typ+"s") {
-				return true
-			}
+		if typ, ok := matchEnabledType(parts[0], enabledTypes); ok {
+			counts[typ]++
 		}
 	}
-	return false
+	return counts
 }
 
 // SyncMediaType syncs Radarr or Sonarr depending on mediaType
@@ -1400,7 +2290,7 @@ func SyncMediaType(mediaType MediaType) error {
 	case MediaTypeMovie:
 		return SyncMedia(
 			"radarr",
-			"/api/v3/movie",
+			arrAPIPath("movie"),
 			MoviesStoreKey,
 			func(m map[string]interface{}) bool {
 				hasFile, ok := m["hasFile"].(bool)
@@ -1412,7 +2302,7 @@ func SyncMediaType(mediaType MediaType) error {
 	case MediaTypeTV:
 		return SyncMedia(
 			"sonarr",
-			"/api/v3/series",
+			arrAPIPath("series"),
 			SeriesStoreKey,
 			func(m map[string]interface{}) bool {
 				stats, ok := m["statistics"].(map[string]interface{})
@@ -0,0 +1,71 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMediaHandlersETagAndConditionalGet(t *testing.T) {
+	CreateTempConfig(t)
+	SeedMovieWithPath(t, 9351)
+	defer GetStoreClient().Del(context.Background(), MoviesStoreKey)
+
+	r := NewTestRouter()
+	r.GET("/api/movies", GetMediaHandler(MoviesStoreKey, "id"))
+	r.GET("/api/movies/:id", GetMediaByIdHandler(MoviesStoreKey, "id"))
+	get := func(path, inm string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if inm != "" {
+			req.Header.Set("If-None-Match", inm)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/movies", "")
+	etag := w.Header().Get("ETag")
+	if w.Code != 200 || etag == "" {
+		t.Fatalf("expected 200 with an ETag, got %d etag=%q", w.Code, etag)
+	}
+	if w := get("/api/movies", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("expected 304 with an empty body, got %d body=%s", w.Code, w.Body.String())
+	}
+	if w := get("/api/movies/9351", `"other", W/`+etag); w.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for a weak match in a list, got %d", w.Code)
+	}
+
+	SeedMovieWithPath(t, 9352)
+	w = get("/api/movies", etag)
+	if w.Code != 200 || w.Header().Get("ETag") == etag {
+		t.Fatalf("expected a new ETag after SaveMediaToStore, got %d etag=%q", w.Code, w.Header().Get("ETag"))
+	}
+	if w := get("/api/movies/9352", ""); w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	// Single-item reads apply the path mappings at read time, so saving them
+	// must invalidate the ETag even though the media store is unchanged.
+	r.PUT("/api/settings/radarr/pathMappings", PutPathMappingsHandler("radarr"))
+	w = get("/api/movies/9352", "")
+	etag = w.Header().Get("ETag")
+	var before struct {
+		Item map[string]interface{} `json:"item"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &before)
+	from, _ := before.Item["path"].(string)
+	if from == "" {
+		t.Fatalf("expected a path on the seeded movie, got %s", w.Body.String())
+	}
+	body, _ := json.Marshal(map[string]interface{}{"pathMappings": []map[string]interface{}{{"from": from, "to": "/mapped"}}})
+	if w := DoRequest(r, "PUT", "/api/settings/radarr/pathMappings", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	w = get("/api/movies/9352", etag)
+	if w.Code != 200 || w.Header().Get("ETag") == etag {
+		t.Fatalf("expected a new ETag after saving path mappings, got %d etag=%q", w.Code, w.Header().Get("ETag"))
+	}
+}
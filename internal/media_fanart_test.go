@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMediaFanartFetchedCachedAndServed(t *testing.T) {
+	CreateTempConfig(t)
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/MediaCover/5/fanart-360.jpg" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("fanart-bytes"))
+	}))
+	defer ts.Close()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"url": ts.URL, "apiKey": "x"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cached := filepath.Join(MediaCoverPath, "Movies", "5", "fanart-360.jpg")
+	_ = os.Remove(cached)
+	defer os.Remove(cached)
+
+	r := NewTestRouter()
+	r.GET("/api/media/:mediaType/:id/fanart", MediaFanartHandler)
+	for i := 0; i < 2; i++ {
+		w := DoRequest(r, "GET", "/api/media/movie/5/fanart?size=360", nil)
+		if w.Code != 200 || w.Body.String() != "fanart-bytes" {
+			t.Fatalf("request %d: expected cached fanart, got %d body=%q", i, w.Code, w.Body.String())
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected a single upstream fetch, got %d", n)
+	}
+	if _, err := os.Stat(cached); err != nil {
+		t.Fatalf("expected fanart cached at %s: %v", cached, err)
+	}
+
+	if w := DoRequest(r, "GET", "/api/media/movie/5/fanart?size=999", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for unsupported size, got %d", w.Code)
+	}
+	if w := DoRequest(r, "GET", "/api/media/movie/6/fanart?size=360", nil); w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502 when the Arr server has no fanart, got %d", w.Code)
+	}
+}
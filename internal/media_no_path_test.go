@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDownloadSkippedForMediaWithoutPath(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["queueItemRemoveDelaySeconds"] = 0
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	const mediaId = 9401
+	const ytID = "no-path-yt"
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": mediaId, "title": "Not Imported", "path": ""}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer func() {
+		queueMutex.Lock()
+		delete(downloadStatusMap, ytID)
+		queueMutex.Unlock()
+	}()
+
+	if _, err := prepareDownloadInfo(MediaTypeMovie, mediaId, "Trailers", "Trailer", ytID, 0); !errors.Is(err, errMediaHasNoPath) {
+		t.Fatalf("expected errMediaHasNoPath, got %v", err)
+	}
+
+	item := DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Trailer", YouTubeID: ytID, Status: "queued"}
+	b, _ := json.Marshal(item)
+	if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+		t.Fatalf("RPush: %v", err)
+	}
+	if err := processQueueItem(ctx, 0, item); err != nil {
+		t.Fatalf("processQueueItem: %v", err)
+	}
+	if st := GetDownloadStatus(ytID); st.Status != StatusNoPath || st.Error == "" {
+		t.Fatalf("expected %q status with a reason, got %+v", StatusNoPath, st)
+	}
+	if entry, _ := GetExtraByYoutubeId(ctx, ytID, MediaTypeMovie, mediaId); entry != nil && entry.Status == "rejected" {
+		t.Fatalf("expected the extra not to be rejected so a later sync retries it")
+	}
+}
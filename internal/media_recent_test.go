@@ -0,0 +1,47 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestHandleNewItemsRecordsRecentFeed(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, RecentMediaStoreKey)
+	defer client.Del(ctx, RecentMediaStoreKey)
+
+	prev := []map[string]interface{}{{"id": 1.0, "title": "Old"}}
+	handleNewItems("radarr", []map[string]interface{}{{"id": 1.0, "title": "Old"}, {"id": 2.0, "title": "First New"}}, prev)
+	handleNewItems("sonarr", []map[string]interface{}{{"id": 7.0, "title": "New Show"}}, prev)
+	// A first sync (no previous items) is not a diff and records nothing.
+	handleNewItems("radarr", []map[string]interface{}{{"id": 3.0, "title": "Initial"}}, nil)
+
+	r := NewTestRouter()
+	RegisterRoutes(r)
+	w := DoRequest(r, "GET", "/api/media/recent", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Recent []RecentMedia `json:"recent"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Recent) != 2 || resp.Recent[0].ID != 7 || resp.Recent[0].MediaType != MediaTypeTV || resp.Recent[0].Provider != "sonarr" ||
+		resp.Recent[1].Title != "First New" || resp.Recent[1].AddedAt.IsZero() {
+		t.Fatalf("unexpected recent feed: %+v", resp.Recent)
+	}
+
+	w = DoRequest(r, "GET", "/api/media/recent?limit=1", nil)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.Recent) != 1 || resp.Recent[0].ID != 7 {
+		t.Fatalf("expected limit to keep the newest entry, got %+v", resp.Recent)
+	}
+	if w := DoRequest(r, "GET", "/api/media/recent?limit=0", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for an invalid limit, got %d", w.Code)
+	}
+}
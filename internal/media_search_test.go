@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestMediaListSearchFiltersAndPaginates(t *testing.T) {
+	CreateTempConfig(t)
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{
+		{"id": 1, "title": "The Matrix", "sortTitle": "matrix", "year": 1999, "wanted": true},
+		{"id": 2, "title": "The Matrix Reloaded", "sortTitle": "matrix reloaded", "year": 2003, "wanted": false},
+		{"id": 3, "title": "Alien", "sortTitle": "alien", "year": 1979, "wanted": true},
+		{"id": 4, "title": "Aliens", "sortTitle": "aliens", "year": 1986},
+	}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	defer GetStoreClient().Del(context.Background(), MoviesStoreKey)
+
+	r := NewTestRouter()
+	r.GET("/api/movies", GetMediaHandler(MoviesStoreKey, "id"))
+	list := func(query string) ([]int, int) {
+		t.Helper()
+		w := DoRequest(r, "GET", "/api/movies"+query, nil)
+		if w.Code != 200 {
+			t.Fatalf("%s: expected 200, got %d body=%s", query, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Items []struct {
+				ID int `json:"id"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		ids := make([]int, 0, len(resp.Items))
+		for _, it := range resp.Items {
+			ids = append(ids, it.ID)
+		}
+		total, _ := strconv.Atoi(w.Header().Get(HeaderTotalCount))
+		return ids, total
+	}
+
+	for query, want := range map[string][]int{
+		"":                                   {1, 2, 3, 4},
+		"?q=MATRIX":                          {1, 2},
+		"?q=aliens":                          {4},
+		"?year=1979":                         {3},
+		"?wanted=true":                       {1, 3},
+		"?wanted=false":                      {2, 4},
+		"?q=the&wanted=true":                 {1},
+		"?limit=2&offset=1":                  {2, 3},
+		"?offset=10":                         {},
+		"?sort=title":                        {3, 4, 1, 2},
+		"?sort=year&order=desc":              {2, 1, 4, 3},
+		"?sort=wanted&order=desc":            {1, 3, 2, 4},
+		"?sort=sortTitle&limit=2":            {3, 4},
+		"?wanted=true&sort=title&order=DESC": {1, 3},
+	} {
+		ids, total := list(query)
+		if len(ids) != len(want) {
+			t.Fatalf("%q: got ids %v, want %v", query, ids, want)
+		}
+		for i := range ids {
+			if ids[i] != want[i] {
+				t.Fatalf("%q: got ids %v, want %v", query, ids, want)
+			}
+		}
+		if query == "?limit=2&offset=1" && total != 4 {
+			t.Fatalf("expected total to count every match, got %d", total)
+		}
+	}
+	for _, bad := range []string{"?limit=0", "?offset=-1", "?year=abc", "?wanted=maybe", "?sort=rating", "?order=up"} {
+		if w := DoRequest(r, "GET", "/api/movies"+bad, nil); w.Code != 400 {
+			t.Fatalf("%s: expected 400, got %d", bad, w.Code)
+		}
+	}
+}
+
+func TestSeriesListSortsByNextAirDate(t *testing.T) {
+	CreateTempConfig(t)
+	if err := SaveMediaToStore(SeriesStoreKey, []map[string]interface{}{
+		// Shaped like Sonarr's /api/v3/series: ended series have no nextAiring.
+		{"id": 1, "title": "Ended", "status": "ended", "previousAiring": "2019-05-19T01:00:00Z", "seasons": []interface{}{map[string]interface{}{"seasonNumber": 1, "monitored": true}}},
+		{"id": 2, "title": "Later", "status": "continuing", "nextAiring": "2026-12-01T00:00:00Z", "previousAiring": "2026-06-01T00:00:00Z"},
+		{"id": 3, "title": "Sooner", "status": "continuing", "nextAiring": "2026-10-20T00:00:00Z", "previousAiring": "2026-10-13T00:00:00Z"},
+	}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	defer GetStoreClient().Del(context.Background(), SeriesStoreKey)
+
+	r := NewTestRouter()
+	r.GET("/api/series", GetMediaHandler(SeriesStoreKey, "id"))
+	for query, want := range map[string]string{
+		"?sort=airDate":            `[3,2,1]`,
+		"?sort=airDate&order=desc": `[2,3,1]`,
+	} {
+		w := DoRequest(r, "GET", "/api/series"+query, nil)
+		var resp struct {
+			Items []struct {
+				ID int `json:"id"`
+			} `json:"items"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		ids := make([]int, 0, len(resp.Items))
+		for _, it := range resp.Items {
+			ids = append(ids, it.ID)
+		}
+		if got, _ := json.Marshal(ids); string(got) != want {
+			t.Fatalf("%s: got ids %s, want %s", query, got, want)
+		}
+	}
+}
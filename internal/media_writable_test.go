@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestIsNotWritableError(t *testing.T) {
+	cases := map[error]bool{
+		&os.PathError{Op: "mkdir", Path: "/movies/X", Err: syscall.EACCES}:         true,
+		fmt.Errorf("wrapped: %w", &os.LinkError{Op: "rename", Err: syscall.EROFS}): true,
+		&os.PathError{Op: "mkdir", Path: "/movies/X", Err: syscall.ENOSPC}:         false,
+		errors.New("permission denied"):                                            false,
+	}
+	for err, want := range cases {
+		if got := isNotWritableError(err); got != want {
+			t.Fatalf("isNotWritableError(%v) = %v, want %v", err, got, want)
+		}
+	}
+}
+
+func TestCheckPathMappingsWritable(t *testing.T) {
+	CreateTempConfig(t)
+	missing := filepath.Join(t.TempDir(), "missing")
+	setMappings := func(targets ...string) {
+		cfg, err := readConfigFileRaw()
+		if err != nil {
+			t.Fatalf("read config: %v", err)
+		}
+		var pm []map[string]string
+		for _, to := range targets {
+			pm = append(pm, map[string]string{"from": "/movies", "to": to})
+		}
+		cfg["radarr"] = map[string]interface{}{"pathMappings": pm}
+		cfg["sonarr"] = map[string]interface{}{}
+		if err := writeConfigFile(cfg); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	setMappings()
+	if err := CheckPathMappingsWritable(); err != nil {
+		t.Fatalf("expected no error without mappings, got %v", err)
+	}
+	setMappings(missing)
+	if h := pathMappingsWritableHealthCheck(); h == nil || !strings.Contains(h.Message, "not writable") {
+		t.Fatalf("expected a not-writable health issue, got %+v", h)
+	}
+	setMappings(missing, t.TempDir())
+	if err := CheckPathMappingsWritable(); err != nil {
+		t.Fatalf("expected one writable target to pass, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"sort"
+	"sync"
+)
+
+// MediaServer is a media server told to rescan a media folder after an extra
+// was downloaded into it.
+type MediaServer interface {
+	Name() string
+	Enabled() bool
+	RefreshLibrary(media MediaRef) error
+}
+
+// MediaRef is the movie or series a media server refresh is for. Path is its
+// folder after path mappings, empty when unknown.
+type MediaRef struct {
+	Type MediaType
+	ID   int
+	Path string
+}
+
+// mediaServerFactory builds a MediaServer from its section of config.yml.
+type mediaServerFactory func() (MediaServer, error)
+
+// mediaServerFactories is the registry of media server integrations, keyed
+// by config section.
+var mediaServerFactories = map[string]mediaServerFactory{
+	"plex": newPlexServer,
+	"emby": newEmbyServer,
+}
+
+// enabledMediaServers builds every registered media server from the current
+// config and returns the enabled ones, ordered by config section. Servers
+// whose section cannot be read are skipped.
+func enabledMediaServers() []MediaServer {
+	names := make([]string, 0, len(mediaServerFactories))
+	for name := range mediaServerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var servers []MediaServer
+	for _, name := range names {
+		s, err := mediaServerFactories[name]()
+		if err != nil {
+			TrailarrLog(DEBUG, "MediaServer", "Skipping %s: %v", name, err)
+			continue
+		}
+		if s.Enabled() {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
+// refreshMediaServers asks every enabled media server to rescan media, in
+// parallel, and returns once all have answered. Failures are logged; they
+// never fail the download.
+func refreshMediaServers(media MediaRef) {
+	var wg sync.WaitGroup
+	for _, s := range enabledMediaServers() {
+		wg.Add(1)
+		go func(s MediaServer) {
+			defer wg.Done()
+			if err := s.RefreshLibrary(media); err != nil {
+				TrailarrLog(WARN, "MediaServer", "Failed to refresh %s for %s %d: %v", s.Name(), media.Type, media.ID, err)
+			}
+		}(s)
+	}
+	wg.Wait()
+}
+
+// plexServer refreshes the metadata of the Plex item matching the media.
+type plexServer struct {
+	cfg PlexConfig
+}
+
+func newPlexServer() (MediaServer, error) {
+	cfg, err := GetPlexConfig()
+	if err != nil {
+		return nil, err
+	}
+	return plexServer{cfg: cfg}, nil
+}
+
+func (plexServer) Name() string { return "Plex" }
+
+func (s plexServer) Enabled() bool {
+	return s.cfg.Enabled && s.cfg.Token != "" && s.cfg.IP != ""
+}
+
+// RefreshLibrary refreshes the Plex metadata of the movie or series.
+func (plexServer) RefreshLibrary(media MediaRef) error {
+	if media.Type == MediaTypeTV {
+		return RefreshPlexShowMetadata(media.ID)
+	}
+	return RefreshPlexMovieMetadata(media.ID)
+}
+
+// embyServer refreshes Emby through its library API.
+type embyServer struct {
+	cfg EmbyConfig
+}
+
+func newEmbyServer() (MediaServer, error) {
+	cfg, err := GetEmbyConfig()
+	if err != nil {
+		return nil, err
+	}
+	return embyServer{cfg: cfg}, nil
+}
+
+func (embyServer) Name() string { return "Emby" }
+
+func (s embyServer) Enabled() bool {
+	return s.cfg.Enabled && s.cfg.URL != "" && s.cfg.APIKey != ""
+}
+
+func (s embyServer) RefreshLibrary(media MediaRef) error {
+	return refreshEmbyLibrary(s.cfg, media.Path)
+}
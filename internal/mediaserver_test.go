@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+type fakeMediaServer struct {
+	name    string
+	enabled bool
+	err     error
+	mu      sync.Mutex
+	refs    []MediaRef
+}
+
+func (f *fakeMediaServer) Name() string  { return f.name }
+func (f *fakeMediaServer) Enabled() bool { return f.enabled }
+func (f *fakeMediaServer) RefreshLibrary(media MediaRef) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refs = append(f.refs, media)
+	return f.err
+}
+
+func TestRefreshMediaServersCallsEachEnabledServerOnce(t *testing.T) {
+	a := &fakeMediaServer{name: "A", enabled: true}
+	b := &fakeMediaServer{name: "B", enabled: true, err: errors.New("unreachable")}
+	off := &fakeMediaServer{name: "Off"}
+	orig := mediaServerFactories
+	mediaServerFactories = map[string]mediaServerFactory{
+		"a":      func() (MediaServer, error) { return a, nil },
+		"b":      func() (MediaServer, error) { return b, nil },
+		"off":    func() (MediaServer, error) { return off, nil },
+		"broken": func() (MediaServer, error) { return nil, errors.New("section missing") },
+	}
+	defer func() { mediaServerFactories = orig }()
+
+	if got := enabledMediaServers(); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Fatalf("expected the enabled servers in section order, got %v", got)
+	}
+	media := MediaRef{Type: MediaTypeMovie, ID: 42, Path: "/media/Film (2020)"}
+	refreshMediaServers(media)
+	for _, s := range []*fakeMediaServer{a, b} {
+		if len(s.refs) != 1 || s.refs[0] != media {
+			t.Fatalf("%s: expected one refresh of the media, got %v", s.name, s.refs)
+		}
+	}
+	if len(off.refs) != 0 {
+		t.Fatalf("expected a disabled server not to be refreshed, got %v", off.refs)
+	}
+}
+
+func TestPlexAndEmbyRegisteredFromConfig(t *testing.T) {
+	CreateTempConfig(t)
+	for _, name := range []string{"plex", "emby"} {
+		if _, ok := mediaServerFactories[name]; !ok {
+			t.Fatalf("expected %s to be registered", name)
+		}
+	}
+	if err := SaveEmbyConfig(EmbyConfig{URL: "http://emby:8096", APIKey: "k"}); err != nil {
+		t.Fatalf("SaveEmbyConfig: %v", err)
+	}
+	if err := SavePlexConfig(PlexConfig{Protocol: "http", IP: "plex", Port: 32400, Token: "t"}); err != nil {
+		t.Fatalf("SavePlexConfig: %v", err)
+	}
+	if got := enabledMediaServers(); len(got) != 0 {
+		t.Fatalf("expected no enabled servers while both are disabled, got %v", got)
+	}
+	if err := SaveEmbyConfig(EmbyConfig{URL: "http://emby:8096", APIKey: "k", Enabled: true}); err != nil {
+		t.Fatalf("SaveEmbyConfig: %v", err)
+	}
+	if got := enabledMediaServers(); len(got) != 1 || got[0].Name() != "Emby" {
+		t.Fatalf("expected only Emby enabled, got %v", got)
+	}
+}
@@ -52,6 +52,7 @@ func TestMain(m *testing.M) {
 	TooManyRequestsPauseLogInterval = 10 * time.Millisecond
 	TasksDepsWaitInterval = 10 * time.Millisecond
 	TasksInitialDelay = 10 * time.Millisecond
+	ProviderRetryDelay = 10 * time.Millisecond
 
 	// Run tests
 	code := m.Run()
@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Values of general.metadataFormat, selecting the sidecars written next to a
+// downloaded extra.
+const (
+	MetadataFormatJSON = "json"
+	MetadataFormatNFO  = "nfo"
+	MetadataFormatBoth = "both"
+)
+
+const nfoSuffix = ".nfo"
+
+// extraNFO is the Kodi NFO written for an extra. Movie extras use a <movie>
+// root; series extras use <musicvideo>, the Kodi type for standalone clips.
+type extraNFO struct {
+	XMLName   xml.Name    `xml:""`
+	Title     string      `xml:"title"`
+	Genre     string      `xml:"genre,omitempty"`
+	UniqueID  nfoUniqueID `xml:"uniqueid"`
+	DateAdded string      `xml:"dateadded,omitempty"`
+}
+
+type nfoUniqueID struct {
+	Type    string `xml:"type,attr"`
+	Default bool   `xml:"default,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// extraNFOPath returns the NFO path of an extra file: "<dir>/<title>.nfo".
+func extraNFOPath(extraFile string) string {
+	return strings.TrimSuffix(extraFile, filepath.Ext(extraFile)) + nfoSuffix
+}
+
+// writeExtraNFO writes the Kodi NFO of a downloaded extra next to outFile.
+func writeExtraNFO(meta *ExtraDownloadMetadata, outFile string) error {
+	root := "movie"
+	if meta.MediaType == MediaTypeTV {
+		root = "musicvideo"
+	}
+	nfo := extraNFO{
+		XMLName:   xml.Name{Local: root},
+		Title:     meta.ExtraTitle,
+		Genre:     meta.ExtraType,
+		UniqueID:  nfoUniqueID{Type: "youtube", Default: true, Value: meta.YouTubeID},
+		DateAdded: time.Now().Format("2006-01-02 15:04:05"),
+	}
+	b, err := xml.MarshalIndent(nfo, "", "  ")
+	if err != nil {
+		return err
+	}
+	data := append([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`+"\n"), b...)
+	return os.WriteFile(extraNFOPath(outFile), append(data, '\n'), 0644)
+}
+
+// readExtraNFO parses an extra NFO written by writeExtraNFO (or by hand with
+// the same fields) regardless of its root element.
+func readExtraNFO(path string) (*extraNFO, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var nfo extraNFO
+	if err := xml.Unmarshal(data, &nfo); err != nil {
+		return nil, err
+	}
+	return &nfo, nil
+}
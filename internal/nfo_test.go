@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetadataFormatWritesAndReadsNFO(t *testing.T) {
+	CreateTempConfig(t)
+	setFormat := func(f string) {
+		cfg, err := readConfigFileRaw()
+		if err != nil {
+			t.Fatalf("read config: %v", err)
+		}
+		cfg["general"].(map[string]interface{})["metadataFormat"] = f
+		if err := writeConfigFile(cfg); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	tmp := t.TempDir()
+	sub := filepath.Join(tmp, "Trailers")
+	_ = os.MkdirAll(sub, 0o755)
+	outFile := filepath.Join(sub, "Teaser.mkv")
+	_ = os.WriteFile(outFile, []byte("dummy"), 0o644)
+	// Left behind by a manual download while queued.
+	_ = os.WriteFile(outFile+".json", []byte(`{"status":"queued"}`), 0o644)
+	meta := &ExtraDownloadMetadata{MediaType: MediaTypeMovie, MediaId: 1, ExtraType: "Trailers", ExtraTitle: "Teaser", YouTubeID: "nfo-yt", FileName: outFile, Status: "downloaded"}
+
+	setFormat(MetadataFormatNFO)
+	writeMetaFile(meta, outFile)
+	if _, err := os.Stat(outFile + ".json"); !os.IsNotExist(err) {
+		t.Fatalf("expected no .mkv.json with metadataFormat nfo, stat err=%v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(sub, "Teaser.nfo"))
+	if err != nil || !strings.Contains(string(data), "<movie>") || !strings.Contains(string(data), `<uniqueid type="youtube" default="true">nfo-yt</uniqueid>`) {
+		t.Fatalf("expected a Kodi movie NFO, got %q err=%v", data, err)
+	}
+	res := collectExistingFromSubdir(sub, map[string]int{})
+	if len(res) != 1 || res[0]["extraTitle"] != "Teaser" || res[0]["YoutubeId"] != "nfo-yt" || res[0]["status"] != "downloaded" {
+		t.Fatalf("expected the extra read from its NFO, got %v", res)
+	}
+
+	setFormat(MetadataFormatBoth)
+	writeMetaFile(meta, outFile)
+	if _, err := os.Stat(outFile + ".json"); err != nil {
+		t.Fatalf("expected .mkv.json with metadataFormat both: %v", err)
+	}
+	if info := scanExtrasInfo(tmp); len(info["Trailers"]) != 1 {
+		t.Fatalf("expected one extra when both sidecars exist, got %v", info)
+	}
+	_ = os.Remove(outFile + ".json")
+	if info := scanExtrasInfo(tmp); len(info["Trailers"]) != 1 || info["Trailers"][0]["YoutubeId"] != "nfo-yt" {
+		t.Fatalf("expected the NFO-only extra scanned, got %v", info)
+	}
+}
@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPathMappingsEndpointsLeaveProviderSettings(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"url": "http://radarr:7878", "apiKey": "keep-me", "pathMappings": []map[string]interface{}{{"from": "/old", "to": "/media/old"}}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	const path = "/api/settings/radarr/pathMappings"
+	r := NewTestRouter()
+	r.GET(path, GetPathMappingsHandler("radarr"))
+	r.PUT(path, PutPathMappingsHandler("radarr"))
+
+	body, _ := json.Marshal(map[string]interface{}{"pathMappings": []map[string]interface{}{
+		{"from": "/movies", "to": "/media/movies"},
+		{"from": "/movies", "to": "/duplicate"},
+		{"from": "^/data/", "to": "", "regex": true},
+	}})
+	if w := DoRequest(r, "PUT", path, body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	w := DoRequest(r, "GET", path, nil)
+	var resp struct {
+		PathMappings []struct {
+			From  string `json:"from"`
+			To    string `json:"to"`
+			Regex bool   `json:"regex"`
+		} `json:"pathMappings"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.PathMappings) != 2 || resp.PathMappings[0].To != "/media/movies" || !resp.PathMappings[1].Regex {
+		t.Fatalf("expected deduplicated mappings, got %+v", resp.PathMappings)
+	}
+	stored, _ := readConfigFile()
+	if sec := stored["radarr"].(map[string]interface{}); sec["url"] != "http://radarr:7878" || sec["apiKey"] != "keep-me" {
+		t.Fatalf("expected url and apiKey untouched, got %v", sec)
+	}
+
+	for _, bad := range []map[string]interface{}{
+		{"pathMappings": []map[string]interface{}{{"from": "/movies", "to": ""}}},
+		{"pathMappings": []map[string]interface{}{{"from": "^/data/(", "to": "/x", "regex": true}}},
+		{},
+	} {
+		body, _ := json.Marshal(bad)
+		if w := DoRequest(r, "PUT", path, body); w.Code != 400 {
+			t.Fatalf("expected 400 for %v, got %d", bad, w.Code)
+		}
+	}
+}
+
+func TestNestedPathMappingsPreferLongestPrefix(t *testing.T) {
+	CreateTempConfig(t)
+	mappings := [][]string{{"/data", "/movies"}, {"/data/4k", "/movies4k"}}
+	item := map[string]interface{}{"path": "/data/4k/Film (2020)"}
+	updateItemPath(item, mappings, MediaTypeMovie)
+	if item["path"] != "/movies4k/Film (2020)" {
+		t.Fatalf("expected the nested mapping applied, got %v", item["path"])
+	}
+	item = map[string]interface{}{"path": "/data/hd/Film (2020)"}
+	updateItemPath(item, mappings, MediaTypeMovie)
+	if item["path"] != "/movies/hd/Film (2020)" {
+		t.Fatalf("expected the outer mapping for other paths, got %v", item["path"])
+	}
+
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": 9501, "title": "Film", "path": "/data/4k/Film (2020)"}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	cacheFile, _ := resolveCachePath(MediaTypeMovie)
+	if got := findMappedMediaPath(cacheFile, mappings, 9501); got != "/movies4k/Film (2020)" {
+		t.Fatalf("findMappedMediaPath = %q, want the nested mapping", got)
+	}
+
+	r := NewTestRouter()
+	r.PUT("/api/settings/radarr/pathMappings", PutPathMappingsHandler("radarr"))
+	body, _ := json.Marshal(map[string]interface{}{"pathMappings": []map[string]interface{}{{"from": "/data", "to": "/movies"}, {"from": "/data/4k", "to": "/movies4k"}}})
+	w := DoRequest(r, "PUT", "/api/settings/radarr/pathMappings", body)
+	var resp struct {
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != 200 || len(resp.Warnings) != 1 {
+		t.Fatalf("expected one overlap warning, got %d body=%s", w.Code, w.Body.String())
+	}
+}
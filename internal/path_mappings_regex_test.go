@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRegexPathMappingsAppliedAndValidated(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"pathMappings": []map[string]interface{}{
+		{"from": `^/data/(4k|hd)/`, "to": "/media/$1/", "regex": true},
+		{"from": `^/strip`, "to": "", "regex": true},
+		{"from": "/movies", "to": "/media/movies"},
+	}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	mappings, err := GetPathMappings(MediaTypeMovie)
+	if err != nil || len(mappings) != 3 {
+		t.Fatalf("expected 3 mappings, got %v err=%v", mappings, err)
+	}
+
+	for in, want := range map[string]string{
+		"/data/4k/Film (2020)":   "/media/4k/Film (2020)",
+		"/strip/Film (2020)":     "/Film (2020)",
+		"/movies/Film (2020)":    "/media/movies/Film (2020)",
+		"/elsewhere/Film (2020)": "/elsewhere/Film (2020)",
+	} {
+		item := map[string]interface{}{"path": in}
+		updateItemPath(item, mappings, MediaTypeMovie)
+		if item["path"] != want {
+			t.Fatalf("updateItemPath(%q) = %v, want %q", in, item["path"], want)
+		}
+	}
+
+	// An invalid regex written to config.yml directly is left out on load.
+	cfg["radarr"] = map[string]interface{}{"pathMappings": []map[string]interface{}{
+		{"from": `^/data/(`, "to": "/media", "regex": true},
+		{"from": "/movies", "to": "/media/movies"},
+	}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if mappings, _ := GetPathMappings(MediaTypeMovie); len(mappings) != 1 || isRegexPathMapping(mappings[0]) {
+		t.Fatalf("expected only the literal mapping, got %v", mappings)
+	}
+
+	r := NewTestRouter()
+	r.POST(radarrSettingsPath, SaveSettingsHandler("radarr"))
+	body, _ := json.Marshal(map[string]interface{}{"providerURL": "http://radarr", "apiKey": "x", "pathMappings": []map[string]interface{}{{"from": "^/data/(", "to": "/media", "regex": true}}})
+	if w := DoRequest(r, "POST", radarrSettingsPath, body); w.Code != 400 {
+		t.Fatalf("expected 400 for an invalid regex, got %d body=%s", w.Code, w.Body.String())
+	}
+	body, _ = json.Marshal(map[string]interface{}{"providerURL": "http://radarr", "apiKey": "x", "pathMappings": []map[string]interface{}{{"from": "^/data/", "to": "/media/", "regex": true}}})
+	if w := DoRequest(r, "POST", radarrSettingsPath, body); w.Code != 200 {
+		t.Fatalf("expected 200 for a valid regex, got %d body=%s", w.Code, w.Body.String())
+	}
+	if mappings, _ := GetPathMappings(MediaTypeMovie); len(mappings) != 1 || !isRegexPathMapping(mappings[0]) {
+		t.Fatalf("expected the regex flag to be stored, got %v", mappings)
+	}
+	// Only the patterns of the current mappings stay compiled.
+	pathMappingRegexpsMu.Lock()
+	cached := pathMappingRegexps["radarr"]
+	pathMappingRegexpsMu.Unlock()
+	if len(cached) != 1 || cached["^/data/"] == nil {
+		t.Fatalf("expected only the saved pattern cached, got %v", cached)
+	}
+}
+
+func TestNormalizedPathMappingsIgnoreCaseAndSeparators(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"pathMappings": []map[string]interface{}{{"from": `C:\Movies`, "to": "/movies"}}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	item := map[string]interface{}{"path": `c:/MOVIES\Film (2020)`}
+	mappings, _ := GetPathMappings(MediaTypeMovie)
+	updateItemPath(item, mappings, MediaTypeMovie)
+	if item["path"] != `c:/MOVIES\Film (2020)` {
+		t.Fatalf("expected no match with normalization disabled, got %v", item["path"])
+	}
+
+	cfg["general"].(map[string]interface{})["normalizePathMappings"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	mappings, _ = GetPathMappings(MediaTypeMovie)
+	updateItemPath(item, mappings, MediaTypeMovie)
+	if item["path"] != "/movies/Film (2020)" {
+		t.Fatalf("expected normalized match, got %v", item["path"])
+	}
+	if stored, _ := readConfigFile(); stored["radarr"].(map[string]interface{})["pathMappings"].([]interface{})[0].(map[string]interface{})["from"] != `C:\Movies` {
+		t.Fatalf("expected the stored mapping to keep its original form")
+	}
+}
@@ -35,10 +35,7 @@ func performPlexSearch(base, title, token string, plexType int) (int, []byte, st
 	req.Header.Set(PlexHeader, token)
 	req.Header.Set("Accept", "application/json")
 	// Log search request (mask token)
-	masked := token
-	if len(masked) > 8 {
-		masked = masked[:4] + "..." + masked[len(masked)-4:]
-	}
+	masked := maskSecret(token)
 	TrailarrLog(INFO, "Plex", "performPlexSearch: GET %s header %s=%s Accept=application/json", u, PlexHeader, masked)
 	client := &http.Client{Timeout: 10 * time.Second}
 	sresp, sErr := client.Do(req)
@@ -252,10 +249,7 @@ func tryCandidatesFromMap(it map[string]interface{}, base, token string) (bool,
 
 // tryRefreshRatingKey attempts a single refresh for ratingKey and returns true on success (200/204).
 func tryRefreshRatingKey(base, ratingKey, token string) (bool, error) {
-	masked := token
-	if len(masked) > 8 {
-		masked = masked[:4] + "..." + masked[len(masked)-4:]
-	}
+	masked := maskSecret(token)
 	TrailarrLog(INFO, "Plex", "tryRefreshRatingKey: attempting item refresh for ratingKey=%s base=%s token=%s", ratingKey, base, masked)
 	status, body, err := doRefreshRequest(base, ratingKey, token)
 	if err != nil {
@@ -335,10 +329,7 @@ func doRefreshRequest(base, ratingKey, token string) (int, string, error) {
 	rreq.Header.Set("Accept", "application/json")
 
 	// Log the outgoing refresh request (mask token)
-	masked := token
-	if len(masked) > 8 {
-		masked = masked[:4] + "..." + masked[len(masked)-4:]
-	}
+	masked := maskSecret(token)
 	TrailarrLog(INFO, "Plex", "doRefreshRequest: PUT %s tokenQuery=%s", refreshURL, masked)
 	client := &http.Client{Timeout: 10 * time.Second}
 	rresp, err := client.Do(rreq)
@@ -351,3 +342,46 @@ func doRefreshRequest(base, ratingKey, token string) (int, string, error) {
 	TrailarrLog(INFO, "Plex", "doRefreshRequest: response for %s status=%d bodyLen=%d", refreshURL, rresp.StatusCode, len(rbody))
 	return rresp.StatusCode, string(rbody), nil
 }
+
+// plexServerIdentity is what GET /identity reports about a Plex server.
+type plexServerIdentity struct {
+	Name              string `json:"serverName"`
+	MachineIdentifier string `json:"machineIdentifier"`
+	Version           string `json:"version"`
+}
+
+// fetchPlexIdentity calls /identity on the server in cfg with its token,
+// checking that it is reachable and accepts the token.
+func fetchPlexIdentity(cfg PlexConfig) (plexServerIdentity, error) {
+	base := fmt.Sprintf("%s://%s:%d", cfg.Protocol, cfg.IP, cfg.Port)
+	req, err := http.NewRequest("GET", base+"/identity", nil)
+	if err != nil {
+		return plexServerIdentity{}, err
+	}
+	req.Header.Set(PlexHeader, cfg.Token)
+	req.Header.Set("Accept", "application/json")
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return plexServerIdentity{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return plexServerIdentity{}, &providerStatusError{status: resp.StatusCode}
+	}
+	var body struct {
+		MediaContainer struct {
+			FriendlyName      string `json:"friendlyName"`
+			MachineIdentifier string `json:"machineIdentifier"`
+			Version           string `json:"version"`
+		} `json:"MediaContainer"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return plexServerIdentity{}, fmt.Errorf("decode plex identity: %w", err)
+	}
+	if body.MediaContainer.MachineIdentifier == "" {
+		return plexServerIdentity{}, fmt.Errorf("plex identity has no machineIdentifier")
+	}
+	mc := body.MediaContainer
+	return plexServerIdentity{Name: mc.FriendlyName, MachineIdentifier: mc.MachineIdentifier, Version: mc.Version}, nil
+}
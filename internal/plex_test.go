@@ -1,9 +1,15 @@
 package internal
 
 import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -130,3 +136,50 @@ func TestSavePlexConfigPreservesTokenAndClientId(t *testing.T) {
 		t.Fatalf("expected updated fields to be saved, got: %+v", got)
 	}
 }
+
+func TestSavePlexConfigHandlerTestsConnection(t *testing.T) {
+	CreateTempConfig(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/identity" || r.Header.Get(PlexHeader) != "GOOD" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"MediaContainer":{"friendlyName":"Living Room","machineIdentifier":"abc123","version":"1.40"}}`))
+	}))
+	defer ts.Close()
+	host, portStr, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+	body := func(token string) []byte {
+		b, _ := json.Marshal(PlexConfig{Protocol: "http", IP: host, Port: port, Token: token, Enabled: true})
+		return b
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/plex", SavePlexConfigHandler)
+	w := DoRequest(r, "POST", "/api/settings/plex?test=true", body("BAD"))
+	if w.Code != 400 || !strings.Contains(w.Body.String(), string(CodeUnauthorized)) {
+		t.Fatalf("expected 400 UNAUTHORIZED for a rejected token, got %d body=%s", w.Code, w.Body.String())
+	}
+	if got, _ := GetPlexConfig(); got.Token == "BAD" {
+		t.Fatalf("expected the failing config not to be saved")
+	}
+	if w := DoRequest(r, "POST", "/api/settings/plex?test=true&force=true", body("BAD")); w.Code != 200 {
+		t.Fatalf("expected a forced save to succeed, got %d body=%s", w.Code, w.Body.String())
+	}
+	if w := DoRequest(r, "POST", "/api/settings/plex", body("GOOD")); w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	// A blank token is tested with, and keeps, the stored one.
+	w = DoRequest(r, "POST", "/api/settings/plex?test=true", body(""))
+	var resp map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if resp["serverName"] != "Living Room" || resp["machineIdentifier"] != "abc123" {
+		t.Fatalf("expected the server identity, got %v", resp)
+	}
+	if got, _ := GetPlexConfig(); got.Token != "GOOD" {
+		t.Fatalf("expected the stored token preserved, got %q", got.Token)
+	}
+}
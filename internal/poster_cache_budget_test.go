@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPosterCachingSharesWorkerBudgetAcrossProviders(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["posterCacheWorkers"] = 3
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var inFlight, maxInFlight int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		_, _ = w.Write([]byte("img"))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	jobsFor := func(section string) []posterJob {
+		var jobs []posterJob
+		for i := 0; i < 6; i++ {
+			idDir := filepath.Join(dir, section, fmt.Sprint(i))
+			jobs = append(jobs, posterJob{fmt.Sprint(i), idDir, filepath.Join(idDir, "poster-500.jpg"), ts.URL + "/poster"})
+		}
+		return jobs
+	}
+	var wg sync.WaitGroup
+	var success int64
+	for _, section := range []string{"radarr", "sonarr"} {
+		wg.Add(1)
+		go func(section string) {
+			defer wg.Done()
+			ok, _ := processPosterJobs(jobsFor(section), GetPosterCacheWorkers(), section)
+			atomic.AddInt64(&success, ok)
+		}(section)
+	}
+	wg.Wait()
+
+	if success != 12 {
+		t.Fatalf("expected every poster cached, got %d", success)
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m > 3 {
+		t.Fatalf("expected at most 3 downloads in flight across both syncs, got %d", m)
+	}
+
+	cfg["general"].(map[string]interface{})["posterCacheWorkers"] = 0
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if n := GetPosterCacheWorkers(); n != DefaultPosterCacheWorkers {
+		t.Fatalf("expected the default for an invalid value, got %d", n)
+	}
+}
@@ -3,21 +3,28 @@ package internal
 import (
 	"context"
 	"net/http"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
 
-// rewriteTransport rewrites requests to api.themoviedb.org to point to the test server
+// rewriteTransport rewrites requests for hosts (api.themoviedb.org when
+// empty) to point to the test server
 type rewriteTransport struct {
 	base   http.RoundTripper
 	target string
+	hosts  []string
 }
 
 func (r *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// clone request to avoid mutating shared state
 	req2 := req.Clone(req.Context())
-	// if the request is intended for api.themoviedb.org, rewrite it
-	if req2.URL.Host == "api.themoviedb.org" || req2.URL.Host == "api.themoviedb.org:443" {
+	hosts := r.hosts
+	if len(hosts) == 0 {
+		hosts = []string{"api.themoviedb.org"}
+	}
+	if slices.Contains(hosts, strings.TrimSuffix(req2.URL.Host, ":443")) {
 		req2.URL.Scheme = "http"
 		req2.URL.Host = r.target
 	}
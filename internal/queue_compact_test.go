@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestCompactDownloadQueueTrimsTerminalEntries(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	statuses := []string{"downloaded", "failed", "exists", "rejected"}
+	for i := 0; i < 40; i++ {
+		item := DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: i, YouTubeID: fmt.Sprintf("yt-%02d", i), Status: statuses[i%len(statuses)]}
+		if i%10 == 0 {
+			item.Status = "queued"
+		}
+		b, _ := json.Marshal(item)
+		if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+	}
+
+	removed, err := CompactDownloadQueue(ctx, 5)
+	if err != nil {
+		t.Fatalf("CompactDownloadQueue: %v", err)
+	}
+	if removed != 31 {
+		t.Fatalf("expected 31 terminal entries removed, got %d", removed)
+	}
+	vals, _ := client.LRange(ctx, DownloadQueue, 0, -1)
+	var queued, terminal []string
+	for _, v := range vals {
+		var item DownloadQueueItem
+		_ = json.Unmarshal([]byte(v), &item)
+		if item.Status == "queued" {
+			queued = append(queued, item.YouTubeID)
+		} else {
+			terminal = append(terminal, item.YouTubeID)
+		}
+	}
+	if len(queued) != 4 {
+		t.Fatalf("expected all 4 queued items kept, got %v", queued)
+	}
+	want := []string{"yt-35", "yt-36", "yt-37", "yt-38", "yt-39"}
+	if fmt.Sprint(terminal) != fmt.Sprint(want) {
+		t.Fatalf("expected newest terminal entries %v kept, got %v", want, terminal)
+	}
+
+	if n, _ := CompactDownloadQueue(ctx, 0); n != 0 {
+		t.Fatalf("expected retention 0 to disable compaction, removed %d", n)
+	}
+}
@@ -0,0 +1,123 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBatchStatusReportsQueuePositionAndETA(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	recentDownloadDurationsMu.Lock()
+	origDurations := recentDownloadDurations
+	recentDownloadDurations = []time.Duration{20 * time.Second, 40 * time.Second}
+	recentDownloadDurationsMu.Unlock()
+	defer func() {
+		recentDownloadDurationsMu.Lock()
+		recentDownloadDurations = origDurations
+		recentDownloadDurationsMu.Unlock()
+	}()
+
+	for _, it := range []DownloadQueueItem{
+		{YouTubeID: "qp-done", Status: "downloaded"},
+		{YouTubeID: "qp-active", Status: "downloading"},
+		{YouTubeID: "qp-first", Status: "queued"},
+		{YouTubeID: "qp-second", Status: "queued"},
+	} {
+		b, _ := json.Marshal(it)
+		if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+	}
+	queueMutex.Lock()
+	downloadStatusMap["qp-second"] = &DownloadStatus{Status: "queued"}
+	queueMutex.Unlock()
+	defer func() {
+		queueMutex.Lock()
+		delete(downloadStatusMap, "qp-second")
+		queueMutex.Unlock()
+	}()
+
+	if idx, item, ok := NextQueuedItem(); !ok || idx != 2 || item.YouTubeID != "qp-first" {
+		t.Fatalf("expected qp-first next, got %d %+v %v", idx, item, ok)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/extras/status/batch", GetBatchDownloadStatusHandler)
+	w := DoRequest(r, "POST", "/api/extras/status/batch", []byte(`{"youtubeIds":["qp-done","qp-active","qp-first","qp-second"]}`))
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp BatchStatusResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string][2]int{"qp-done": {0, 0}, "qp-active": {0, 0}, "qp-first": {1, 30}, "qp-second": {2, 60}}
+	for id, pw := range want {
+		st := resp.Statuses[id]
+		if st == nil || st.QueuePosition != pw[0] || st.ETASeconds != pw[1] {
+			t.Fatalf("%s: expected position %d eta %d, got %+v", id, pw[0], pw[1], st)
+		}
+	}
+	queueMutex.Lock()
+	mapped := downloadStatusMap["qp-second"].QueuePosition
+	queueMutex.Unlock()
+	if mapped != 0 {
+		t.Fatalf("expected in-memory status left untouched, got position %d", mapped)
+	}
+}
+
+func TestBatchStatusIncludesMediaContext(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	b, _ := json.Marshal(DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 4901, ExtraType: "Trailers", ExtraTitle: "Queued One", YouTubeID: "ctx-queued", Status: "queued"})
+	if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+		t.Fatalf("RPush: %v", err)
+	}
+	entry := ExtrasEntry{MediaType: MediaTypeTV, MediaId: 4902, ExtraType: "Featurettes", ExtraTitle: "Stored One", YoutubeId: "ctx-stored", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, entry); err != nil {
+		t.Fatalf("AddOrUpdateExtra: %v", err)
+	}
+	defer RemoveExtra(ctx, "ctx-stored", MediaTypeTV, 4902)
+
+	r := NewTestRouter()
+	r.POST("/api/extras/status/batch", GetBatchDownloadStatusHandler)
+	decode := func(body string) BatchStatusResponse {
+		w := DoRequest(r, "POST", "/api/extras/status/batch", []byte(body))
+		if w.Code != 200 {
+			t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+		}
+		var resp BatchStatusResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp
+	}
+
+	if resp := decode(`{"youtubeIds":["ctx-queued"]}`); resp.Statuses["ctx-queued"].MediaId != 0 {
+		t.Fatalf("expected no media context without includeMedia, got %+v", resp.Statuses["ctx-queued"])
+	}
+	if resp := decode(`{"youtubeIds":["ctx-stored"],"includeMedia":true}`); resp.Statuses["ctx-stored"].MediaId != 0 {
+		t.Fatalf("expected stored extras looked up only for the requested media, got %+v", resp.Statuses["ctx-stored"])
+	}
+	resp := decode(`{"youtubeIds":["ctx-queued","ctx-stored","ctx-unknown"],"includeMedia":true,"mediaType":"tv","mediaId":4902}`)
+	if st := resp.Statuses["ctx-queued"]; st.MediaType != MediaTypeMovie || st.MediaId != 4901 || st.ExtraTitle != "Queued One" {
+		t.Fatalf("unexpected queued context: %+v", st)
+	}
+	if st := resp.Statuses["ctx-stored"]; st.MediaType != MediaTypeTV || st.MediaId != 4902 || st.ExtraType != "Featurettes" {
+		t.Fatalf("unexpected stored context: %+v", st)
+	}
+	if st := resp.Statuses["ctx-unknown"]; st.MediaId != 0 || st.Status != "missing" {
+		t.Fatalf("unexpected unknown status: %+v", st)
+	}
+}
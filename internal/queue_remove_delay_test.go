@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestQueueItemRemoveDelayFromConfig(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	const mediaId = 4811
+	const ytID = "remove-delay-yt"
+
+	mediaDir := filepath.Join(TrailarrRoot, "media", "Remove Delay Movie")
+	if err := os.MkdirAll(mediaDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["queueItemRemoveDelaySeconds"] = 0.2
+	cfg["radarr"] = map[string]interface{}{"pathMappings": []map[string]string{{"from": mediaDir, "to": mediaDir}}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if got := GetQueueItemRemoveDelay(); got != 200*time.Millisecond {
+		t.Fatalf("expected configured delay of 200ms, got %v", got)
+	}
+	item := map[string]interface{}{"id": mediaId, "title": "Remove Delay Movie", "path": mediaDir}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{item}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+	defer RemoveExtra(ctx, ytID, MediaTypeMovie, mediaId)
+
+	queued := DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: "Delay Trailer", YouTubeID: ytID, Status: "queued"}
+	b, _ := json.Marshal(queued)
+	if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+		t.Fatalf("RPush: %v", err)
+	}
+
+	done := make(chan time.Duration, 1)
+	start := time.Now()
+	go func() {
+		_ = processQueueItem(ctx, 0, queued)
+		done <- time.Since(start)
+	}()
+
+	// Mid-delay the finished item must still be visible in the queue.
+	time.Sleep(100 * time.Millisecond)
+	if vals, _ := client.LRange(ctx, DownloadQueue, 0, -1); len(vals) != 1 {
+		t.Fatalf("expected finished item to linger in the queue, got %v", vals)
+	}
+	select {
+	case elapsed := <-done:
+		if elapsed < 200*time.Millisecond {
+			t.Fatalf("item removed after %v, before the configured delay", elapsed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("processQueueItem did not finish")
+	}
+}
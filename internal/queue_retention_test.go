@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFinishedQueueItemsKeptAndExpired(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	const mediaId = 4821
+	mediaDir := filepath.Join(TrailarrRoot, "media", "Retention Movie")
+	if err := os.MkdirAll(mediaDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": mediaId, "title": "Retention Movie", "path": mediaDir}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	process := func(ytID string) {
+		defer RemoveExtra(ctx, ytID, MediaTypeMovie, mediaId)
+		item := DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: mediaId, ExtraType: "Trailers", ExtraTitle: ytID, YouTubeID: ytID, Status: "queued"}
+		b, _ := json.Marshal(item)
+		if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+		vals, _ := client.LRange(ctx, DownloadQueue, 0, -1)
+		_ = processQueueItem(ctx, len(vals)-1, item)
+	}
+
+	process("retention-removed")
+	if q := GetCurrentDownloadQueue(); len(q) != 0 {
+		t.Fatalf("expected the finished item removed by default, got %+v", q)
+	}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["keepFinishedQueueItems"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	process("retention-kept")
+	r := NewTestRouter()
+	r.GET("/api/download/queue", GetDownloadQueueHandler)
+	w := DoRequest(r, "GET", "/api/download/queue", nil)
+	var resp struct {
+		Queue []DownloadQueueItem `json:"queue"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Queue) != 1 {
+		t.Fatalf("expected the finished item kept, got %s", w.Body.String())
+	}
+	kept := resp.Queue[0]
+	if kept.YouTubeID != "retention-kept" || !isTerminalQueueStatus(kept.Status) || kept.FinishedAt.IsZero() {
+		t.Fatalf("expected a finished entry with its finish time, got %+v", kept)
+	}
+
+	if n, _ := ExpireFinishedQueueItems(ctx, time.Hour); n != 0 {
+		t.Fatalf("expected a recent entry to be kept, removed %d", n)
+	}
+	kept.FinishedAt = time.Now().Add(-2 * time.Hour)
+	b, _ := json.Marshal(kept)
+	_ = client.LSet(ctx, DownloadQueue, 0, b)
+	if n, _ := ExpireFinishedQueueItems(ctx, time.Hour); n != 1 {
+		t.Fatalf("expected the old entry to expire, removed %d", n)
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestUnstickRequeuesDownloadingItemsWithoutWorker(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	for _, it := range []DownloadQueueItem{
+		{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "stuck-yt", Status: "downloading"},
+		{MediaType: MediaTypeMovie, MediaId: 2, YouTubeID: "active-yt", Status: "downloading"},
+		{MediaType: MediaTypeMovie, MediaId: 3, YouTubeID: "done-yt", Status: "downloaded"},
+	} {
+		b, _ := json.Marshal(it)
+		if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+	}
+	setDownloadInFlight("active-yt", true)
+	defer setDownloadInFlight("active-yt", false)
+
+	r := NewTestRouter()
+	r.POST("/api/queue/unstick", UnstickDownloadQueueHandler)
+	w := DoRequest(r, "POST", "/api/queue/unstick", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Requeued int `json:"requeued"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.Requeued != 1 {
+		t.Fatalf("expected exactly one item re-queued, got %d", resp.Requeued)
+	}
+
+	vals, _ := client.LRange(ctx, DownloadQueue, 0, -1)
+	got := map[string]string{}
+	for _, v := range vals {
+		var it DownloadQueueItem
+		_ = json.Unmarshal([]byte(v), &it)
+		got[it.YouTubeID] = it.Status
+	}
+	want := map[string]string{"stuck-yt": "queued", "active-yt": "downloading", "done-yt": "downloaded"}
+	for id, status := range want {
+		if got[id] != status {
+			t.Fatalf("expected %s to be %q, got %q (all=%v)", id, status, got[id], got)
+		}
+	}
+}
+
+func TestUnstickStatusWritesDoNotRaceStatusReads(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, DownloadQueue)
+	defer client.Del(ctx, DownloadQueue)
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = GetDownloadStatus("race-yt")
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		b, _ := json.Marshal(DownloadQueueItem{MediaType: MediaTypeMovie, MediaId: 4, YouTubeID: "race-yt", Status: "downloading"})
+		if err := client.RPush(ctx, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+		if _, err := UnstickDownloadQueue(ctx); err != nil {
+			t.Fatalf("UnstickDownloadQueue: %v", err)
+		}
+	}
+	close(stop)
+	<-done
+	if st := GetDownloadStatus("race-yt"); st.Status != "queued" {
+		t.Fatalf("expected the re-queued status, got %+v", st)
+	}
+}
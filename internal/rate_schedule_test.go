@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduledRatePicksWindowForClockTime(t *testing.T) {
+	cfg := DefaultYtdlpFlagsConfig()
+	cfg.LimitRate = "30M"
+	cfg.RateSchedule = []RateWindow{
+		{Start: "09:00", End: "18:00", Rate: "2M"},
+		{Start: "23:00", End: "06:00", Rate: ""},
+	}
+	at := func(h, m int) time.Time { return time.Date(2024, 5, 6, h, m, 0, 0, time.Local) }
+	for _, tc := range []struct {
+		now  time.Time
+		want string
+	}{
+		{at(8, 59), "30M"},
+		{at(9, 0), "2M"},
+		{at(17, 59), "2M"},
+		{at(18, 0), "30M"},
+		{at(23, 30), ""},
+		{at(2, 0), ""},
+		{at(6, 0), "30M"},
+	} {
+		if got := scheduledRate(cfg, tc.now); got != tc.want {
+			t.Fatalf("at %s expected rate %q, got %q", tc.now.Format("15:04"), tc.want, got)
+		}
+	}
+
+	cfg.RateSchedule = []RateWindow{{Start: "25:00", End: "06:00", Rate: "1M"}}
+	if err := ValidateRateSchedule(cfg.RateSchedule); err == nil {
+		t.Fatalf("expected an invalid start time to be rejected")
+	}
+	if got := scheduledRate(cfg, at(2, 0)); got != "30M" {
+		t.Fatalf("expected limitRate for an invalid schedule, got %q", got)
+	}
+}
+
+func TestRateScheduleRoundTripsThroughConfig(t *testing.T) {
+	CreateTempConfig(t)
+	cfg := DefaultYtdlpFlagsConfig()
+	cfg.RateSchedule = []RateWindow{{Start: "09:00", End: "18:00", Rate: "2M"}}
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	got, _ := GetYtdlpFlagsConfig()
+	if len(got.RateSchedule) != 1 || got.RateSchedule[0] != cfg.RateSchedule[0] {
+		t.Fatalf("expected schedule to round-trip, got %+v", got.RateSchedule)
+	}
+}
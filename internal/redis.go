@@ -456,6 +456,18 @@ func PingStore(ctx context.Context) error {
 	return c.Ping(ctx)
 }
 
+// StoreReady reports whether a store client has already been initialised and
+// answers a ping. Unlike PingStore it never creates the client.
+func StoreReady(ctx context.Context) error {
+	storeMu.Lock()
+	sc := storeClient
+	storeMu.Unlock()
+	if sc == nil {
+		return ErrNoStoreClient
+	}
+	return sc.Ping(ctx)
+}
+
 // ---- adapter methods ----
 // No adapter methods beyond the Store methods are provided — callers should
 // call `GetStoreClient()` and use the Store methods directly
@@ -57,6 +57,9 @@ func registerFaviconRoute(r *gin.Engine, distFS iofs.FS) {
 }
 
 func RegisterRoutes(r *gin.Engine) {
+	// Authentication must be installed first so it covers every route below.
+	r.Use(AuthMiddleware())
+
 	// Register grouped routes to keep this function small
 	registerCastRoutes(r)
 	registerYouTubeAndProxyRoutes(r)
@@ -98,15 +101,29 @@ func RegisterRoutes(r *gin.Engine) {
 
 	// Extras and history endpoints
 	r.POST("/api/extras/download", downloadExtraHandler)
+	r.POST("/api/extras/pin", PinExtraHandler)
+	r.DELETE("/api/extras/pin", UnpinExtraHandler)
 	r.DELETE("/api/extras", deleteExtraHandler)
+	r.POST("/api/extras/delete-batch", deleteExtraBatchHandler)
 	r.GET("/api/extras/existing", existingExtrasHandler)
+	r.POST("/api/extras/:mediaType/:id/refresh", RefreshTMDBExtrasHandler)
+	r.POST("/api/extras/:mediaType/:id/download-missing", DownloadMissingExtrasHandler)
+	r.GET("/api/extras/:mediaType/:id/summary", ExtrasSummaryHandler)
+	r.GET("/api/extras/:mediaType/:id/types", GetExtraTypesOverrideHandler)
+	r.PUT("/api/extras/:mediaType/:id/types", SetExtraTypesOverrideHandler)
+	r.DELETE("/api/extras/:mediaType/:id/types", ClearExtraTypesOverrideHandler)
+	r.GET("/api/extras/:mediaType/:id/:youtubeId", ExtraDetailHandler)
 	r.GET("/api/history", historyHandler)
+	r.DELETE("/api/history", clearHistoryHandler)
+	r.DELETE("/api/history/:index", deleteHistoryEventHandler)
 
 	// Extra types and canonicalize config endpoints
 	r.GET("/api/settings/extratypes", GetExtraTypesConfigHandler)
 	r.POST("/api/settings/extratypes", SaveExtraTypesConfigHandler)
 	r.GET("/api/settings/canonicalizeextratype", GetCanonicalizeExtraTypeConfigHandler)
 	r.POST("/api/settings/canonicalizeextratype", SaveCanonicalizeExtraTypeConfigHandler)
+	r.PUT("/api/extratypes/mapping/:tmdbType", PutCanonicalizeMappingEntryHandler)
+	r.DELETE("/api/extratypes/mapping/:tmdbType", DeleteCanonicalizeMappingEntryHandler)
 
 	// TMDB extra types endpoint
 	r.GET("/api/tmdb/extratypes", func(c *gin.Context) {
@@ -164,10 +181,13 @@ func registerCastRoutes(r *gin.Engine) {
 }
 
 func registerYouTubeAndProxyRoutes(r *gin.Engine) {
-	r.POST("/api/youtube/search", YouTubeTrailerSearchHandler)
-	r.GET("/api/youtube/search/stream", YouTubeTrailerSearchStreamHandler)
+	r.POST("/api/youtube/search", SearchRateLimitMiddleware(), YouTubeTrailerSearchHandler)
+	r.GET("/api/youtube/search/stream", SSELimitMiddleware(), SearchRateLimitMiddleware(), YouTubeTrailerSearchStreamHandler)
+	r.POST("/api/youtube/probe", SearchRateLimitMiddleware(), YouTubeProbeHandler)
 	r.GET("/api/proxy/youtube-image/:youtubeId", ProxyYouTubeImageHandler)
 	r.HEAD("/api/proxy/youtube-image/:youtubeId", ProxyYouTubeImageHandler)
+	r.GET("/api/media/:mediaType/:id/fanart", MediaFanartHandler)
+	r.GET("/api/media/recent", RecentMediaHandler)
 }
 
 func registerDownloadAndBlacklistRoutes(r *gin.Engine) {
@@ -192,10 +212,13 @@ func registerDownloadAndBlacklistRoutes(r *gin.Engine) {
 	// Download status endpoints
 	r.GET("/api/extras/status/:youtubeId", GetDownloadStatusHandler)
 	r.POST("/api/extras/status/batch", GetBatchDownloadStatusHandler)
-	// Start the download queue worker
-	StartDownloadQueueWorker()
 	r.GET("/api/blacklist/extras", BlacklistExtrasHandler)
 	r.POST("/api/blacklist/extras/remove", RemoveBlacklistExtraHandler)
+	r.POST("/api/queue/unstick", UnstickDownloadQueueHandler)
+	r.GET("/api/download/:youtubeId/log", GetDownloadLogHandler)
+	r.GET("/api/download/queue", GetDownloadQueueHandler)
+	r.POST("/api/download/pause", PauseDownloadsHandler)
+	r.POST("/api/download/resume", ResumeDownloadsHandler)
 }
 
 func registerTaskWebSocketRoutes(r *gin.Engine) {
@@ -217,9 +240,28 @@ func registerTaskWebSocketRoutes(r *gin.Engine) {
 		removeTaskStatusClient(conn)
 		conn.Close()
 	})
+	// WebSocket for live wanted (missing extras) counts
+	r.GET("/ws/wanted", func(c *gin.Context) {
+		wsUpgrader := getWebSocketUpgrader()
+		conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			TrailarrLog(WARN, "WS", "WebSocket upgrade failed: %v", err)
+			return
+		}
+		addWantedCountClient(conn)
+		for {
+			_, _, err := conn.ReadMessage()
+			if err != nil {
+				break
+			}
+		}
+		removeWantedCountClient(conn)
+		conn.Close()
+	})
 }
 
 func registerLogAndTMDBRoutes(r *gin.Engine) {
+	r.GET("/api/logs", logsTailHandler)
 	r.GET("/api/logs/list", logsListHandler)
 	r.GET("/api/test/tmdb", testTMDBHandler)
 }
@@ -335,6 +377,11 @@ func registerHealthAndTaskRoutes(r *gin.Engine) {
 		respondJSON(c, http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Container probes: process health only, unrelated to provider health
+	// issues. These must stay unauthenticated.
+	r.GET("/health/live", LivenessHandler)
+	r.GET("/health/ready", ReadinessHandler)
+
 	// Trigger an immediate healthcheck task run (used by UI test button)
 	// This route runs the health check synchronously and returns whether it
 	// succeeded (no issues) or failed (issues found / errors).
@@ -345,6 +392,7 @@ func registerHealthAndTaskRoutes(r *gin.Engine) {
 
 	// System status for UI Status page
 	r.GET("/api/system/status", SystemStatusHandler())
+	r.GET("/api/system/versions", SystemVersionsHandler)
 	r.POST("/api/system/update/ytdlp", handleYtdlpUpdate)
 	r.POST("/api/system/update/ffmpeg", handleFfmpegUpdate)
 
@@ -354,6 +402,12 @@ func registerHealthAndTaskRoutes(r *gin.Engine) {
 	// Debug endpoint: raw store contents and count
 	r.GET("/api/tasks/queue/debug", GetTaskQueueDebugHandler())
 	r.POST("/api/tasks/force", TaskHandler())
+	r.GET("/api/tasks/:taskId/history", GetTaskHistoryHandler())
+	r.POST("/api/tasks/:taskId/cancel", CancelTaskHandler())
+	r.POST("/api/debug/flush-caches", DebugEndpointsGuard(), FlushCachesHandler)
+	r.POST("/api/maintenance/rebuild-wanted-index", RebuildWantedIndexHandler)
+	r.POST("/api/maintenance/rebuild-rejected-index", RebuildRejectedIndexHandler)
+	r.POST("/api/maintenance/cleanup-mediacover", CleanupMediaCoverHandler)
 }
 
 // handleHealthExecute runs the health check synchronously and responds with success status.
@@ -619,14 +673,23 @@ func registerMediaAndSettingsRoutes(r *gin.Engine) {
 	for _, provider := range []string{"radarr", "sonarr"} {
 		r.GET("/api/settings/"+provider, GetSettingsHandler(provider))
 		r.POST("/api/settings/"+provider, SaveSettingsHandler(provider))
+		r.GET("/api/settings/"+provider+"/pathMappings", GetPathMappingsHandler(provider))
+		r.PUT("/api/settings/"+provider+"/pathMappings", PutPathMappingsHandler(provider))
+		r.POST("/api/settings/"+provider+"/test", TestSettingsConnectionHandler(provider))
+		r.POST("/api/webhook/"+provider, WebhookHandler(provider))
 	}
+	r.GET("/api/config", GetConfigHandler)
 	// General settings (TMDB key)
 	r.GET("/api/settings/general", getGeneralSettingsHandler)
 	r.POST("/api/settings/general", saveGeneralSettingsHandler)
+	r.GET("/api/settings/trustedproxies", GetTrustedProxiesHandler)
+	r.POST("/api/settings/trustedproxies", SaveTrustedProxiesHandler)
 
 	// Plex settings and OAuth
 	r.GET("/api/settings/plex", GetPlexConfigHandler)
 	r.POST("/api/settings/plex", SavePlexConfigHandler)
+	r.GET("/api/settings/emby", GetEmbyConfigHandler)
+	r.POST("/api/settings/emby", SaveEmbyConfigHandler)
 
 	r.GET("/api/plex/login", GetPlexOAuthLoginHandler)
 	r.POST("/api/plex/exchange", ExchangePlexCodeHandler)
@@ -77,4 +77,69 @@ func TestCanonicalizeSaveAndGet(t *testing.T) {
 	if cfg.Mapping == nil || cfg.Mapping["Trailer"] != "Trailers" {
 		t.Fatalf("expected mapping Trailer->Trailers, got %v", cfg.Mapping)
 	}
+
+	// Unknown TMDB keys are fine, but destinations must be Plex types.
+	payload = `{"mapping":{"Trailer":"Trailors","Clip":"Scenes","Teaser":"Teasers"}}`
+	w = DoRequest(r, "POST", "/api/settings/canonicalizeextratype", []byte(payload))
+	if w.Code != 400 {
+		t.Fatalf("expected 400 for invalid Plex types, got %d body=%s", w.Code, w.Body.String())
+	}
+	var bad struct {
+		Invalid []string `json:"invalid"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &bad); err != nil {
+		t.Fatalf("failed to parse error response: %v", err)
+	}
+	if len(bad.Invalid) != 2 || bad.Invalid[0] != "Teaser: Teasers" || bad.Invalid[1] != "Trailer: Trailors" {
+		t.Fatalf("expected both invalid entries listed, got %v", bad.Invalid)
+	}
+	if got, _ := GetCanonicalizeExtraTypeConfig(); got.Mapping["Trailer"] != "Trailers" {
+		t.Fatalf("expected rejected mapping not to be saved, got %v", got.Mapping)
+	}
+}
+
+func TestCanonicalizeMappingEntryEndpoints(t *testing.T) {
+	CreateTempConfig(t)
+	r := NewTestRouter()
+	RegisterRoutes(r)
+
+	payload := `{"mapping":{"Trailer":"Trailers","Featurette":"Featurettes"}}`
+	if w := DoRequest(r, "POST", "/api/settings/canonicalizeextratype", []byte(payload)); w.Code != 200 {
+		t.Fatalf("expected 200 saving canonicalize mapping, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	// add a new entry
+	if w := DoRequest(r, "PUT", "/api/extratypes/mapping/Clip", []byte(`{"plexType":"Scenes"}`)); w.Code != 200 {
+		t.Fatalf("expected 200 adding mapping entry, got %d body=%s", w.Code, w.Body.String())
+	}
+	// update an existing entry
+	if w := DoRequest(r, "PUT", "/api/extratypes/mapping/Featurette", []byte(`{"plexType":"Other"}`)); w.Code != 200 {
+		t.Fatalf("expected 200 updating mapping entry, got %d body=%s", w.Code, w.Body.String())
+	}
+	cfg, _ := GetCanonicalizeExtraTypeConfig()
+	want := map[string]string{"Trailer": "Trailers", "Featurette": "Other", "Clip": "Scenes"}
+	for k, v := range want {
+		if cfg.Mapping[k] != v {
+			t.Fatalf("expected %s->%s after PUT, got mapping %v", k, v, cfg.Mapping)
+		}
+	}
+
+	// delete one entry, others must remain
+	if w := DoRequest(r, "DELETE", "/api/extratypes/mapping/Clip", nil); w.Code != 200 {
+		t.Fatalf("expected 200 deleting mapping entry, got %d body=%s", w.Code, w.Body.String())
+	}
+	cfg, _ = GetCanonicalizeExtraTypeConfig()
+	if _, ok := cfg.Mapping["Clip"]; ok {
+		t.Fatalf("expected Clip removed, got %v", cfg.Mapping)
+	}
+	if cfg.Mapping["Trailer"] != "Trailers" || cfg.Mapping["Featurette"] != "Other" {
+		t.Fatalf("expected other entries preserved after DELETE, got %v", cfg.Mapping)
+	}
+
+	if w := DoRequest(r, "DELETE", "/api/extratypes/mapping/Clip", nil); w.Code != 404 {
+		t.Fatalf("expected 404 deleting missing entry, got %d", w.Code)
+	}
+	if w := DoRequest(r, "PUT", "/api/extratypes/mapping/Clip", []byte(`{}`)); w.Code != 400 {
+		t.Fatalf("expected 400 for missing plexType, got %d", w.Code)
+	}
 }
@@ -158,3 +158,96 @@ func TestExtrasDeleteAndExisting(t *testing.T) {
 	// cleanup
 	_ = GetStoreClient().Del(context.Background(), ExtrasStoreKey)
 }
+
+// TestExtrasDeleteWithoutMediaTitle deletes an extra whose media has no title
+// in the cache; the delete succeeds and history records "Unknown".
+func TestExtrasDeleteWithoutMediaTitle(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	entry := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: 901, ExtraType: "Trailers", ExtraTitle: "Untitled", YoutubeId: "y901", Status: "downloaded"}
+	if err := AddOrUpdateExtra(ctx, entry); err != nil {
+		t.Fatalf("failed to seed extra: %v", err)
+	}
+	defer GetStoreClient().Del(ctx, ExtrasStoreKey)
+	mediaPath := filepath.Join(TrailarrRoot, "m901")
+	_ = os.MkdirAll(filepath.Join(mediaPath, "Trailers"), 0755)
+	movie := map[string]interface{}{"id": 901, "path": mediaPath}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{movie}); err != nil {
+		t.Fatalf("failed to save media to store: %v", err)
+	}
+	_ = GetStoreClient().Del(ctx, HistoryStoreKey)
+	defer GetStoreClient().Del(ctx, HistoryStoreKey)
+
+	r := NewTestRouter()
+	RegisterRoutes(r)
+	w := DoRequest(r, "DELETE", "/api/extras", []byte(`{"mediaType":"movie","mediaId":901,"youtubeId":"y901"}`))
+	if w.Code != 200 {
+		t.Fatalf("expected 200 deleting extra, got %d body=%s", w.Code, w.Body.String())
+	}
+	events, err := LoadHistoryEvents()
+	if err != nil {
+		t.Fatalf("LoadHistoryEvents: %v", err)
+	}
+	if len(events) != 1 || events[0].Action != "delete" || events[0].MediaTitle != "Unknown" || events[0].MediaId != 901 {
+		t.Fatalf("expected a delete event titled Unknown, got %+v", events)
+	}
+	if err := recordDeleteHistory(MediaTypeMovie, 901, "Trailers", "Untitled"); err == nil {
+		t.Fatalf("expected recordDeleteHistory to report the missing title")
+	}
+}
+
+func TestExtrasDeleteBatchReportsPerItemResults(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	mediaPath := filepath.Join(TrailarrRoot, "m902")
+	_ = os.MkdirAll(filepath.Join(mediaPath, "Trailers"), 0755)
+	movie := map[string]interface{}{"id": 902, "title": "Batch", "path": mediaPath}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{movie}); err != nil {
+		t.Fatalf("failed to save media to store: %v", err)
+	}
+	for _, id := range []string{"b1", "b2"} {
+		entry := ExtrasEntry{MediaType: MediaTypeMovie, MediaId: 902, ExtraType: "Trailers", ExtraTitle: "Batch " + id, YoutubeId: id, Status: "downloaded"}
+		if err := AddOrUpdateExtra(ctx, entry); err != nil {
+			t.Fatalf("failed to seed extra: %v", err)
+		}
+		_ = os.WriteFile(filepath.Join(mediaPath, "Trailers", "Batch "+id+".mkv"), []byte("x"), 0644)
+	}
+	defer GetStoreClient().Del(ctx, ExtrasStoreKey)
+	defer GetStoreClient().Del(ctx, HistoryStoreKey)
+
+	r := NewTestRouter()
+	RegisterRoutes(r)
+	body := []byte(`[{"mediaType":"movie","mediaId":902,"youtubeId":"b1"},{"mediaType":"movie","mediaId":902,"youtubeId":"missing"},{"mediaType":"movie","mediaId":999999,"youtubeId":"b2"},{"mediaType":"movie","mediaId":902,"youtubeId":"b2"}]`)
+	w := DoRequest(r, "POST", "/api/extras/delete-batch", body)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Results []struct {
+			YoutubeId string `json:"youtubeId"`
+			Status    string `json:"status"`
+			Error     string `json:"error"`
+		} `json:"results"`
+		Deleted int `json:"deleted"`
+		Failed  int `json:"failed"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Deleted != 2 || resp.Failed != 2 || len(resp.Results) != 4 {
+		t.Fatalf("unexpected batch summary: %+v", resp)
+	}
+	for i, want := range []string{"deleted", "failed", "failed", "deleted"} {
+		if resp.Results[i].Status != want {
+			t.Fatalf("result %d: expected %s, got %+v", i, want, resp.Results[i])
+		}
+	}
+	for _, id := range []string{"b1", "b2"} {
+		if e, _ := GetExtraByYoutubeId(ctx, id, MediaTypeMovie, 902); e != nil {
+			t.Fatalf("expected %s removed from the store", id)
+		}
+		if _, err := os.Stat(filepath.Join(mediaPath, "Trailers", "Batch "+id+".mkv")); !os.IsNotExist(err) {
+			t.Fatalf("expected file of %s removed, stat err=%v", id, err)
+		}
+	}
+}
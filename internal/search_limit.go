@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// searchRateWindow is the sliding window used for the per-minute search cap.
+const searchRateWindow = time.Minute
+
+// searchLimiter tracks per-client-IP concurrent searches and recent search
+// start times for the YouTube search routes.
+type searchLimiter struct {
+	mu     sync.Mutex
+	active map[string]int
+	recent map[string][]time.Time
+}
+
+var youtubeSearchLimiter = newSearchLimiter()
+
+func newSearchLimiter() *searchLimiter {
+	return &searchLimiter{active: map[string]int{}, recent: map[string][]time.Time{}}
+}
+
+// acquire reserves a search slot for ip. It returns an empty string on
+// success or the reason the request was rejected. A limit <= 0 disables the
+// corresponding check.
+func (l *searchLimiter) acquire(ip string, maxConcurrent, perMinute int, now time.Time) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for k, times := range l.recent {
+		kept := times[:0]
+		for _, t := range times {
+			if now.Sub(t) < searchRateWindow {
+				kept = append(kept, t)
+			}
+		}
+		if len(kept) == 0 && l.active[k] == 0 {
+			delete(l.recent, k)
+			continue
+		}
+		l.recent[k] = kept
+	}
+	if maxConcurrent > 0 && l.active[ip] >= maxConcurrent {
+		return fmt.Sprintf("too many concurrent searches (limit %d)", maxConcurrent)
+	}
+	if perMinute > 0 && len(l.recent[ip]) >= perMinute {
+		return fmt.Sprintf("too many searches (limit %d per minute)", perMinute)
+	}
+	l.active[ip]++
+	l.recent[ip] = append(l.recent[ip], now)
+	return ""
+}
+
+func (l *searchLimiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.active[ip] <= 1 {
+		delete(l.active, ip)
+		return
+	}
+	l.active[ip]--
+}
+
+// SearchRateLimitMiddleware limits the YouTube search routes per client IP
+// (as resolved by gin from general.trustedProxies) to
+// general.searchMaxConcurrentPerIp simultaneous searches and
+// general.searchMaxPerMinute searches per minute. Rejected requests get 429
+// before any handler runs, so no SSE stream is opened for them.
+func SearchRateLimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ip := c.ClientIP()
+		reason := youtubeSearchLimiter.acquire(ip, GetSearchMaxConcurrentPerIP(), GetSearchMaxPerMinute(), time.Now())
+		if reason != "" {
+			TrailarrLog(WARN, "YouTube", "Rejecting search from %s: %s", ip, reason)
+			c.Header("Retry-After", "60")
+			respondError(c, http.StatusTooManyRequests, reason)
+			c.Abort()
+			return
+		}
+		defer youtubeSearchLimiter.release(ip)
+		c.Next()
+	}
+}
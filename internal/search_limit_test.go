@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSearchRateLimitMiddlewarePerIP(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["searchMaxConcurrentPerIp"] = 1
+	general["searchMaxPerMinute"] = 0
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	youtubeSearchLimiter = newSearchLimiter()
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	r := NewTestRouter()
+	r.GET("/search", SearchRateLimitMiddleware(), func(c *gin.Context) {
+		if c.Query("block") == "1" {
+			close(entered)
+			<-release
+		}
+		c.Status(http.StatusOK)
+	})
+	from := func(ip, path string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	done := make(chan int)
+	go func() { done <- from("10.0.0.1", "/search?block=1") }()
+	select {
+	case <-entered:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("first search never reached the handler")
+	}
+	if code := from("10.0.0.1", "/search"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for a second concurrent search from the same IP, got %d", code)
+	}
+	if code := from("10.0.0.2", "/search"); code != http.StatusOK {
+		t.Fatalf("expected another IP to be unaffected, got %d", code)
+	}
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Fatalf("expected first search to succeed, got %d", code)
+	}
+	if code := from("10.0.0.1", "/search"); code != http.StatusOK {
+		t.Fatalf("expected the slot to be released, got %d", code)
+	}
+}
+
+func TestSearchLimiterPerMinuteWindow(t *testing.T) {
+	l := newSearchLimiter()
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		if reason := l.acquire("ip", 0, 3, now); reason != "" {
+			t.Fatalf("search %d unexpectedly rejected: %s", i, reason)
+		}
+		l.release("ip")
+	}
+	if reason := l.acquire("ip", 0, 3, now.Add(30*time.Second)); reason == "" {
+		t.Fatalf("expected the fourth search within a minute to be rejected")
+	}
+	if reason := l.acquire("ip", 0, 3, now.Add(61*time.Second)); reason != "" {
+		t.Fatalf("expected the window to slide after a minute, got %s", reason)
+	}
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSeasonExtrasFetchedAndPlacedInSeasonFolder(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/3/tv/7701/videos":
+			_, _ = w.Write([]byte(`{"results":[{"id":"s","name":"Series Trailer","key":"yt-series","site":"YouTube","type":"Trailer"}]}`))
+		case "/3/tv/7701/season/1/videos":
+			_, _ = w.Write([]byte(`{"results":[{"id":"s1","name":"Season 1 Trailer","key":"yt-season1","site":"YouTube","type":"Trailer"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		}
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	seriesDir := filepath.Join(TrailarrRoot, "tv", "Season Show")
+	if err := os.MkdirAll(seriesDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["tmdbKey"] = "dummy"
+	general["seasonExtras"] = true
+	cfg["sonarr"] = map[string]interface{}{"pathMappings": []map[string]string{{"from": seriesDir, "to": seriesDir}}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+
+	const seriesId = 9341
+	series := []map[string]interface{}{{
+		"id": seriesId, "tmdbId": 7701, "title": "Season Show", "path": seriesDir,
+		"seasons": []map[string]interface{}{{"seasonNumber": 0}, {"seasonNumber": 1}, {"seasonNumber": 2}},
+	}}
+	if err := SaveMediaToStore(SeriesStoreKey, series); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+
+	extras, err := FetchTMDBExtrasForMedia(MediaTypeTV, seriesId)
+	if err != nil {
+		t.Fatalf("FetchTMDBExtrasForMedia: %v", err)
+	}
+	seasons := map[string]int{}
+	for _, e := range extras {
+		seasons[e.YoutubeId] = e.SeasonNumber
+	}
+	if len(seasons) != 2 || seasons["yt-series"] != 0 || seasons["yt-season1"] != 1 {
+		t.Fatalf("expected series and season 1 trailers, got %+v", extras)
+	}
+	for _, p := range paths {
+		if strings.Contains(p, "/season/0/") {
+			t.Fatalf("specials should not be requested, got %v", paths)
+		}
+	}
+
+	info, err := prepareDownloadInfo(MediaTypeTV, seriesId, "Trailer", "Season 1 Trailer", "yt-season1", 1)
+	if err != nil {
+		t.Fatalf("prepareDownloadInfo: %v", err)
+	}
+	defer os.RemoveAll(info.TempDir)
+	if want := filepath.Join(seriesDir, "Season 01", "Trailers"); info.OutDir != want {
+		t.Fatalf("expected season extra under %s, got %s", want, info.OutDir)
+	}
+}
@@ -77,3 +77,38 @@ func TestCalcNextAndBuildSchedules(t *testing.T) {
 		}
 	}
 }
+
+func TestDisabledTaskSkippedAndReported(t *testing.T) {
+	CreateTempConfig(t)
+	origTasksMeta, origTimings := tasksMeta, Timings
+	defer func() { tasksMeta, Timings = origTasksMeta, origTimings }()
+	noop := func() {}
+	tasksMeta = map[TaskID]TaskMeta{
+		"radarr": {ID: "radarr", Name: "Radarr", Function: noop, Order: 1},
+		"sonarr": {ID: "sonarr", Name: "Sonarr", Function: noop, Order: 2},
+	}
+	Timings = map[string]int{"radarr": 15, "sonarr": 15}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	cfg["tasks"] = map[string]interface{}{"radarr": map[string]interface{}{"enabled": false}}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	states := TaskStates{"radarr": {ID: "radarr", Status: "idle"}, "sonarr": {ID: "sonarr", Status: "idle"}}
+	bg := buildBgTasks(states, GetDisabledTasks())
+	if len(bg) != 1 || bg[0].id != "sonarr" {
+		t.Fatalf("expected only sonarr to be scheduled, got %+v", bg)
+	}
+
+	schedules := buildSchedules(states)
+	if schedules[0].Status != "disabled" || !schedules[0].NextExecution.IsZero() {
+		t.Fatalf("expected radarr reported as disabled without next execution, got %+v", schedules[0])
+	}
+	if schedules[1].Status != "idle" {
+		t.Fatalf("expected sonarr status idle, got %+v", schedules[1])
+	}
+}
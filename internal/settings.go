@@ -5,12 +5,19 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -31,13 +38,25 @@ const (
 	SeriesWantedStoreKey   = "trailarr:series:wanted"
 	ExtrasStoreKey         = "trailarr:extras"
 	RejectedExtrasStoreKey = "trailarr:extras:rejected"
-	DownloadQueue          = "trailarr:download_queue"
+	ExtraPinsStoreKey      = "trailarr:extra_pins"
+	// ExtraTypeOverridesStoreKey holds the per-media extra type overrides.
+	ExtraTypeOverridesStoreKey = "trailarr:extra_type_overrides"
+	DownloadQueue              = "trailarr:download_queue"
+	// DownloadQueuePausedKey is set while downloads are paused.
+	DownloadQueuePausedKey = "trailarr:download_queue:paused"
 	TaskTimesStoreKey      = "trailarr:task_times"
 	HealthIssuesStoreKey   = "trailarr:health_issues"
+	UpdateNoticesStoreKey  = "trailarr:update_notices"
 	HistoryStoreKey        = "trailarr:history"
-	HistoryMaxLen          = 1000
+	RecentMediaStoreKey    = "trailarr:media:recent"
+	RecentMediaMaxLen      = 200
+	TMDBExtrasCacheKeyFmt  = "trailarr:tmdb_extras:%s:%d"
+	HistoryMaxLen          = 1000 // default of general.historyMaxLen
 	TaskQueueStoreKey      = "trailarr:task_queue"
 	TaskQueueMaxLen        = 1000
+	DownloadLogsStoreKey   = "trailarr:download_logs"
+	DownloadLogMaxBytes    = 16 * 1024
+	DownloadLogMaxEntries  = 500
 	RemoteMediaCoverPath   = "/MediaCover/"
 	// MediaCoverRoute is the HTTP route prefix used to serve media cover images
 	// from the server. Keep this constant in sync with routes that register the
@@ -45,14 +64,96 @@ const (
 	MediaCoverRoute          = "/mediacover"
 	HeaderApiKey             = "X-Api-Key"
 	HeaderContentType        = "Content-Type"
+	HeaderTotalCount         = "X-Total-Count"
 	ErrInvalidSonarrSettings = "Invalid Sonarr settings"
 	ErrInvalidRequest        = "invalid request"
 	ErrSectionNotMap         = "section %s is not a map"
 )
 
+// ArrAPIVersion is the Radarr/Sonarr API version of every provider request.
+const ArrAPIVersion = "v3"
+
+// arrAPIPath returns the Radarr/Sonarr API path of resource, e.g.
+// "/api/v3/rootfolder".
+func arrAPIPath(resource string) string {
+	return "/api/" + ArrAPIVersion + "/" + resource
+}
+
+var (
+	// ProviderRequestTimeout bounds each Radarr/Sonarr settings request.
+	ProviderRequestTimeout = 10 * time.Second
+	// RootFolderFetchAttempts and ProviderRetryDelay control the retries of
+	// FetchRootFolders on network errors and 5xx responses.
+	RootFolderFetchAttempts = 3
+	ProviderRetryDelay      = 1 * time.Second
+)
+
 // Default frontend URL used when no config or env override is provided.
 const DefaultFrontendURL = "http://localhost:8080"
 
+// Default cap on concurrently open SSE streams.
+const DefaultMaxSSEClients = 10
+
+// Default per-client-IP limits for the YouTube search routes.
+const (
+	DefaultSearchMaxConcurrentPerIP = 2
+	DefaultSearchMaxPerMinute       = 30
+)
+
+// Default number of finished entries kept in the download queue.
+const DefaultDownloadQueueRetention = 100
+
+// Default proxies trusted for forwarded client IPs: IPv4 and IPv6 loopback.
+var DefaultTrustedProxies = []string{"127.0.0.1", "::1"}
+
+// Default fanart widths served by the on-demand fanart endpoint.
+var DefaultFanartSizes = []int{1280, 360, 180}
+
+// Default language TMDB videos are requested in.
+const DefaultTMDBLanguage = "en-US"
+
+// Default factor applied to the 429 pause for each consecutive 429.
+const DefaultTooManyRequestsBackoffMultiplier = 2.0
+
+// Default age in days after which cached MediaCover images are removed.
+const DefaultMediaCoverTTLDays = 30
+
+// Default cap on simultaneous TMDB extras fetches.
+const DefaultMaxConcurrentTMDBFetches = 4
+
+// Default cap on yt-dlp processes (searches and downloads) running at once.
+const DefaultMaxYtdlpProcesses = 3
+
+// Default number of poster downloads in flight across radarr and sonarr syncs.
+const DefaultPosterCacheWorkers = 8
+
+// Default number of seconds the extras task waits for the radarr/sonarr
+// syncs to run once before it proceeds without them.
+const DefaultExtrasDepsMaxWaitSeconds = 1800
+
+// Default upper bound, in seconds, of the random delay added to each
+// interval task's first run after startup.
+const DefaultTaskStartupJitterSeconds = 30
+
+// Default number of wanted items the extras task looks up at once.
+const DefaultExtrasDiscoveryConcurrency = 4
+
+// Default TMDB request budget: TMDB documents 40 requests per 10 seconds.
+const (
+	DefaultTMDBRateLimitRequests      = 40
+	DefaultTMDBRateLimitWindowSeconds = 10
+)
+
+// Default minutes TMDB extras listed in the UI are cached per media.
+const DefaultTMDBExtrasCacheTTLMinutes = 360
+
+// Defaults of the YouTube thumbnail proxy.
+const (
+	DefaultThumbnailFetchTimeoutSeconds = 5
+	DefaultThumbnailFetchConcurrency    = 8
+	DefaultThumbnailNegativeCacheHours  = 24
+)
+
 // NOTE: store keys are defined below as the primary constants (use these names).
 
 // Path and runtime-configurable variables. Tests may override these.
@@ -91,6 +192,7 @@ func SetConfigPath(p string) {
 	// sync for compatibility with any code that still reads it directly.
 	configPathValue.Store(p)
 	ConfigPath = p
+	refreshAuthSettings()
 }
 
 // Initialize atomic config path value at package init to the default
@@ -129,6 +231,22 @@ const (
 	Other           PlexType = "Other"
 )
 
+// PlexTypes lists every valid canonicalizeExtraType mapping destination.
+var PlexTypes = []PlexType{BehindTheScenes, DeletedScenes, Featurettes, Interviews, Scenes, Shorts, Trailers, Other}
+
+// invalidPlexTypeMappings returns the "tmdbType: plexType" entries of mapping
+// whose destination is not one of PlexTypes, sorted by TMDB type.
+func invalidPlexTypeMappings(mapping map[string]string) []string {
+	invalid := []string{}
+	for tmdbType, plexType := range mapping {
+		if !slices.Contains(PlexTypes, PlexType(plexType)) {
+			invalid = append(invalid, tmdbType+": "+plexType)
+		}
+	}
+	sort.Strings(invalid)
+	return invalid
+}
+
 var defaultExtraTypes = ExtraTypesConfig{
 	Trailers:        true,
 	Scenes:          false,
@@ -205,6 +323,8 @@ func parseCanonicalizeExtraTypeConfig(data []byte) CanonicalizeExtraTypeConfig {
 
 // SaveCanonicalizeExtraTypeConfig saves mapping config to config.yml
 func SaveCanonicalizeExtraTypeConfig(cfg CanonicalizeExtraTypeConfig) error {
+	canonicalizeMappingMu.Lock()
+	defer canonicalizeMappingMu.Unlock()
 	config, err := readConfigFile()
 	if err != nil {
 		config = map[string]interface{}{}
@@ -215,6 +335,83 @@ func SaveCanonicalizeExtraTypeConfig(cfg CanonicalizeExtraTypeConfig) error {
 	return writeConfigFile(config)
 }
 
+// canonicalizeMappingMu serializes read-modify-write updates of single
+// canonicalizeExtraType mapping entries.
+var canonicalizeMappingMu sync.Mutex
+
+// updateCanonicalizeMappingEntry applies fn to the current mapping and saves
+// the result. fn returns false to abort without writing.
+func updateCanonicalizeMappingEntry(fn func(mapping map[string]interface{}) bool) error {
+	canonicalizeMappingMu.Lock()
+	defer canonicalizeMappingMu.Unlock()
+	config, err := readConfigFile()
+	if err != nil {
+		return err
+	}
+	sec, _ := config["canonicalizeExtraType"].(map[string]interface{})
+	if sec == nil {
+		sec = map[string]interface{}{}
+	}
+	mapping, _ := sec["mapping"].(map[string]interface{})
+	if mapping == nil {
+		mapping = map[string]interface{}{}
+	}
+	if !fn(mapping) {
+		return nil
+	}
+	sec["mapping"] = mapping
+	config["canonicalizeExtraType"] = sec
+	return writeConfigFile(config)
+}
+
+// PutCanonicalizeMappingEntryHandler handles PUT /api/extratypes/mapping/:tmdbType
+// and adds or updates a single mapping entry, leaving the others untouched.
+func PutCanonicalizeMappingEntryHandler(c *gin.Context) {
+	tmdbType := c.Param("tmdbType")
+	var req struct {
+		PlexType string `json:"plexType"`
+	}
+	if err := c.BindJSON(&req); err != nil || tmdbType == "" || req.PlexType == "" {
+		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		return
+	}
+	if invalid := invalidPlexTypeMappings(map[string]string{tmdbType: req.PlexType}); len(invalid) > 0 {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "invalid Plex extra type", "invalid": invalid, "allowed": PlexTypes})
+		return
+	}
+	err := updateCanonicalizeMappingEntry(func(mapping map[string]interface{}) bool {
+		mapping[tmdbType] = req.PlexType
+		return true
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "saved", "tmdbType": tmdbType, "plexType": req.PlexType})
+}
+
+// DeleteCanonicalizeMappingEntryHandler handles DELETE /api/extratypes/mapping/:tmdbType
+// and removes a single mapping entry.
+func DeleteCanonicalizeMappingEntryHandler(c *gin.Context) {
+	tmdbType := c.Param("tmdbType")
+	found := false
+	err := updateCanonicalizeMappingEntry(func(mapping map[string]interface{}) bool {
+		if _, found = mapping[tmdbType]; found {
+			delete(mapping, tmdbType)
+		}
+		return found
+	})
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !found {
+		respondError(c, http.StatusNotFound, "mapping entry not found")
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "deleted", "tmdbType": tmdbType})
+}
+
 // Handler to get canonicalizeExtraType config
 func GetCanonicalizeExtraTypeConfigHandler(c *gin.Context) {
 	cfg, _ := GetCanonicalizeExtraTypeConfig()
@@ -228,6 +425,10 @@ func SaveCanonicalizeExtraTypeConfigHandler(c *gin.Context) {
 		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
 		return
 	}
+	if invalid := invalidPlexTypeMappings(req.Mapping); len(invalid) > 0 {
+		respondJSON(c, http.StatusBadRequest, gin.H{"error": "invalid Plex extra type", "invalid": invalid, "allowed": PlexTypes})
+		return
+	}
 	if err := SaveCanonicalizeExtraTypeConfig(req); err != nil {
 		respondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -241,6 +442,10 @@ func DefaultGeneralConfig() map[string]interface{} {
 		"tmdbKey":            "",
 		"autoDownloadExtras": true,
 		"logLevel":           "Info",
+		// The log file in LogsDir is rotated past logMaxSizeMb; logMaxFiles
+		// rotated files are kept (0 keeps all).
+		"logMaxSizeMb": DefaultLogMaxSizeMB,
+		"logMaxFiles":  DefaultLogMaxFiles,
 		// frontendUrl may be used by OAuth flows to build redirect targets;
 		// keep default pointing at the local dev frontend so devs don't need
 		// to set it explicitly.
@@ -249,46 +454,846 @@ func DefaultGeneralConfig() map[string]interface{} {
 		// client IP via forwarded headers. For security we default to
 		// trusting only the loopback interface. Administrators may override
 		// this in config.yml to add their reverse proxy networks.
-		"trustedProxies": []string{"127.0.0.1"},
+		"trustedProxies": append([]string(nil), DefaultTrustedProxies...),
 		// Download timeout for large assets like ffmpeg. Accepts a duration
 		// string parseable by time.ParseDuration, e.g. "10m" or "30m".
 		"ffmpegDownloadTimeout": "10m",
 		// Separate timeout for yt-dlp downloads (smaller binary), default 5m
 		"ytdlpDownloadTimeout": "5m",
+		// yt-dlp release tag installed by the update endpoint, e.g.
+		// "2024.08.06". Empty installs the latest release.
+		"ytdlpVersion": "",
+		// Maximum number of concurrently open SSE streams (e.g. the YouTube
+		// search stream). Each stream may spawn a yt-dlp process so the cap
+		// protects the server from leaked client connections. 0 disables it.
+		"maxSseClients": DefaultMaxSSEClients,
+		// Number of history events kept; older ones are dropped.
+		"historyMaxLen": HistoryMaxLen,
+		// Maximum number of TMDB extras fetches in flight at once, shared by
+		// the UI and the extras task. 0 disables the limit.
+		"maxConcurrentTmdbFetches": DefaultMaxConcurrentTMDBFetches,
+		// yt-dlp processes (searches and downloads) allowed at once; protects
+		// low-memory hosts when searches and downloads coincide.
+		"maxYtdlpProcesses": DefaultMaxYtdlpProcesses,
+		// Poster downloads in flight at once during syncs, shared by radarr
+		// and sonarr when they run concurrently.
+		"posterCacheWorkers": DefaultPosterCacheWorkers,
+		// Seconds the extras task waits for the first radarr/sonarr sync
+		// before running with the syncs that did run. 0 does not wait.
+		"extrasDepsMaxWaitSeconds": DefaultExtrasDepsMaxWaitSeconds,
+		// Random 0..N second delay added to each interval task's first run
+		// so tasks due at startup do not all start together. 0 disables it.
+		"taskStartupJitterSeconds": DefaultTaskStartupJitterSeconds,
+		// Wanted items the extras task looks up (store, TMDB) in parallel.
+		// Enqueueing downloads stays sequential. 1 disables parallelism.
+		"extrasDiscoveryConcurrency": DefaultExtrasDiscoveryConcurrency,
+		// TMDB requests allowed per window, shared by every TMDB call.
+		// Requests beyond it wait for the budget; 0 disables the limit.
+		"tmdbRateLimitRequests":      DefaultTMDBRateLimitRequests,
+		"tmdbRateLimitWindowSeconds": DefaultTMDBRateLimitWindowSeconds,
+		// When the last radarr/sonarr sync failed the extras task is deferred.
+		// Set to true to re-run the failed sync once before deferring.
+		"extrasRetryFailedSync": false,
+		// Enables troubleshooting endpoints under /api/debug (e.g. cache flush).
+		"debugEndpoints": false,
+		// When true, the video thumbnail is saved next to each downloaded
+		// extra as "<title>-thumb.jpg".
+		"saveExtraThumbnails": true,
+		// YouTube channel ids to restrict (allowed) or exclude (blocked) in
+		// search results and downloads. Blocked wins; empty lists disable.
+		"allowedChannelIds": []string{},
+		"blockedChannelIds": []string{},
+		// Studio channel names (matched case-insensitively against the
+		// channel or uploader). "prefer" ranks their search results first;
+		// "require" drops every other result and download.
+		"officialChannels":     []string{},
+		"officialChannelsMode": OfficialChannelsPrefer,
+		// Duration bounds in seconds for YouTube search results and downloads;
+		// 0 disables a bound.
+		"minTrailerSeconds": 0,
+		"maxTrailerSeconds": 0,
+		// When true, TMDB season videos are fetched for every Sonarr season
+		// and downloaded into the matching "Season XX" folder.
+		"seasonExtras": false,
+		// Language TMDB videos are requested in, then each fallback in order
+		// until one returns videos.
+		"tmdbLanguage":          DefaultTMDBLanguage,
+		"tmdbFallbackLanguages": []string{},
+		// When true, automatic downloads only use trailers TMDB marks as
+		// official. Manual search in the UI is not affected.
+		"officialTrailersOnly": false,
+		// Directory for in-progress yt-dlp downloads; empty uses TrailarrRoot.
+		// Finished files are copied when it is on another filesystem than
+		// the media library.
+		"tempDir": "",
+		// When true, each download is checked with ffprobe (next to the
+		// ffmpeg in use) for a video stream and a non-zero duration before
+		// it is moved into the library; failures are retried later.
+		"verifyDownloads": false,
+		// When true, media unmonitored in Radarr/Sonarr is never wanted and
+		// the extras task skips it.
+		"onlyMonitored": false,
+		// When true, a radarr/sonarr sync removes the stored extras of media
+		// the provider no longer returns (deleted or without files).
+		"syncCleanupRemovedMedia": false,
+		// When true, literal path mapping prefixes match case-insensitively
+		// and treat \ and / alike, for Windows Radarr/Sonarr hosts.
+		"normalizePathMappings": false,
+		// Per-client-IP limits for the YouTube search endpoints, each of
+		// which spawns yt-dlp processes. 0 disables the respective limit.
+		"searchMaxConcurrentPerIp": DefaultSearchMaxConcurrentPerIP,
+		"searchMaxPerMinute":       DefaultSearchMaxPerMinute,
+		// Number of finished (downloaded/failed/exists/rejected) entries kept
+		// in the download queue by periodic compaction. 0 disables it.
+		"downloadQueueRetention": DefaultDownloadQueueRetention,
+		// Keep finished downloads in the queue (instead of removing them after
+		// queueItemRemoveDelaySeconds) until compaction trims them by count or
+		// they are older than downloadQueueRetentionHours (0 keeps them).
+		"keepFinishedQueueItems":      false,
+		"downloadQueueRetentionHours": 0,
+		// Require general.apiKey (X-Api-Key header, apikey query param or
+		// basic-auth password) on every route except the health endpoints.
+		"authEnabled": false,
+		"apiKey":      "",
+		// Fanart widths that may be requested on demand from
+		// /api/media/:mediaType/:id/fanart?size=N.
+		"fanartSizes": DefaultFanartSizes,
+		// Cached posters/fanart and YouTube thumbnails under MediaCover older
+		// than this many days are removed by the mediacover task and fetched
+		// again on next use. 0 keeps them until their media is removed.
+		"mediaCoverTtlDays": DefaultMediaCoverTTLDays,
+		// Minutes the TMDB extras shown for a movie/series are cached before
+		// TMDB is asked again. 0 fetches on every request.
+		"tmdbExtrasCacheTtlMinutes": DefaultTMDBExtrasCacheTTLMinutes,
+		// YouTube thumbnail proxy: seconds each thumbnail URL may take,
+		// simultaneous upstream fetches (0 is unbounded), and hours a video
+		// without a thumbnail is served the fallback image without asking
+		// YouTube again (0 disables the negative cache).
+		"thumbnailFetchTimeoutSeconds": DefaultThumbnailFetchTimeoutSeconds,
+		"thumbnailFetchConcurrency":    DefaultThumbnailFetchConcurrency,
+		"thumbnailNegativeCacheHours":  DefaultThumbnailNegativeCacheHours,
+		// Sidecar written next to each downloaded extra: "json" (.mkv.json),
+		// "nfo" (Kodi .nfo) or "both".
+		"metadataFormat": MetadataFormatJSON,
+		// File name of downloaded extras, without extension. Placeholders:
+		// {title}, {type} (the extra type folder) and {youtubeId}.
+		"extraFilenameTemplate": DefaultExtraFilenameTemplate,
+		// Extras of each type enqueued per media by the automatic downloads;
+		// trailers are topped up from a YouTube search when TMDB has fewer.
+		// 0 enqueues every missing extra.
+		"maxTrailersPerType": 0,
+	}
+}
+
+// trustedProxyHostPattern matches a DNS hostname such as "traefik" or
+// "proxy.internal", the form Docker service names take.
+var trustedProxyHostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?)*$`)
+
+// lookupTrustedProxyHost resolves hostname entries of general.trustedProxies;
+// tests replace it to avoid depending on DNS.
+var lookupTrustedProxyHost = net.LookupIP
+
+// isTrustedProxyHostname reports whether entry is a hostname rather than a
+// malformed address: the last label of a hostname is never all digits.
+func isTrustedProxyHostname(entry string) bool {
+	if len(entry) > 253 || !trustedProxyHostPattern.MatchString(entry) {
+		return false
+	}
+	last := entry[strings.LastIndex(entry, ".")+1:]
+	return strings.Trim(last, "0123456789") != ""
+}
+
+// splitTrustedProxies trims entries and splits them into valid ones (an
+// IPv4/IPv6 address, a CIDR such as "172.18.0.0/16" or "fd00::/8", or a
+// hostname) and invalid ones.
+func splitTrustedProxies(entries []string) (valid, invalid []string) {
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if net.ParseIP(e) != nil || isTrustedProxyHostname(e) {
+			valid = append(valid, e)
+			continue
+		}
+		if _, _, err := net.ParseCIDR(e); err == nil {
+			valid = append(valid, e)
+			continue
+		}
+		invalid = append(invalid, e)
+	}
+	return valid, invalid
+}
+
+// errInvalidTrustedProxies prefixes the list of malformed trustedProxies
+// entries.
+const errInvalidTrustedProxies = "invalid trustedProxies entries (expected an IP, CIDR or hostname)"
+
+// trustedProxiesError returns msg followed by the quoted entries, or nil
+// when there are none.
+func trustedProxiesError(msg string, entries []string) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(entries))
+	for i, e := range entries {
+		quoted[i] = fmt.Sprintf("%q", e)
+	}
+	return fmt.Errorf("%s: %s", msg, strings.Join(quoted, ", "))
+}
+
+// resolveTrustedProxies returns entries with hostnames replaced by their
+// addresses, and the hostnames that do not resolve.
+func resolveTrustedProxies(entries []string) (res, unresolved []string) {
+	res = make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !isTrustedProxyHostname(e) {
+			res = append(res, e)
+			continue
+		}
+		ips, err := lookupTrustedProxyHost(e)
+		if err != nil || len(ips) == 0 {
+			unresolved = append(unresolved, e)
+			continue
+		}
+		for _, ip := range ips {
+			res = append(res, ip.String())
+		}
 	}
+	return res, unresolved
 }
 
-// GetTrustedProxies returns the list of CIDRs configured for trusted proxies.
-// If not present in config it returns the default of only loopback.
+// GetTrustedProxies returns general.trustedProxies, with hostnames resolved,
+// for Gin's SetTrustedProxies. Without entries it returns
+// DefaultTrustedProxies. Invalid entries are left out and reported in the
+// error, and hostnames that do not resolve are dropped with a warning; the
+// remaining ones are still returned, or DefaultTrustedProxies when none
+// remain.
 func GetTrustedProxies() ([]string, error) {
+	entries := getGeneralStringSlice("trustedProxies")
+	if len(entries) == 0 {
+		return append([]string(nil), DefaultTrustedProxies...), nil
+	}
+	valid, invalid := splitTrustedProxies(entries)
+	res, unresolved := resolveTrustedProxies(valid)
+	for _, host := range unresolved {
+		TrailarrLog(WARN, "Settings", "Ignoring trusted proxy %q: cannot resolve host", host)
+	}
+	if len(res) == 0 {
+		res = append([]string(nil), DefaultTrustedProxies...)
+	}
+	return res, trustedProxiesError(errInvalidTrustedProxies, invalid)
+}
+
+// GetTrustedProxiesHandler returns the configured general.trustedProxies and
+// the addresses currently derived from them.
+func GetTrustedProxiesHandler(c *gin.Context) {
+	configured := getGeneralStringSlice("trustedProxies")
+	if configured == nil {
+		configured = []string{}
+	}
+	effective, err := GetTrustedProxies()
+	resp := gin.H{"trustedProxies": configured, "effective": effective}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+// SaveTrustedProxiesHandler validates and stores general.trustedProxies.
+// Malformed entries and hostnames that do not resolve are rejected with 400
+// and nothing is saved. The new list is applied on the next start.
+func SaveTrustedProxiesHandler(c *gin.Context) {
+	var req struct {
+		TrustedProxies []string `json:"trustedProxies"`
+	}
+	if err := c.BindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		return
+	}
+	proxies, invalid := splitTrustedProxies(req.TrustedProxies)
+	if err := trustedProxiesError(errInvalidTrustedProxies, invalid); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, unresolved := resolveTrustedProxies(proxies); len(unresolved) > 0 {
+		respondError(c, http.StatusBadRequest, trustedProxiesError("cannot resolve trustedProxies hosts", unresolved).Error())
+		return
+	}
+	if proxies == nil {
+		proxies = []string{}
+	}
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		respondErrorCode(c, http.StatusInternalServerError, CodeConfigWriteFailed, err.Error())
+		return
+	}
+	general, _ := cfg["general"].(map[string]interface{})
+	if general == nil {
+		general = map[string]interface{}{}
+		cfg["general"] = general
+	}
+	general["trustedProxies"] = proxies
+	if err := writeConfigFile(cfg); err != nil {
+		respondErrorCode(c, http.StatusInternalServerError, CodeConfigWriteFailed, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "saved", "trustedProxies": proxies})
+}
+
+// getGeneralInt reads an integer value from the general config section,
+// returning def when the key is missing or not numeric.
+func getGeneralInt(key string, def int) int {
 	cfg, err := readConfigFile()
 	if err != nil {
-		// Return default if we can't read config
-		return []string{"127.0.0.1"}, nil
+		return def
 	}
 	general, ok := cfg["general"].(map[string]interface{})
 	if !ok || general == nil {
-		return []string{"127.0.0.1"}, nil
+		return def
 	}
-	if v, ok := general["trustedProxies"]; ok {
-		switch t := v.(type) {
-		case []interface{}:
-			var res []string
-			for _, e := range t {
-				if s, ok := e.(string); ok {
-					res = append(res, s)
-				}
+	switch v := general[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// getGeneralBool reads a boolean value from the general config section,
+// returning def when the key is missing or not a bool.
+func getGeneralBool(key string, def bool) bool {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return def
+	}
+	general, ok := cfg["general"].(map[string]interface{})
+	if !ok || general == nil {
+		return def
+	}
+	if v, ok := general[key].(bool); ok {
+		return v
+	}
+	return def
+}
+
+// getGeneralString reads a string value from the general config section,
+// trimmed, returning def when the key is missing or empty. When allowed is
+// given the value is matched case-insensitively against it; unknown values
+// are logged and def is returned.
+func getGeneralString(key, def string, allowed ...string) string {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return def
+	}
+	general, _ := cfg["general"].(map[string]interface{})
+	v, _ := general[key].(string)
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return def
+	}
+	if len(allowed) == 0 {
+		return v
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(v, a) {
+			return a
+		}
+	}
+	TrailarrLog(WARN, "Settings", "Unknown %s %q; using %s", key, v, def)
+	return def
+}
+
+// getGeneralStringSlice reads a list of strings from the general config
+// section. Non-string entries are skipped.
+func getGeneralStringSlice(key string) []string {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return nil
+	}
+	general, ok := cfg["general"].(map[string]interface{})
+	if !ok || general == nil {
+		return nil
+	}
+	switch t := general[key].(type) {
+	case []string:
+		return t
+	case []interface{}:
+		res := make([]string, 0, len(t))
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				res = append(res, s)
 			}
-			if len(res) > 0 {
-				return res, nil
+		}
+		return res
+	}
+	return nil
+}
+
+// GetExtrasRetryFailedSync reports whether a failed radarr/sonarr sync should
+// be retried before the extras task is deferred.
+func GetExtrasRetryFailedSync() bool {
+	return getGeneralBool("extrasRetryFailedSync", false)
+}
+
+// GetDebugEndpointsEnabled reports whether the /api/debug endpoints are enabled.
+func GetDebugEndpointsEnabled() bool {
+	return getGeneralBool("debugEndpoints", false)
+}
+
+// GetQueueItemRemoveDelay returns how long a finished download lingers in the
+// queue before removal. general.queueItemRemoveDelaySeconds (fractions allowed)
+// overrides the QueueItemRemoveDelay default of 10s.
+func GetQueueItemRemoveDelay() time.Duration {
+	return getGeneralSeconds("queueItemRemoveDelaySeconds", QueueItemRemoveDelay)
+}
+
+// getGeneralSeconds reads a general.<key> value in seconds (fractions
+// allowed) as a duration. Missing or negative values return def.
+func getGeneralSeconds(key string, def time.Duration) time.Duration {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return def
+	}
+	general, _ := cfg["general"].(map[string]interface{})
+	var secs float64
+	switch v := general[key].(type) {
+	case int:
+		secs = float64(v)
+	case int64:
+		secs = float64(v)
+	case float64:
+		secs = v
+	default:
+		return def
+	}
+	if secs < 0 {
+		TrailarrLog(WARN, "Settings", "Ignoring negative %s=%v", key, secs)
+		return def
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// GetTooManyRequestsBackoff returns the 429 pause settings: the first pause
+// (general.tooManyRequestsPauseSeconds, default TooManyRequestsPauseDuration),
+// the factor applied per consecutive 429 (general.tooManyRequestsBackoffMultiplier,
+// default 2) and the longest pause (general.tooManyRequestsMaxPauseSeconds,
+// default TooManyRequestsMaxPause).
+func GetTooManyRequestsBackoff() (base time.Duration, multiplier float64, maxPause time.Duration) {
+	base = getGeneralSeconds("tooManyRequestsPauseSeconds", TooManyRequestsPauseDuration)
+	maxPause = getGeneralSeconds("tooManyRequestsMaxPauseSeconds", TooManyRequestsMaxPause)
+	multiplier = DefaultTooManyRequestsBackoffMultiplier
+	if cfg, err := readConfigFile(); err == nil {
+		general, _ := cfg["general"].(map[string]interface{})
+		if v, ok := toFloat64(general["tooManyRequestsBackoffMultiplier"]); ok {
+			if v >= 1 {
+				multiplier = v
+			} else {
+				TrailarrLog(WARN, "Settings", "Ignoring tooManyRequestsBackoffMultiplier=%v below 1", v)
 			}
-		case []string:
-			if len(t) > 0 {
-				return t, nil
+		}
+	}
+	return base, multiplier, maxPause
+}
+
+// GetFanartSizes returns the fanart widths allowed by the on-demand fanart
+// endpoint (general.fanartSizes).
+func GetFanartSizes() []int {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return DefaultFanartSizes
+	}
+	general, _ := cfg["general"].(map[string]interface{})
+	raw, ok := general["fanartSizes"].([]interface{})
+	if !ok {
+		if sizes, ok := general["fanartSizes"].([]int); ok {
+			return sizes
+		}
+		return DefaultFanartSizes
+	}
+	sizes := make([]int, 0, len(raw))
+	for _, v := range raw {
+		switch n := v.(type) {
+		case int:
+			sizes = append(sizes, n)
+		case float64:
+			sizes = append(sizes, int(n))
+		default:
+			TrailarrLog(WARN, "Settings", "Ignoring invalid fanartSizes entry %v", v)
+		}
+	}
+	return sizes
+}
+
+// GetDownloadQueueRetention returns how many terminal-status entries the
+// download queue compaction keeps. A value <= 0 disables compaction.
+func GetDownloadQueueRetention() int {
+	return getGeneralInt("downloadQueueRetention", DefaultDownloadQueueRetention)
+}
+
+// GetMaxTrailersPerType returns how many extras of each type the automatic
+// downloads keep per media. A value <= 0 means no limit.
+func GetMaxTrailersPerType() int {
+	return getGeneralInt("maxTrailersPerType", 0)
+}
+
+// GetKeepFinishedQueueItems reports whether finished downloads stay in the
+// queue until compaction or expiry instead of being removed after
+// GetQueueItemRemoveDelay.
+func GetKeepFinishedQueueItems() bool {
+	return getGeneralBool("keepFinishedQueueItems", false)
+}
+
+// GetDownloadQueueRetentionAge returns how long finished entries are kept in
+// the download queue. Zero disables age-based expiry.
+func GetDownloadQueueRetentionAge() time.Duration {
+	return time.Duration(getGeneralInt("downloadQueueRetentionHours", 0)) * time.Hour
+}
+
+// GetSearchMaxConcurrentPerIP returns the cap on simultaneous YouTube searches
+// per client IP. A value <= 0 means no limit.
+func GetSearchMaxConcurrentPerIP() int {
+	return getGeneralInt("searchMaxConcurrentPerIp", DefaultSearchMaxConcurrentPerIP)
+}
+
+// GetSearchMaxPerMinute returns the cap on YouTube searches per client IP per
+// minute. A value <= 0 means no limit.
+func GetSearchMaxPerMinute() int {
+	return getGeneralInt("searchMaxPerMinute", DefaultSearchMaxPerMinute)
+}
+
+var channelIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// GetSaveExtraThumbnails reports whether downloads store the video thumbnail
+// next to the extra file.
+func GetSaveExtraThumbnails() bool {
+	return getGeneralBool("saveExtraThumbnails", true)
+}
+
+// GetChannelFilters returns general.allowedChannelIds and
+// general.blockedChannelIds. Entries that are not valid YouTube channel ids
+// are dropped with a warning.
+func GetChannelFilters() (allowed, blocked []string) {
+	valid := func(key string) []string {
+		var out []string
+		for _, id := range getGeneralStringSlice(key) {
+			id = strings.TrimSpace(id)
+			if !channelIdPattern.MatchString(id) {
+				TrailarrLog(WARN, "Settings", "Ignoring invalid %s entry %q", key, id)
+				continue
 			}
+			out = append(out, id)
+		}
+		return out
+	}
+	return valid("allowedChannelIds"), valid("blockedChannelIds")
+}
+
+// tmdbLanguagePattern matches TMDB language codes such as "en" or "pt-BR".
+var tmdbLanguagePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// GetTMDBLanguages returns the languages TMDB videos are requested in, in
+// order: general.tmdbLanguage (default DefaultTMDBLanguage) followed by
+// general.tmdbFallbackLanguages. Invalid codes are dropped with a warning.
+func GetTMDBLanguages() []string {
+	primary := DefaultTMDBLanguage
+	if cfg, err := readConfigFile(); err == nil {
+		general, _ := cfg["general"].(map[string]interface{})
+		if v, ok := general["tmdbLanguage"].(string); ok && strings.TrimSpace(v) != "" {
+			primary = strings.TrimSpace(v)
+		}
+	}
+	var langs []string
+	for _, lang := range append([]string{primary}, getGeneralStringSlice("tmdbFallbackLanguages")...) {
+		lang = strings.TrimSpace(lang)
+		if !tmdbLanguagePattern.MatchString(lang) {
+			TrailarrLog(WARN, "Settings", "Ignoring invalid TMDB language %q", lang)
+			continue
+		}
+		if !slices.Contains(langs, lang) {
+			langs = append(langs, lang)
+		}
+	}
+	return langs
+}
+
+// GetYtdlpVersionPin returns general.ytdlpVersion, the yt-dlp release tag
+// the update endpoint installs instead of the latest one. Empty means latest.
+func GetYtdlpVersionPin() string {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return ""
+	}
+	general, _ := cfg["general"].(map[string]interface{})
+	v, _ := general["ytdlpVersion"].(string)
+	return strings.TrimSpace(v)
+}
+
+// GetTrailerDurationRange returns general.minTrailerSeconds and
+// general.maxTrailerSeconds. 0 means the bound is disabled.
+func GetTrailerDurationRange() (int, int) {
+	minSec := getGeneralInt("minTrailerSeconds", 0)
+	maxSec := getGeneralInt("maxTrailerSeconds", 0)
+	if minSec < 0 {
+		minSec = 0
+	}
+	if maxSec < 0 {
+		maxSec = 0
+	}
+	return minSec, maxSec
+}
+
+// GetSeasonExtrasEnabled reports whether season-level TV extras are fetched.
+func GetSeasonExtrasEnabled() bool {
+	return getGeneralBool("seasonExtras", false)
+}
+
+// GetOfficialTrailersOnly reports whether the auto-download pipeline keeps
+// only official TMDB trailers.
+func GetOfficialTrailersOnly() bool {
+	return getGeneralBool("officialTrailersOnly", false)
+}
+
+// Values of general.officialChannelsMode.
+const (
+	OfficialChannelsPrefer  = "prefer"
+	OfficialChannelsRequire = "require"
+)
+
+// GetOfficialChannels returns general.officialChannels, trimmed. Names with
+// characters that cannot be quoted in a yt-dlp --match-filter (' " & \) are
+// dropped with a warning.
+func GetOfficialChannels() []string {
+	var out []string
+	for _, name := range getGeneralStringSlice("officialChannels") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if strings.ContainsAny(name, `'"&\`) {
+			TrailarrLog(WARN, "Settings", "Ignoring officialChannels entry %q: quotes, & and \\ are not supported", name)
+			continue
+		}
+		out = append(out, name)
+	}
+	return out
+}
+
+// GetOfficialChannelsRequired reports whether general.officialChannelsMode
+// is "require". Unknown modes are logged and treated as "prefer".
+func GetOfficialChannelsRequired() bool {
+	mode := getGeneralString("officialChannelsMode", OfficialChannelsPrefer, OfficialChannelsPrefer, OfficialChannelsRequire)
+	return mode == OfficialChannelsRequire
+}
+
+// GetMediaCoverTTL returns how long cached MediaCover images are kept.
+// Zero disables age-based removal.
+func GetMediaCoverTTL() time.Duration {
+	days := getGeneralInt("mediaCoverTtlDays", DefaultMediaCoverTTLDays)
+	if days <= 0 {
+		return 0
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// GetTMDBExtrasCacheTTL returns how long TMDB extras listed in the UI are
+// cached per media. Zero disables the cache.
+func GetTMDBExtrasCacheTTL() time.Duration {
+	minutes := getGeneralInt("tmdbExtrasCacheTtlMinutes", DefaultTMDBExtrasCacheTTLMinutes)
+	if minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GetThumbnailFetchTimeout returns the deadline of each YouTube thumbnail
+// URL tried by the proxy. A value <= 0 disables the deadline.
+func GetThumbnailFetchTimeout() time.Duration {
+	seconds := getGeneralInt("thumbnailFetchTimeoutSeconds", DefaultThumbnailFetchTimeoutSeconds)
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// GetThumbnailFetchConcurrency returns the cap on simultaneous YouTube
+// thumbnail fetches; a value <= 0 means unbounded.
+func GetThumbnailFetchConcurrency() int {
+	return getGeneralInt("thumbnailFetchConcurrency", DefaultThumbnailFetchConcurrency)
+}
+
+// GetThumbnailNegativeCacheTTL returns how long a video without a thumbnail
+// is remembered. 0 disables the negative cache.
+func GetThumbnailNegativeCacheTTL() time.Duration {
+	hours := getGeneralInt("thumbnailNegativeCacheHours", DefaultThumbnailNegativeCacheHours)
+	if hours <= 0 {
+		return 0
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// GetMetadataFormat returns general.metadataFormat. Unknown values are
+// logged and treated as "json", the format written before the option existed.
+func GetMetadataFormat() string {
+	return getGeneralString("metadataFormat", MetadataFormatJSON, MetadataFormatJSON, MetadataFormatNFO, MetadataFormatBoth)
+}
+
+// DefaultExtraFilenameTemplate names extras after their title, as before
+// general.extraFilenameTemplate existed.
+const DefaultExtraFilenameTemplate = "{title}"
+
+var extraFilenamePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateExtraFilenameTemplate checks tmpl only uses known placeholders and
+// includes {title} or {youtubeId}, so different extras get different names.
+func ValidateExtraFilenameTemplate(tmpl string) error {
+	for _, ph := range extraFilenamePlaceholder.FindAllString(tmpl, -1) {
+		switch ph {
+		case "{title}", "{type}", "{youtubeId}":
+		default:
+			return fmt.Errorf("unknown placeholder %s in extraFilenameTemplate %q", ph, tmpl)
 		}
 	}
-	return []string{"127.0.0.1"}, nil
+	if !strings.Contains(tmpl, "{title}") && !strings.Contains(tmpl, "{youtubeId}") {
+		return fmt.Errorf("extraFilenameTemplate %q must contain {title} or {youtubeId}", tmpl)
+	}
+	return nil
+}
+
+// GetExtraFilenameTemplate returns general.extraFilenameTemplate. Invalid
+// templates, which the settings handler rejects but config.yml may still
+// contain, are logged and the default is used.
+func GetExtraFilenameTemplate() string {
+	v := getGeneralString("extraFilenameTemplate", DefaultExtraFilenameTemplate)
+	if err := ValidateExtraFilenameTemplate(v); err != nil {
+		TrailarrLog(WARN, "Settings", "%v; using %s", err, DefaultExtraFilenameTemplate)
+		return DefaultExtraFilenameTemplate
+	}
+	return v
+}
+
+// GetTempDir returns the directory temp download dirs are created under:
+// general.tempDir, or TrailarrRoot when unset.
+func GetTempDir() string {
+	return getGeneralString("tempDir", TrailarrRoot)
+}
+
+// GetNormalizePathMappings reports whether literal path mappings ignore case
+// and separator differences when matching.
+func GetNormalizePathMappings() bool {
+	return getGeneralBool("normalizePathMappings", false)
+}
+
+// GetVerifyDownloads reports whether downloads are validated with ffprobe.
+func GetVerifyDownloads() bool {
+	return getGeneralBool("verifyDownloads", false)
+}
+
+// GetOnlyMonitored reports whether unmonitored media is excluded from the
+// wanted index and the extras task.
+func GetOnlyMonitored() bool {
+	return getGeneralBool("onlyMonitored", false)
+}
+
+// GetSyncCleanupRemovedMedia reports whether SyncMedia cleans up the extras
+// of media no longer returned by the provider.
+func GetSyncCleanupRemovedMedia() bool {
+	return getGeneralBool("syncCleanupRemovedMedia", false)
+}
+
+// GetHistoryMaxLen returns general.historyMaxLen, the number of history
+// events kept. Values below 1 are invalid and use HistoryMaxLen.
+func GetHistoryMaxLen() int {
+	n := getGeneralInt("historyMaxLen", HistoryMaxLen)
+	if n < 1 {
+		TrailarrLog(WARN, "Settings", "Invalid historyMaxLen %d; using %d", n, HistoryMaxLen)
+		return HistoryMaxLen
+	}
+	return n
+}
+
+// GetMaxSSEClients returns the configured cap on concurrent SSE streams.
+// A value <= 0 means no limit.
+func GetMaxSSEClients() int {
+	return getGeneralInt("maxSseClients", DefaultMaxSSEClients)
+}
+
+// GetMaxConcurrentTMDBFetches returns the configured cap on simultaneous
+// TMDB extras fetches. A value <= 0 means no limit.
+func GetMaxConcurrentTMDBFetches() int {
+	return getGeneralInt("maxConcurrentTmdbFetches", DefaultMaxConcurrentTMDBFetches)
+}
+
+// GetMaxYtdlpProcesses returns general.maxYtdlpProcesses. Values below 1 are
+// replaced by DefaultMaxYtdlpProcesses with a warning.
+func GetMaxYtdlpProcesses() int {
+	n := getGeneralInt("maxYtdlpProcesses", DefaultMaxYtdlpProcesses)
+	if n < 1 {
+		TrailarrLog(WARN, "Settings", "Invalid general.maxYtdlpProcesses %d; using %d", n, DefaultMaxYtdlpProcesses)
+		return DefaultMaxYtdlpProcesses
+	}
+	return n
+}
+
+// GetPosterCacheWorkers returns general.posterCacheWorkers, the combined cap
+// on poster downloads of all running syncs. Values below 1 are replaced by
+// DefaultPosterCacheWorkers with a warning.
+func GetPosterCacheWorkers() int {
+	n := getGeneralInt("posterCacheWorkers", DefaultPosterCacheWorkers)
+	if n < 1 {
+		TrailarrLog(WARN, "Settings", "Invalid general.posterCacheWorkers %d; using %d", n, DefaultPosterCacheWorkers)
+		return DefaultPosterCacheWorkers
+	}
+	return n
+}
+
+// GetExtrasDepsMaxWait returns general.extrasDepsMaxWaitSeconds as a
+// duration. Negative values are replaced by the default with a warning.
+func GetExtrasDepsMaxWait() time.Duration {
+	n := getGeneralInt("extrasDepsMaxWaitSeconds", DefaultExtrasDepsMaxWaitSeconds)
+	if n < 0 {
+		TrailarrLog(WARN, "Settings", "Invalid general.extrasDepsMaxWaitSeconds %d; using %d", n, DefaultExtrasDepsMaxWaitSeconds)
+		n = DefaultExtrasDepsMaxWaitSeconds
+	}
+	return time.Duration(n) * time.Second
+}
+
+// isProviderConfigured reports whether the radarr or sonarr section has both
+// a URL and an API key, i.e. the provider is in use.
+func isProviderConfigured(provider string) bool {
+	url, apiKey, err := GetProviderUrlAndApiKey(provider)
+	return err == nil && url != "" && apiKey != ""
+}
+
+// GetTaskStartupJitter returns general.taskStartupJitterSeconds as a
+// duration. Negative values are replaced by the default with a warning.
+func GetTaskStartupJitter() time.Duration {
+	n := getGeneralInt("taskStartupJitterSeconds", DefaultTaskStartupJitterSeconds)
+	if n < 0 {
+		TrailarrLog(WARN, "Settings", "Invalid general.taskStartupJitterSeconds %d; using %d", n, DefaultTaskStartupJitterSeconds)
+		n = DefaultTaskStartupJitterSeconds
+	}
+	return time.Duration(n) * time.Second
+}
+
+// GetExtrasDiscoveryConcurrency returns how many wanted items the extras task
+// looks up concurrently; values below 1 are treated as 1.
+func GetExtrasDiscoveryConcurrency() int {
+	if n := getGeneralInt("extrasDiscoveryConcurrency", DefaultExtrasDiscoveryConcurrency); n > 1 {
+		return n
+	}
+	return 1
+}
+
+// GetTMDBRateLimit returns the number of TMDB requests allowed per window.
+// A request count or window <= 0 disables the limit and returns 0, 0.
+func GetTMDBRateLimit() (int, time.Duration) {
+	requests := getGeneralInt("tmdbRateLimitRequests", DefaultTMDBRateLimitRequests)
+	seconds := getGeneralInt("tmdbRateLimitWindowSeconds", DefaultTMDBRateLimitWindowSeconds)
+	if requests <= 0 || seconds <= 0 {
+		return 0, 0
+	}
+	return requests, time.Duration(seconds) * time.Second
 }
 
 // GetFfmpegDownloadTimeout returns configured timeout or default 10m
@@ -368,6 +1373,11 @@ func EnsureConfigDefaults() error {
 	var changed bool
 	config, err := readConfigFileRaw()
 	if err != nil {
+		// Never replace a config that exists but does not parse; that would
+		// silently reset settings such as authEnabled.
+		if info, statErr := os.Stat(GetConfigPath()); statErr == nil && info.Size() > 0 {
+			return err
+		}
 		// Create file with core defaults when missing
 		config = map[string]interface{}{
 			"general":    DefaultGeneralConfig(),
@@ -391,6 +1401,9 @@ func EnsureConfigDefaults() error {
 	if ensurePlexDefaults(config) {
 		changed = true
 	}
+	if ensureEmbyDefaults(config) {
+		changed = true
+	}
 	if ensureExtraTypesDefaults(config) {
 		changed = true
 	}
@@ -551,6 +1564,29 @@ func ensurePlexDefaults(config map[string]interface{}) bool {
 	return changed
 }
 
+// ensureEmbyDefaults adds a disabled emby section with empty url and apiKey.
+func ensureEmbyDefaults(config map[string]interface{}) bool {
+	defaultConfig := map[string]interface{}{
+		"url":     "",
+		"apiKey":  "",
+		"enabled": false,
+	}
+	emby, ok := config["emby"].(map[string]interface{})
+	if !ok {
+		config["emby"] = defaultConfig
+		return true
+	}
+	changed := false
+	for k, v := range defaultConfig {
+		if _, ok := emby[k]; !ok {
+			emby[k] = v
+			changed = true
+		}
+	}
+	config["emby"] = emby
+	return changed
+}
+
 func ensureExtraTypesDefaults(config map[string]interface{}) bool {
 	if config["extraTypes"] == nil {
 		config["extraTypes"] = map[string]interface{}{
@@ -699,7 +1735,14 @@ func writeConfigFile(config map[string]interface{}) error {
 	if err := os.WriteFile(tmp, out, 0644); err != nil {
 		return err
 	}
-	return os.Rename(tmp, GetConfigPath())
+	if err := os.Rename(tmp, GetConfigPath()); err != nil {
+		return err
+	}
+	// Apply a changed logLevel, log rotation or auth setting without a
+	// restart.
+	refreshLogSettings()
+	refreshAuthSettings()
+	return nil
 }
 
 // EnsureYtdlpFlagsConfigExists checks config.yml and writes defaults if missing
@@ -849,22 +1892,67 @@ func ytdlpFlagSetters(cfg *YtdlpFlagsConfig) map[string]func(interface{}) {
 			}
 		}
 	}
+	stringSliceSetter := func(dst *[]string) func(interface{}) {
+		return func(v interface{}) {
+			list, ok := v.([]interface{})
+			if !ok {
+				return
+			}
+			vals := make([]string, 0, len(list))
+			for _, e := range list {
+				if val, ok := toString(e); ok {
+					vals = append(vals, val)
+				}
+			}
+			*dst = vals
+		}
+	}
 
 	return map[string]func(interface{}){
-		"quiet":            boolSetter(&cfg.Quiet),
-		"noprogress":       boolSetter(&cfg.NoProgress),
-		"writesubs":        boolSetter(&cfg.WriteSubs),
-		"writeautosubs":    boolSetter(&cfg.WriteAutoSubs),
-		"embedsubs":        boolSetter(&cfg.EmbedSubs),
-		"sublangs":         stringSetter(&cfg.SubLangs),
-		"requestedformats": stringSetter(&cfg.RequestedFormats),
-		"timeout":          floatSetter(&cfg.Timeout),
-		"sleepInterval":    floatSetter(&cfg.SleepInterval),
-		"maxDownloads":     intSetter(&cfg.MaxDownloads),
-		"limitRate":        stringSetter(&cfg.LimitRate),
-		"sleepRequests":    floatSetter(&cfg.SleepRequests),
-		"maxSleepInterval": floatSetter(&cfg.MaxSleepInterval),
-		"ffmpegLocation":   stringSetter(&cfg.FfmpegLocation),
+		"quiet":             boolSetter(&cfg.Quiet),
+		"noprogress":        boolSetter(&cfg.NoProgress),
+		"writesubs":         boolSetter(&cfg.WriteSubs),
+		"writeautosubs":     boolSetter(&cfg.WriteAutoSubs),
+		"embedsubs":         boolSetter(&cfg.EmbedSubs),
+		"sublangs":          stringSetter(&cfg.SubLangs),
+		"subFormat":         stringSetter(&cfg.SubFormat),
+		"requestedformats":  stringSetter(&cfg.RequestedFormats),
+		"timeout":           floatSetter(&cfg.Timeout),
+		"sleepInterval":     floatSetter(&cfg.SleepInterval),
+		"maxDownloads":      intSetter(&cfg.MaxDownloads),
+		"limitRate":         stringSetter(&cfg.LimitRate),
+		"sleepRequests":     floatSetter(&cfg.SleepRequests),
+		"maxSleepInterval":  floatSetter(&cfg.MaxSleepInterval),
+		"ffmpegLocation":    stringSetter(&cfg.FfmpegLocation),
+		"impersonateTarget": stringSetter(&cfg.ImpersonateTarget),
+		"postProcessing":    stringSetter(&cfg.PostProcessing),
+		"hwaccel":           stringSetter(&cfg.Hwaccel),
+		"rateSchedule":      rateScheduleSetter(&cfg.RateSchedule),
+		"extraArgs":         stringSliceSetter(&cfg.ExtraArgs),
+	}
+}
+
+// rateScheduleSetter decodes the ytdlpFlags.rateSchedule list of
+// {start, end, rate} maps.
+func rateScheduleSetter(dst *[]RateWindow) func(interface{}) {
+	return func(v interface{}) {
+		list, ok := v.([]interface{})
+		if !ok {
+			return
+		}
+		schedule := make([]RateWindow, 0, len(list))
+		for _, item := range list {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var w RateWindow
+			w.Start, _ = toString(m["start"])
+			w.End, _ = toString(m["end"])
+			w.Rate, _ = toString(m["rate"])
+			schedule = append(schedule, w)
+		}
+		*dst = schedule
 	}
 }
 
@@ -875,20 +1963,26 @@ func SaveYtdlpFlagsConfig(cfg YtdlpFlagsConfig) error {
 		config = map[string]interface{}{}
 	}
 	config["ytdlpFlags"] = map[string]interface{}{
-		"quiet":            cfg.Quiet,
-		"noprogress":       cfg.NoProgress,
-		"writesubs":        cfg.WriteSubs,
-		"writeautosubs":    cfg.WriteAutoSubs,
-		"embedsubs":        cfg.EmbedSubs,
-		"sublangs":         cfg.SubLangs,
-		"requestedformats": cfg.RequestedFormats,
-		"timeout":          cfg.Timeout,
-		"sleepInterval":    cfg.SleepInterval,
-		"maxDownloads":     cfg.MaxDownloads,
-		"limitRate":        cfg.LimitRate,
-		"sleepRequests":    cfg.SleepRequests,
-		"maxSleepInterval": cfg.MaxSleepInterval,
-		"ffmpegLocation":   cfg.FfmpegLocation,
+		"quiet":             cfg.Quiet,
+		"noprogress":        cfg.NoProgress,
+		"writesubs":         cfg.WriteSubs,
+		"writeautosubs":     cfg.WriteAutoSubs,
+		"embedsubs":         cfg.EmbedSubs,
+		"sublangs":          cfg.SubLangs,
+		"subFormat":         cfg.SubFormat,
+		"requestedformats":  cfg.RequestedFormats,
+		"timeout":           cfg.Timeout,
+		"sleepInterval":     cfg.SleepInterval,
+		"maxDownloads":      cfg.MaxDownloads,
+		"limitRate":         cfg.LimitRate,
+		"sleepRequests":     cfg.SleepRequests,
+		"maxSleepInterval":  cfg.MaxSleepInterval,
+		"ffmpegLocation":    cfg.FfmpegLocation,
+		"impersonateTarget": cfg.ImpersonateTarget,
+		"postProcessing":    cfg.PostProcessing,
+		"hwaccel":           cfg.Hwaccel,
+		"rateSchedule":      cfg.RateSchedule,
+		"extraArgs":         cfg.ExtraArgs,
 	}
 	return writeConfigFile(config)
 }
@@ -910,6 +2004,28 @@ func SaveYtdlpFlagsConfigHandler(c *gin.Context) {
 		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
 		return
 	}
+	if req.ImpersonateTarget != "" {
+		if err := ValidateImpersonateTarget(req.ImpersonateTarget); err != nil {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if err := ValidatePostProcessing(req.PostProcessing); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ValidateSubFormat(req.SubFormat); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ValidateRateSchedule(req.RateSchedule); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := ValidateExtraArgs(req.ExtraArgs); err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err := SaveYtdlpFlagsConfig(req); err != nil {
 		respondError(c, http.StatusInternalServerError, err.Error())
 		return
@@ -919,6 +2035,34 @@ func SaveYtdlpFlagsConfigHandler(c *gin.Context) {
 
 var Timings map[string]int
 
+// GetDisabledTasks returns the task ids that have tasks.<id>.enabled set to
+// false in config.yml. Tasks without an entry are enabled.
+func GetDisabledTasks() map[TaskID]bool {
+	disabled := map[TaskID]bool{}
+	cfg, err := readConfigFile()
+	if err != nil {
+		return disabled
+	}
+	tasks, ok := cfg["tasks"].(map[string]interface{})
+	if !ok {
+		return disabled
+	}
+	for id, v := range tasks {
+		sec, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if enabled, ok := sec["enabled"].(bool); ok && !enabled {
+			disabled[TaskID(id)] = true
+		}
+	}
+	return disabled
+}
+
+// CronTimings holds tasks scheduled by cron expression instead of a minute
+// interval. A task present here takes precedence over Timings.
+var CronTimings map[string]*CronSchedule
+
 // PlexConfig holds Plex server connection settings
 type PlexConfig struct {
 	Protocol string `yaml:"protocol" json:"protocol"`
@@ -929,6 +2073,13 @@ type PlexConfig struct {
 	Enabled  bool   `yaml:"enabled" json:"enabled"`
 }
 
+// EmbyConfig holds the Emby server refreshed after downloads.
+type EmbyConfig struct {
+	URL     string `yaml:"url" json:"url"`
+	APIKey  string `yaml:"apiKey" json:"apiKey"`
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+}
+
 // ExtraTypesConfig holds config for enabling/disabling specific extra types
 type ExtraTypesConfig struct {
 	Trailers        bool `yaml:"trailers" json:"trailers"`
@@ -1055,6 +2206,9 @@ func EnsureSyncTimingsConfig() (map[string]int, error) {
 		"radarr":      15,
 		"sonarr":      15,
 		"extras":      360,
+		"mediacover":  1440,
+		// updatecheck is disabled (0) until an interval is configured.
+		"updatecheck": 0,
 	}
 
 	// If the file doesn't exist create it with defaults
@@ -1080,20 +2234,16 @@ func EnsureSyncTimingsConfig() (map[string]int, error) {
 		return defaultTimings, nil
 	}
 
-	// Ensure extras key exists
-	if _, hasExtras := timings["extras"]; !hasExtras {
-		timings["extras"] = 360
-		cfg["syncTimings"] = timings
-		out, err := yamlv3.Marshal(cfg)
-		if err == nil {
-			_ = os.WriteFile(GetConfigPath(), out, 0644)
+	// Ensure tasks added after the config was created (extras, healthcheck,
+	// mediacover, updatecheck) get their default schedule persisted in config.yml.
+	missing := false
+	for k, v := range defaultTimings {
+		if _, ok := timings[k]; !ok && k != "radarr" && k != "sonarr" {
+			timings[k] = v
+			missing = true
 		}
 	}
-
-	// Ensure healthcheck key exists (6 hours). If missing, persist it so the
-	// default schedule is present in config.yml.
-	if _, hasHealth := timings["healthcheck"]; !hasHealth {
-		timings["healthcheck"] = 360
+	if missing {
 		cfg["syncTimings"] = timings
 		out, err := yamlv3.Marshal(cfg)
 		if err == nil {
@@ -1150,6 +2300,10 @@ func convertTimings(timings map[string]interface{}) map[string]int {
 		case uint:
 			result[k] = int(val)
 		case string:
+			// Multi-field strings are cron expressions; see convertCronTimings.
+			if isCronTiming(val) {
+				continue
+			}
 			var parsed int
 			_, err := fmt.Sscanf(val, "%d", &parsed)
 			if err == nil {
@@ -1166,6 +2320,46 @@ func convertTimings(timings map[string]interface{}) map[string]int {
 	return result
 }
 
+// isCronTiming reports whether a syncTimings value is a cron expression
+// rather than an integer number of minutes.
+func isCronTiming(v string) bool {
+	return len(strings.Fields(v)) > 1
+}
+
+// convertCronTimings extracts the cron-expression entries from a syncTimings
+// section. Invalid expressions are logged and omitted, so the task is not
+// scheduled rather than run on a guessed interval.
+func convertCronTimings(timings map[string]interface{}) map[string]*CronSchedule {
+	result := map[string]*CronSchedule{}
+	for k, v := range timings {
+		str, ok := v.(string)
+		if !ok || !isCronTiming(str) {
+			continue
+		}
+		sched, err := ParseCron(str)
+		if err != nil {
+			TrailarrLog(WARN, "Settings", "Invalid cron expression for syncTimings.%s: %v", k, err)
+			continue
+		}
+		result[k] = sched
+	}
+	return result
+}
+
+// LoadSyncCronTimings returns the syncTimings entries configured as cron
+// expressions, keyed by task id.
+func LoadSyncCronTimings() (map[string]*CronSchedule, error) {
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		return map[string]*CronSchedule{}, err
+	}
+	timings, ok := cfg["syncTimings"].(map[string]interface{})
+	if !ok {
+		return map[string]*CronSchedule{}, nil
+	}
+	return convertCronTimings(timings), nil
+}
+
 // Loads settings for a given section ("radarr" or "sonarr")
 func loadMediaSettings(section string) (MediaSettings, error) {
 	data, err := os.ReadFile(GetConfigPath())
@@ -1178,48 +2372,211 @@ func loadMediaSettings(section string) (MediaSettings, error) {
 		TrailarrLog(WARN, "Settings", "invalid settings: %v", err)
 		return MediaSettings{}, fmt.Errorf("invalid settings: %w", err)
 	}
-	// Normalize top-level map keys to strings when needed
-	allSettings = normalizeYAML(allSettings).(map[string]interface{})
-	secRaw, ok := allSettings[section]
-	if !ok {
-		TrailarrLog(WARN, "Settings", "section %s not found", section)
-		return MediaSettings{}, fmt.Errorf("section %s not found", section)
+	// Normalize top-level map keys to strings when needed
+	allSettings = normalizeYAML(allSettings).(map[string]interface{})
+	secRaw, ok := allSettings[section]
+	if !ok {
+		TrailarrLog(WARN, "Settings", "section %s not found", section)
+		return MediaSettings{}, fmt.Errorf("section %s not found", section)
+	}
+	sec, ok := secRaw.(map[string]interface{})
+	if !ok {
+		TrailarrLog(WARN, "Settings", ErrSectionNotMap, section)
+		return MediaSettings{}, fmt.Errorf(ErrSectionNotMap, section)
+	}
+	var ProviderURL, apiKey string
+	if v, ok := sec["url"].(string); ok {
+		ProviderURL = v
+	}
+	if v, ok := sec["apiKey"].(string); ok {
+		apiKey = v
+	}
+	return MediaSettings{ProviderURL: ProviderURL, APIKey: apiKey}, nil
+}
+
+// GetPathMappings reads pathMappings for a section ("radarr" or "sonarr") from config.yml and returns as [][]string
+func GetPathMappings(mediaType MediaType) ([][]string, error) {
+	section := "radarr"
+	if mediaType == MediaTypeTV {
+		section = "sonarr"
+	}
+	data, err := os.ReadFile(GetConfigPath())
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]interface{}
+	if err := yamlv3.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	config = normalizeYAML(config).(map[string]interface{})
+	sec, _ := config[section].(map[string]interface{})
+	all := extractPathMappings(sec)
+	mappings := all[:0]
+	compiled := map[string]*regexp.Regexp{}
+	for _, m := range all {
+		if isRegexPathMapping(m) {
+			re, err := pathMappingRegexp(m[0])
+			if err != nil {
+				TrailarrLog(WARN, "PathMappings", "Skipping invalid regex mapping %q: %v", m[0], err)
+				continue
+			}
+			compiled[m[0]] = re
+		}
+		mappings = append(mappings, m)
+	}
+	setPathMappingRegexps(section, compiled)
+	if general, _ := config["general"].(map[string]interface{}); general != nil {
+		if normalize, _ := general["normalizePathMappings"].(bool); normalize {
+			for i, m := range mappings {
+				if !isRegexPathMapping(m) {
+					mappings[i] = []string{m[0], m[1], pathMappingModeNormalized}
+				}
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// Path mapping modes carried as the third element of a mapping returned by
+// GetPathMappings. pathMappingModeRegex marks a from that is a regular
+// expression with to as its replacement; pathMappingModeNormalized marks a
+// literal mapping matched with general.normalizePathMappings.
+const (
+	pathMappingModeRegex      = "regex"
+	pathMappingModeNormalized = "normalized"
+)
+
+// pathMappingRegexps holds the compiled form of each regex mapping by
+// section, so patterns are compiled once when GetPathMappings loads them
+// rather than on every path mapped. Each load replaces its section's set, so
+// patterns no longer configured are dropped.
+var (
+	pathMappingRegexpsMu sync.Mutex
+	pathMappingRegexps   = map[string]map[string]*regexp.Regexp{}
+)
+
+// pathMappingRegexp returns pattern compiled, from the cache when a loaded
+// mapping uses it.
+func pathMappingRegexp(pattern string) (*regexp.Regexp, error) {
+	pathMappingRegexpsMu.Lock()
+	defer pathMappingRegexpsMu.Unlock()
+	for _, compiled := range pathMappingRegexps {
+		if re, ok := compiled[pattern]; ok {
+			return re, nil
+		}
+	}
+	return regexp.Compile(pattern)
+}
+
+// setPathMappingRegexps replaces the cached regex mappings of section.
+func setPathMappingRegexps(section string, compiled map[string]*regexp.Regexp) {
+	pathMappingRegexpsMu.Lock()
+	pathMappingRegexps[section] = compiled
+	pathMappingRegexpsMu.Unlock()
+}
+
+// isRegexPathMapping reports whether m is a regex mapping.
+func isRegexPathMapping(m []string) bool {
+	return len(m) > 2 && m[2] == pathMappingModeRegex
+}
+
+// byPathMappingSpecificity returns the order mappings are tried in: regex
+// mappings first, as configured, then literal mappings by descending from
+// length so /data/4k wins over /data. Equal lengths keep their configured
+// order.
+func byPathMappingSpecificity(mappings [][]string) [][]string {
+	ordered := make([][]string, 0, len(mappings))
+	for _, m := range mappings {
+		if isRegexPathMapping(m) {
+			ordered = append(ordered, m)
+		}
+	}
+	literal := make([][]string, 0, len(mappings))
+	for _, m := range mappings {
+		if !isRegexPathMapping(m) && len(m) > 1 {
+			literal = append(literal, m)
+		}
+	}
+	sort.SliceStable(literal, func(i, j int) bool { return len(literal[i][0]) > len(literal[j][0]) })
+	return append(ordered, literal...)
+}
+
+// pathMappingOverlapWarnings describes literal mappings whose from prefixes
+// overlap: the longer prefix is applied to paths both match, and of
+// duplicate froms only the first is used.
+func pathMappingOverlapWarnings(mappings [][]string) []string {
+	var warnings []string
+	for i, a := range mappings {
+		if isRegexPathMapping(a) || len(a) < 2 {
+			continue
+		}
+		for _, b := range mappings[i+1:] {
+			if isRegexPathMapping(b) || len(b) < 2 {
+				continue
+			}
+			switch {
+			case a[0] == b[0]:
+				warnings = append(warnings, fmt.Sprintf("path mapping %q is listed more than once; only %q -> %q is applied", a[0], a[0], a[1]))
+			case strings.HasPrefix(b[0], a[0]):
+				warnings = append(warnings, fmt.Sprintf("path mappings %q and %q overlap; %q is applied to paths under it", a[0], b[0], b[0]))
+			case strings.HasPrefix(a[0], b[0]):
+				warnings = append(warnings, fmt.Sprintf("path mappings %q and %q overlap; %q is applied to paths under it", b[0], a[0], a[0]))
+			}
+		}
+	}
+	return warnings
+}
+
+// applyPathMapping maps p with a single mapping from GetPathMappings. Literal
+// mappings replace the from prefix. Regex mappings replace the first match of
+// from with to, which may reference capture groups as $1 or ${name}; an empty
+// to strips the match. Invalid regex mappings, which GetPathMappings already
+// leaves out, never match.
+func applyPathMapping(p string, m []string) (string, bool) {
+	if len(m) < 2 {
+		return "", false
 	}
-	sec, ok := secRaw.(map[string]interface{})
-	if !ok {
-		TrailarrLog(WARN, "Settings", ErrSectionNotMap, section)
-		return MediaSettings{}, fmt.Errorf(ErrSectionNotMap, section)
+	if isRegexPathMapping(m) {
+		re, err := pathMappingRegexp(m[0])
+		if err != nil {
+			return "", false
+		}
+		loc := re.FindStringSubmatchIndex(p)
+		if loc == nil {
+			return "", false
+		}
+		return p[:loc[0]] + string(re.ExpandString(nil, m[1], p, loc)) + p[loc[1]:], true
 	}
-	var ProviderURL, apiKey string
-	if v, ok := sec["url"].(string); ok {
-		ProviderURL = v
+	if len(m) > 2 && m[2] == pathMappingModeNormalized {
+		return applyNormalizedPathMapping(p, m[0], m[1])
 	}
-	if v, ok := sec["apiKey"].(string); ok {
-		apiKey = v
+	if !strings.HasPrefix(p, m[0]) {
+		return "", false
 	}
-	return MediaSettings{ProviderURL: ProviderURL, APIKey: apiKey}, nil
+	return m[1] + p[len(m[0]):], true
 }
 
-// GetPathMappings reads pathMappings for a section ("radarr" or "sonarr") from config.yml and returns as [][]string
-func GetPathMappings(mediaType MediaType) ([][]string, error) {
-	section := "radarr"
-	if mediaType == MediaTypeTV {
-		section = "sonarr"
-	}
-	data, err := os.ReadFile(GetConfigPath())
-	if err != nil {
-		return nil, err
+// applyNormalizedPathMapping matches from against p ignoring case and the
+// difference between \ and /, so C:\Movies matches c:/movies. p itself is
+// never rewritten for matching: the remainder after the prefix is kept,
+// with its separators switched to / unless to uses \.
+func applyNormalizedPathMapping(p, from, to string) (string, bool) {
+	// Separator unification keeps byte lengths, so offsets into the
+	// normalized strings are valid in p.
+	np, nfrom := strings.ReplaceAll(p, `\`, "/"), strings.ReplaceAll(from, `\`, "/")
+	if len(np) < len(nfrom) || !strings.EqualFold(np[:len(nfrom)], nfrom) {
+		return "", false
 	}
-	var config map[string]interface{}
-	if err := yamlv3.Unmarshal(data, &config); err != nil {
-		return nil, err
+	rest := p[len(from):]
+	if !strings.Contains(to, `\`) {
+		rest = strings.ReplaceAll(rest, `\`, "/")
 	}
-	config = normalizeYAML(config).(map[string]interface{})
-	sec, _ := config[section].(map[string]interface{})
-	return extractPathMappings(sec), nil
+	return to + rest, true
 }
 
 // extractPathMappings converts a section's pathMappings into [][]string.
+// Regex mappings carry pathMappingModeRegex as a third element and may have
+// an empty to.
 func extractPathMappings(sec map[string]interface{}) [][]string {
 	if sec == nil {
 		return nil
@@ -1236,6 +2593,10 @@ func extractPathMappings(sec map[string]interface{}) [][]string {
 		}
 		from, _ := mMap["from"].(string)
 		to, _ := mMap["to"].(string)
+		if isRegex, _ := mMap["regex"].(bool); isRegex && from != "" {
+			result = append(result, []string{from, to, pathMappingModeRegex})
+			continue
+		}
 		if from == "" || to == "" {
 			continue
 		}
@@ -1244,6 +2605,72 @@ func extractPathMappings(sec map[string]interface{}) [][]string {
 	return result
 }
 
+// GetExcludedRootFolders returns the root folders listed under a section's
+// excludedRootFolders ("radarr" or "sonarr"). Media under these folders is
+// skipped during sync and the folders are never merged into pathMappings.
+func GetExcludedRootFolders(section string) []string {
+	cfg, err := readConfigFile()
+	if err != nil {
+		return nil
+	}
+	sec, _ := cfg[section].(map[string]interface{})
+	return extractExcludedRootFolders(sec)
+}
+
+// extractExcludedRootFolders converts a section's excludedRootFolders into
+// cleaned paths, dropping empty entries.
+func extractExcludedRootFolders(sec map[string]interface{}) []string {
+	var raw []string
+	switch t := sec["excludedRootFolders"].(type) {
+	case []string:
+		raw = t
+	case []interface{}:
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+	res := make([]string, 0, len(raw))
+	for _, f := range raw {
+		if f = strings.TrimSpace(f); f != "" {
+			res = append(res, filepath.Clean(f))
+		}
+	}
+	return res
+}
+
+// isUnderExcludedFolder reports whether path equals or lies inside one of the
+// excluded folders. Matching respects path separators, so /movies does not
+// exclude /movies4k.
+func isUnderExcludedFolder(path string, excluded []string) bool {
+	if path == "" {
+		return false
+	}
+	path = filepath.Clean(path)
+	for _, ex := range excluded {
+		if path == ex || strings.HasPrefix(path, strings.TrimSuffix(ex, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutExcludedFolders drops root folders whose path is excluded.
+func withoutExcludedFolders(folders []map[string]interface{}, excluded []string) []map[string]interface{} {
+	if len(excluded) == 0 {
+		return folders
+	}
+	res := make([]map[string]interface{}, 0, len(folders))
+	for _, f := range folders {
+		if path, _ := f["path"].(string); isUnderExcludedFolder(path, excluded) {
+			continue
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
 // Returns a Gin handler for settings (url, apiKey, pathMappings) for a given section ("radarr" or "sonarr")
 // Returns url and apiKey for a given section (radarr/sonarr) from config.yml
 func GetProviderUrlAndApiKey(provider string) (string, string, error) {
@@ -1324,7 +2751,7 @@ func GetSettingsHandler(section string) gin.HandlerFunc {
 		pathMappings = tryMergeRemoteFolders(section, sectionData, providerURL, apiKey, pathMappings, mappings, mappingSet)
 
 		TrailarrLog(DEBUG, "Settings", "Loaded settings for %s: URL=%s, APIKey=%s, Mappings=%v", section, providerURL, apiKey, pathMappings)
-		respondJSON(c, http.StatusOK, gin.H{"providerURL": providerURL, "apiKey": apiKey, "pathMappings": pathMappings})
+		respondJSON(c, http.StatusOK, gin.H{"providerURL": providerURL, "apiKey": apiKey, "pathMappings": pathMappings, "excludedRootFolders": extractExcludedRootFolders(sectionData)})
 	}
 }
 
@@ -1335,6 +2762,7 @@ func tryMergeRemoteFolders(section string, sectionData map[string]interface{}, p
 		return pathMappings
 	}
 	if folders, ferr := FetchRootFolders(providerURL, apiKey); ferr == nil {
+		folders = withoutExcludedFolders(folders, extractExcludedRootFolders(sectionData))
 		if merged, _, updated := mergeFoldersIntoMappings(pathMappings, mappings, mappingSet, folders); updated {
 			// Update response payload with merged folders and schedule background persistence.
 			pathMappings = merged
@@ -1357,6 +2785,7 @@ func backgroundFetchAndMerge(section string, providerURL string, apiKey string,
 		TrailarrLog(DEBUG, "Settings", "Background rootfolder fetch failed for %s: %v", section, err)
 		return
 	}
+	folders = withoutExcludedFolders(folders, GetExcludedRootFolders(section))
 	mergedPathMappings, _, updated := mergeFoldersIntoMappings(currentPathMappings, mappings, mappingSet, folders)
 	if !updated {
 		return
@@ -1407,7 +2836,11 @@ func parseSectionPathMappings(config map[string]interface{}, section string) (ma
 				}
 				mappings = append(mappings, map[string]string{"from": from, "to": to})
 				mappingSet[from] = true
-				pathMappings = append(pathMappings, map[string]interface{}{"from": from, "to": to})
+				entry := map[string]interface{}{"from": from, "to": to}
+				if isRegex, _ := mMap["regex"].(bool); isRegex {
+					entry["regex"] = true
+				}
+				pathMappings = append(pathMappings, entry)
 			}
 		}
 	}
@@ -1436,14 +2869,26 @@ func SaveSettingsHandler(section string) gin.HandlerFunc {
 			ProviderURL  string `json:"providerURL" yaml:"url"`
 			APIKey       string `json:"apiKey" yaml:"apiKey"`
 			PathMappings []struct {
-				From string `json:"from" yaml:"from"`
-				To   string `json:"to" yaml:"to"`
+				From  string `json:"from" yaml:"from"`
+				To    string `json:"to" yaml:"to"`
+				Regex bool   `json:"regex,omitempty" yaml:"regex,omitempty"`
 			} `json:"pathMappings" yaml:"pathMappings"`
+			// ExcludedRootFolders is optional; when omitted the stored list is kept.
+			ExcludedRootFolders *[]string `json:"excludedRootFolders"`
 		}
 		if err := c.BindJSON(&req); err != nil {
-			respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+			respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, ErrInvalidRequest)
 			return
 		}
+		for _, m := range req.PathMappings {
+			if !m.Regex {
+				continue
+			}
+			if err := validateRegexPathMapping(m.From); err != nil {
+				respondErrorCode(c, http.StatusBadRequest, CodeInvalidPathMapping, err.Error())
+				return
+			}
+		}
 		config, err := readConfigFile()
 		if err != nil {
 			config = map[string]interface{}{}
@@ -1453,11 +2898,16 @@ func SaveSettingsHandler(section string) gin.HandlerFunc {
 			"apiKey":       req.APIKey,
 			"pathMappings": req.PathMappings,
 		}
+		if req.ExcludedRootFolders != nil {
+			sectionData["excludedRootFolders"] = *req.ExcludedRootFolders
+		} else if prev, ok := config[section].(map[string]interface{}); ok && prev["excludedRootFolders"] != nil {
+			sectionData["excludedRootFolders"] = prev["excludedRootFolders"]
+		}
 		config[section] = sectionData
 
 		// Persist config
 		if err := writeConfigFile(config); err != nil {
-			respondError(c, http.StatusInternalServerError, err.Error())
+			respondErrorCode(c, http.StatusInternalServerError, CodeConfigWriteFailed, err.Error())
 			return
 		}
 
@@ -1465,11 +2915,142 @@ func SaveSettingsHandler(section string) gin.HandlerFunc {
 		if Config != nil {
 			Config[section] = sectionData
 		}
+		// Media reads apply the new path mappings; drop cached validators.
+		invalidateMediaETags()
 
 		// Trigger an immediate healthcheck task run so UI reflects new provider settings
 		triggerHealthcheckTaskAsync()
 
-		respondJSON(c, http.StatusOK, gin.H{"status": "saved"})
+		var saved [][]string
+		for _, m := range req.PathMappings {
+			if !m.Regex && m.From != "" && m.To != "" {
+				saved = append(saved, []string{m.From, m.To})
+			}
+		}
+		resp := gin.H{"status": "saved"}
+		if warnings := logPathMappingOverlaps(section, saved); len(warnings) > 0 {
+			resp["warnings"] = warnings
+		}
+		respondJSON(c, http.StatusOK, resp)
+	}
+}
+
+// logPathMappingOverlaps logs and returns the overlap warnings of the
+// mappings saved for section.
+func logPathMappingOverlaps(section string, mappings [][]string) []string {
+	warnings := pathMappingOverlapWarnings(mappings)
+	for _, w := range warnings {
+		TrailarrLog(WARN, "Settings", "%s: %s", section, w)
+	}
+	return warnings
+}
+
+// validateRegexPathMapping checks the from pattern of a regex path mapping.
+func validateRegexPathMapping(from string) error {
+	if from == "" {
+		return fmt.Errorf("regex path mapping requires a non-empty from pattern")
+	}
+	if _, err := regexp.Compile(from); err != nil {
+		return fmt.Errorf("invalid regex path mapping %q: %v", from, err)
+	}
+	return nil
+}
+
+// pathMappingEntries converts mappings from extractPathMappings into the
+// {from, to, regex} objects used by the API and config.yml.
+func pathMappingEntries(mappings [][]string) []map[string]interface{} {
+	entries := make([]map[string]interface{}, 0, len(mappings))
+	for _, m := range mappings {
+		entry := map[string]interface{}{"from": m[0], "to": m[1]}
+		if isRegexPathMapping(m) {
+			entry["regex"] = true
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// GetPathMappingsHandler returns only the pathMappings of a section
+// ("radarr" or "sonarr").
+func GetPathMappingsHandler(section string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg, err := readConfigFile()
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sec, _ := cfg[section].(map[string]interface{})
+		respondJSON(c, http.StatusOK, gin.H{"pathMappings": pathMappingEntries(extractPathMappings(sec))})
+	}
+}
+
+// PutPathMappingsHandler replaces the pathMappings of a section, leaving its
+// url, apiKey and other keys untouched. Every mapping needs a from and a to
+// (regex mappings may map to ""); duplicates by from keep the first entry.
+func PutPathMappingsHandler(section string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			PathMappings []struct {
+				From  string `json:"from"`
+				To    string `json:"to"`
+				Regex bool   `json:"regex"`
+			} `json:"pathMappings"`
+		}
+		if err := c.BindJSON(&req); err != nil || req.PathMappings == nil {
+			respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+			return
+		}
+		list := make([]map[string]string, 0, len(req.PathMappings))
+		for _, m := range req.PathMappings {
+			from, to := strings.TrimSpace(m.From), strings.TrimSpace(m.To)
+			if m.Regex {
+				if err := validateRegexPathMapping(m.From); err != nil {
+					respondErrorCode(c, http.StatusBadRequest, CodeInvalidPathMapping, err.Error())
+					return
+				}
+				list = append(list, map[string]string{"from": m.From, "to": m.To, "mode": pathMappingModeRegex})
+				continue
+			}
+			if from == "" || to == "" {
+				respondErrorCode(c, http.StatusBadRequest, CodeInvalidPathMapping, "path mappings require a non-empty from and to")
+				return
+			}
+			list = append(list, map[string]string{"from": from, "to": to})
+		}
+		var mappings [][]string
+		for _, m := range DeduplicateByKey(list, "from") {
+			if m["mode"] != "" {
+				mappings = append(mappings, []string{m["from"], m["to"], m["mode"]})
+			} else {
+				mappings = append(mappings, []string{m["from"], m["to"]})
+			}
+		}
+		entries := pathMappingEntries(mappings)
+
+		cfg, err := readConfigFile()
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		sec, _ := cfg[section].(map[string]interface{})
+		if sec == nil {
+			sec = map[string]interface{}{}
+		}
+		sec["pathMappings"] = entries
+		cfg[section] = sec
+		if err := writeConfigFile(cfg); err != nil {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if Config != nil {
+			Config[section] = sec
+		}
+		invalidateMediaETags()
+		resp := gin.H{"status": "saved", "pathMappings": entries}
+		if warnings := logPathMappingOverlaps(section, mappings); len(warnings) > 0 {
+			resp["warnings"] = warnings
+		}
+		respondJSON(c, http.StatusOK, resp)
 	}
 }
 
@@ -1483,6 +3064,60 @@ func triggerHealthcheckTaskAsync() {
 	}()
 }
 
+// secretConfigKeys are config keys holding credentials, compared
+// case-insensitively: provider and auth API keys, the TMDB key and the Plex
+// token.
+var secretConfigKeys = []string{"apikey", "tmdbkey", "token", "password"}
+
+// maskSecret shortens a secret for display: the first and last four
+// characters of long values, "****" for short ones and "" when unset.
+func maskSecret(s string) string {
+	switch {
+	case s == "":
+		return ""
+	case len(s) > 8:
+		return s[:4] + "..." + s[len(s)-4:]
+	default:
+		return "****"
+	}
+}
+
+// redactConfig returns a copy of a normalized config with every value under
+// a secretConfigKeys key masked by maskSecret, at any depth.
+func redactConfig(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			if s, ok := val.(string); ok && slices.Contains(secretConfigKeys, strings.ToLower(k)) {
+				out[k] = maskSecret(s)
+				continue
+			}
+			out[k] = redactConfig(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = redactConfig(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// GetConfigHandler serves GET /api/config: the whole config.yml, normalized,
+// with credentials masked.
+func GetConfigHandler(c *gin.Context) {
+	cfg, err := readConfigFile()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, redactConfig(cfg))
+}
+
 func getGeneralSettingsHandler(c *gin.Context) {
 	data, err := os.ReadFile(GetConfigPath())
 	if err != nil {
@@ -1499,6 +3134,7 @@ func getGeneralSettingsHandler(c *gin.Context) {
 	var autoDownloadExtras bool = true
 	var logLevel string = "Info"
 	var frontendUrl string = DefaultFrontendURL
+	extraFilenameTemplate := DefaultExtraFilenameTemplate
 	if general, ok := config["general"].(map[string]interface{}); ok {
 		if v, ok := general["tmdbKey"].(string); ok {
 			tmdbKey = v
@@ -1512,8 +3148,11 @@ func getGeneralSettingsHandler(c *gin.Context) {
 		if v, ok := general["frontendUrl"].(string); ok && v != "" {
 			frontendUrl = strings.TrimRight(v, "/")
 		}
+		if v, ok := general["extraFilenameTemplate"].(string); ok && v != "" {
+			extraFilenameTemplate = v
+		}
 	}
-	respondJSON(c, http.StatusOK, gin.H{"tmdbKey": tmdbKey, "autoDownloadExtras": autoDownloadExtras, "logLevel": logLevel, "frontendUrl": frontendUrl})
+	respondJSON(c, http.StatusOK, gin.H{"tmdbKey": tmdbKey, "autoDownloadExtras": autoDownloadExtras, "logLevel": logLevel, "frontendUrl": frontendUrl, "extraFilenameTemplate": extraFilenameTemplate})
 }
 
 func saveGeneralSettingsHandler(c *gin.Context) {
@@ -1522,6 +3161,8 @@ func saveGeneralSettingsHandler(c *gin.Context) {
 		AutoDownloadExtras *bool  `json:"autoDownloadExtras" yaml:"autoDownloadExtras"`
 		LogLevel           string `json:"logLevel" yaml:"logLevel"`
 		FrontendUrl        string `json:"frontendUrl" yaml:"frontendUrl"`
+		// ExtraFilenameTemplate is left unchanged when omitted.
+		ExtraFilenameTemplate *string `json:"extraFilenameTemplate" yaml:"extraFilenameTemplate"`
 	}
 	// Read and decode JSON manually to avoid issues where Gin's BindJSON
 	// may behave unexpectedly in some test environments. We still accept
@@ -1541,6 +3182,12 @@ func saveGeneralSettingsHandler(c *gin.Context) {
 		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
 		return
 	}
+	if req.ExtraFilenameTemplate != nil && strings.TrimSpace(*req.ExtraFilenameTemplate) != "" {
+		if err := ValidateExtraFilenameTemplate(*req.ExtraFilenameTemplate); err != nil {
+			respondError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 	// (no-op) proceed to save parsed request
 	// Debug logging to help diagnose CI failure where tmdbKey is not persisted.
 	TrailarrLog(DEBUG, "Settings", "saveGeneralSettingsHandler parsed request: tmdbKey=%s autoDownloadExtras=%v logLevel=%s", req.TMDBApiKey, req.AutoDownloadExtras, req.LogLevel)
@@ -1576,6 +3223,9 @@ func saveGeneralSettingsHandler(c *gin.Context) {
 	if req.FrontendUrl != "" {
 		general["frontendUrl"] = strings.TrimRight(req.FrontendUrl, "/")
 	}
+	if req.ExtraFilenameTemplate != nil {
+		general["extraFilenameTemplate"] = *req.ExtraFilenameTemplate
+	}
 	config["general"] = general
 	err = writeConfigFile(config)
 	if err != nil {
@@ -1722,10 +3372,114 @@ func SavePlexConfigHandler(c *gin.Context) {
 		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
 		return
 	}
+	test, _ := strconv.ParseBool(c.Query("test"))
+	force, _ := strconv.ParseBool(c.Query("force"))
+	var identity *plexServerIdentity
+	if test {
+		id, err := testPlexConfig(req)
+		if err != nil && !force {
+			code := CodeProviderUnreachable
+			var statusErr *providerStatusError
+			if errors.As(err, &statusErr) && (statusErr.status == http.StatusUnauthorized || statusErr.status == http.StatusForbidden) {
+				code = CodeUnauthorized
+			}
+			TrailarrLog(INFO, "Settings", "Plex connection test failed, not saving: %v", err)
+			respondErrorCode(c, http.StatusBadRequest, code, "plex connection test failed: "+err.Error())
+			return
+		} else if err != nil {
+			TrailarrLog(WARN, "Settings", "Plex connection test failed, saving anyway (force): %v", err)
+		} else {
+			identity = &id
+		}
+	}
 	if err := SavePlexConfig(req); err != nil {
 		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	resp := gin.H{"status": "saved"}
+	if identity != nil {
+		resp["serverName"] = identity.Name
+		resp["machineIdentifier"] = identity.MachineIdentifier
+	}
+	respondJSON(c, http.StatusOK, resp)
+}
+
+// testPlexConfig checks cfg against its Plex server before SavePlexConfigHandler
+// saves it. A blank token is tested with the stored one, which SavePlexConfig
+// keeps.
+func testPlexConfig(cfg PlexConfig) (plexServerIdentity, error) {
+	if cfg.Token == "" {
+		if stored, err := GetPlexConfig(); err == nil {
+			cfg.Token = stored.Token
+		}
+	}
+	return fetchPlexIdentity(cfg)
+}
+
+// GetEmbyConfig loads the emby section of config.yml.
+func GetEmbyConfig() (EmbyConfig, error) {
+	config, err := readConfigFile()
+	if err != nil {
+		return EmbyConfig{}, fmt.Errorf("settings not found: %w", err)
+	}
+	sec, ok := config["emby"].(map[string]interface{})
+	if !ok {
+		return EmbyConfig{}, fmt.Errorf(ErrSectionNotMap, "emby")
+	}
+	cfg := EmbyConfig{}
+	cfg.URL, _ = sec["url"].(string)
+	cfg.APIKey, _ = sec["apiKey"].(string)
+	cfg.Enabled, _ = sec["enabled"].(bool)
+	return cfg, nil
+}
+
+// SaveEmbyConfig saves the emby section of config.yml. An empty APIKey keeps
+// the stored one, since GetEmbyConfigHandler never returns it.
+func SaveEmbyConfig(cfg EmbyConfig) error {
+	config, err := readConfigFileRaw()
+	if err != nil {
+		config = map[string]interface{}{}
+	}
+	if cfg.APIKey == "" {
+		if sec, ok := config["emby"].(map[string]interface{}); ok {
+			cfg.APIKey, _ = sec["apiKey"].(string)
+		}
+	}
+	config["emby"] = map[string]interface{}{
+		"url":     trimTrailingSlash(strings.TrimSpace(cfg.URL)),
+		"apiKey":  cfg.APIKey,
+		"enabled": cfg.Enabled,
+	}
+	return writeConfigFile(config)
+}
+
+// Handler to get Emby config
+func GetEmbyConfigHandler(c *gin.Context) {
+	cfg, err := GetEmbyConfig()
+	if err != nil {
+		respondJSON(c, http.StatusOK, EmbyConfig{})
+		return
+	}
+	// Don't expose the API key in the response
+	cfg.APIKey = ""
+	respondJSON(c, http.StatusOK, cfg)
+}
+
+// Handler to save Emby config. Enabling Emby requires a url.
+func SaveEmbyConfigHandler(c *gin.Context) {
+	var req EmbyConfig
+	if err := c.BindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+		return
+	}
+	if req.Enabled && strings.TrimSpace(req.URL) == "" {
+		respondError(c, http.StatusBadRequest, "url is required when emby is enabled")
+		return
+	}
+	if err := SaveEmbyConfig(req); err != nil {
+		respondErrorCode(c, http.StatusInternalServerError, CodeConfigWriteFailed, err.Error())
+		return
+	}
 	respondJSON(c, http.StatusOK, gin.H{"status": "saved"})
 }
 
@@ -1986,56 +3740,136 @@ func ExchangePlexCodeHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"token": token})
 }
 
-// Fetch root folders from Radarr or Sonarr API
+// Fetch root folders from Radarr or Sonarr API, retrying transient failures.
+// Folders without a path are skipped.
 func FetchRootFolders(apiURL, apiKey string) ([]map[string]interface{}, error) {
-	req, err := http.NewRequest("GET", apiURL+"/api/v3/rootfolder", nil)
+	var folders []map[string]interface{}
+	var err error
+	for attempt := 1; attempt <= RootFolderFetchAttempts; attempt++ {
+		var retry bool
+		folders, retry, err = fetchRootFoldersOnce(apiURL, apiKey)
+		if err == nil || !retry {
+			break
+		}
+		if attempt < RootFolderFetchAttempts {
+			TrailarrLog(WARN, "Settings", "Root folder request failed (attempt %d/%d), retrying: %v", attempt, RootFolderFetchAttempts, err)
+			time.Sleep(ProviderRetryDelay)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	// Only return root folder paths
+	var rootFolderPaths []map[string]interface{}
+	for _, folder := range folders {
+		path, ok := folder["path"].(string)
+		if !ok || path == "" {
+			TrailarrLog(DEBUG, "Settings", "Skipping root folder without a path: %v", folder)
+			continue
+		}
+		rootFolderPaths = append(rootFolderPaths, map[string]interface{}{"path": path})
+	}
+	return rootFolderPaths, nil
+}
+
+// fetchRootFoldersOnce requests the root folders once. retry reports whether
+// the failure may be transient (network error or 5xx).
+func fetchRootFoldersOnce(apiURL, apiKey string) (folders []map[string]interface{}, retry bool, err error) {
+	req, err := http.NewRequest("GET", apiURL+arrAPIPath("rootfolder"), nil)
+	if err != nil {
+		return nil, false, err
+	}
 	req.Header.Set(HeaderApiKey, apiKey)
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: ProviderRequestTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		TrailarrLog(WARN, "Settings", ApiReturnedStatusFmt, resp.StatusCode)
-		return nil, fmt.Errorf(ApiReturnedStatusFmt, resp.StatusCode)
+		return nil, resp.StatusCode >= 500, fmt.Errorf(ApiReturnedStatusFmt, resp.StatusCode)
 	}
-	var folders []map[string]interface{}
 	if err := json.NewDecoder(resp.Body).Decode(&folders); err != nil {
-		return nil, err
-	}
-	// Only return root folder paths
-	var rootFolderPaths []map[string]interface{}
-	for _, folder := range folders {
-		if path, ok := folder["path"].(string); ok {
-			rootFolderPaths = append(rootFolderPaths, map[string]interface{}{"path": path})
-		}
+		return nil, false, err
 	}
-	return rootFolderPaths, nil
+	return folders, false, nil
 }
 
 // Test connection to Radarr/Sonarr by calling /api/v3/system/status
 func testMediaConnection(providerURL, apiKey, _ string) error {
-	endpoint := "/api/v3/system/status"
-	req, err := http.NewRequest("GET", providerURL+endpoint, nil)
+	_, err := fetchProviderVersion(providerURL, apiKey)
+	return err
+}
+
+// providerStatusError is a non-200 answer of Radarr/Sonarr.
+type providerStatusError struct {
+	status int
+}
+
+func (e *providerStatusError) Error() string {
+	return fmt.Sprintf(ApiReturnedStatusFmt, e.status)
+}
+
+// fetchProviderVersion calls /api/v3/system/status and returns the version
+// Radarr/Sonarr reports.
+func fetchProviderVersion(providerURL, apiKey string) (string, error) {
+	req, err := http.NewRequest("GET", providerURL+arrAPIPath("system/status"), nil)
 	if err != nil {
-		return err
+		return "", err
 	}
 	req.Header.Set(HeaderApiKey, apiKey)
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: ProviderRequestTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		TrailarrLog(WARN, "Settings", ApiReturnedStatusFmt, resp.StatusCode)
-		return fmt.Errorf(ApiReturnedStatusFmt, resp.StatusCode)
+		return "", &providerStatusError{status: resp.StatusCode}
+	}
+	var status struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return "", fmt.Errorf("decode system status: %w", err)
+	}
+	return status.Version, nil
+}
+
+// TestSettingsConnectionHandler handles POST /api/settings/<section>/test:
+// it checks a URL and API key against the provider without saving them.
+// Failures are reported in a 200 body like the other test endpoints, with
+// UNAUTHORIZED for a rejected API key and PROVIDER_UNREACHABLE otherwise.
+func TestSettingsConnectionHandler(section string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req struct {
+			ProviderURL string `json:"providerURL"`
+			APIKey      string `json:"apiKey"`
+		}
+		if err := c.BindJSON(&req); err != nil {
+			respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, ErrInvalidRequest)
+			return
+		}
+		providerURL := strings.TrimRight(strings.TrimSpace(req.ProviderURL), "/")
+		if providerURL == "" || req.APIKey == "" {
+			respondErrorCode(c, http.StatusBadRequest, CodeInvalidRequest, "providerURL and apiKey are required")
+			return
+		}
+		version, err := fetchProviderVersion(providerURL, req.APIKey)
+		if err != nil {
+			code := CodeProviderUnreachable
+			var statusErr *providerStatusError
+			if errors.As(err, &statusErr) && (statusErr.status == http.StatusUnauthorized || statusErr.status == http.StatusForbidden) {
+				code = CodeUnauthorized
+			}
+			TrailarrLog(INFO, "Settings", "%s connection test failed: %v", section, err)
+			respondJSON(c, http.StatusOK, gin.H{"success": false, "error": err.Error(), "code": code})
+			return
+		}
+		respondJSON(c, http.StatusOK, gin.H{"success": true, "version": version})
 	}
-	return nil
 }
 
 // Returns a slice of canonical extra types enabled in config
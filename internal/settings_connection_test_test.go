@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSettingsConnectionTestReportsVersionWithoutSaving(t *testing.T) {
+	CreateTempConfig(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != arrAPIPath("system/status") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get(HeaderApiKey) != "good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"appName":"Radarr","version":"5.8.3.8933"}`))
+	}))
+	defer ts.Close()
+
+	r := NewTestRouter()
+	r.POST("/api/settings/radarr/test", TestSettingsConnectionHandler("radarr"))
+	post := func(body string) (int, map[string]interface{}) {
+		w := DoRequest(r, "POST", "/api/settings/radarr/test", []byte(body))
+		var resp map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	code, resp := post(`{"providerURL":"` + ts.URL + `/","apiKey":"good"}`)
+	if code != 200 || resp["success"] != true || resp["version"] != "5.8.3.8933" {
+		t.Fatalf("expected success with the version, got %d %v", code, resp)
+	}
+	if url, _, _ := GetProviderUrlAndApiKey("radarr"); url == ts.URL {
+		t.Fatalf("expected the tested settings not to be saved")
+	}
+	if code, resp = post(`{"providerURL":"` + ts.URL + `","apiKey":"bad"}`); code != 200 || resp["success"] != false || resp["code"] != string(CodeUnauthorized) {
+		t.Fatalf("expected an UNAUTHORIZED failure, got %d %v", code, resp)
+	}
+	if code, resp = post(`{"providerURL":"http://127.0.0.1:1","apiKey":"good"}`); code != 200 || resp["code"] != string(CodeProviderUnreachable) {
+		t.Fatalf("expected PROVIDER_UNREACHABLE, got %d %v", code, resp)
+	}
+	if code, _ = post(`{"providerURL":"","apiKey":"good"}`); code != 400 {
+		t.Fatalf("expected 400 without a URL, got %d", code)
+	}
+}
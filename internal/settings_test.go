@@ -82,8 +82,8 @@ func TestGetTrustedProxies_DefaultAndConfig(t *testing.T) {
 	if err != nil {
 		t.Fatalf("GetTrustedProxies returned error: %v", err)
 	}
-	if len(proxies) != 1 || proxies[0] != "127.0.0.1" {
-		t.Fatalf("Expected default proxies [127.0.0.1], got %v", proxies)
+	if len(proxies) != 2 || proxies[0] != "127.0.0.1" || proxies[1] != "::1" {
+		t.Fatalf("Expected default proxies [127.0.0.1 ::1], got %v", proxies)
 	}
 	// Now write a config with a custom proxy and ensure it's returned
 	cfgMap := map[string]interface{}{
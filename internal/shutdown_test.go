@@ -0,0 +1,90 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDownloadQueueWorkerStopsAndResetsDownloading(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := StartDownloadQueueWorker(ctx)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("download worker did not stop after context cancellation")
+	}
+
+	client := GetStoreClient()
+	bg := context.Background()
+	_ = client.Del(bg, DownloadQueue)
+	items := []DownloadQueueItem{
+		{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "shutdown-a", Status: "downloading"},
+		{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "shutdown-b", Status: "failed"},
+	}
+	for _, it := range items {
+		b, _ := json.Marshal(it)
+		if err := client.RPush(bg, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush failed: %v", err)
+		}
+	}
+	defer client.Del(bg, DownloadQueue)
+
+	DrainDownloadQueueWorker(done)
+
+	vals, err := client.LRange(bg, DownloadQueue, 0, -1)
+	if err != nil || len(vals) != 2 {
+		t.Fatalf("unexpected queue contents: %v err=%v", vals, err)
+	}
+	want := []string{"queued", "failed"}
+	for i, v := range vals {
+		var it DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &it); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		if it.Status != want[i] {
+			t.Fatalf("item %s: expected status %q, got %q", it.YouTubeID, want[i], it.Status)
+		}
+	}
+}
+
+func TestDownloadQueueWorkerKeepsQueueOnStart(t *testing.T) {
+	client := GetStoreClient()
+	bg := context.Background()
+	_ = client.Del(bg, DownloadQueue)
+	defer client.Del(bg, DownloadQueue)
+	for _, it := range []DownloadQueueItem{
+		{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "restart-a", Status: "downloading"},
+		{MediaType: MediaTypeMovie, MediaId: 1, YouTubeID: "restart-b", Status: "downloaded"},
+	} {
+		b, _ := json.Marshal(it)
+		if err := client.RPush(bg, DownloadQueue, b); err != nil {
+			t.Fatalf("RPush failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	select {
+	case <-StartDownloadQueueWorker(ctx):
+	case <-time.After(5 * time.Second):
+		t.Fatalf("download worker did not stop after context cancellation")
+	}
+
+	vals, err := client.LRange(bg, DownloadQueue, 0, -1)
+	if err != nil || len(vals) != 2 {
+		t.Fatalf("expected the queue kept on start, got %v err=%v", vals, err)
+	}
+	want := []string{"queued", "downloaded"}
+	for i, v := range vals {
+		var it DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &it); err != nil {
+			t.Fatalf("unmarshal failed: %v", err)
+		}
+		if it.Status != want[i] {
+			t.Fatalf("item %s: expected status %q, got %q", it.YouTubeID, want[i], it.Status)
+		}
+	}
+}
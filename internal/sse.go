@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// activeSSEClients counts the SSE streams currently being served.
+var activeSSEClients int64
+
+// ActiveSSEClients returns the number of SSE streams currently open.
+func ActiveSSEClients() int64 {
+	return atomic.LoadInt64(&activeSSEClients)
+}
+
+// SSELimitMiddleware caps the number of concurrently open SSE streams to
+// general.maxSseClients. Requests beyond the cap are rejected with 503 before
+// any stream headers are written. Register it on every SSE route.
+func SSELimitMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := int64(GetMaxSSEClients())
+		n := atomic.AddInt64(&activeSSEClients, 1)
+		defer atomic.AddInt64(&activeSSEClients, -1)
+		if limit > 0 && n > limit {
+			TrailarrLog(WARN, "SSE", "Rejecting SSE client %s: %d active streams exceed limit %d", c.ClientIP(), n-1, limit)
+			respondError(c, http.StatusServiceUnavailable, "too many concurrent streams")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSSELimitMiddlewareRejectsExcessClients(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["maxSseClients"] = 1
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	r := NewTestRouter()
+	r.GET("/sse", SSELimitMiddleware(), func(c *gin.Context) {
+		close(entered)
+		<-release
+		c.Status(http.StatusOK)
+	})
+
+	done := make(chan int)
+	go func() {
+		done <- DoRequest(r, "GET", "/sse", nil).Code
+	}()
+	select {
+	case <-entered:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("first SSE client never reached the handler")
+	}
+
+	if w := DoRequest(r, "GET", "/sse", nil); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 for excess SSE client, got %d", w.Code)
+	}
+
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Fatalf("expected 200 for first SSE client, got %d", code)
+	}
+	if n := ActiveSSEClients(); n != 0 {
+		t.Fatalf("expected active SSE counter to return to 0, got %d", n)
+	}
+}
@@ -16,21 +16,34 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// latestRelease is a cached GitHub "latest release" lookup.
+type latestRelease struct {
+	tag       string
+	published time.Time
+	ts        time.Time
+}
+
 // Cache for latest releases to avoid hitting GitHub on every health check
 var latestReleaseCacheMu sync.Mutex
-var latestReleaseCache = map[string]struct {
-	tag string
-	ts  time.Time
-}{}
+var latestReleaseCache = map[string]latestRelease{}
 var latestReleaseTTL = 12 * time.Hour
 
+// fetchLatestGithubReleaseTag returns the tag of repo's latest release
+// without a leading "v".
 // It caches results for `latestReleaseTTL` to avoid frequent API calls.
 func fetchLatestGithubReleaseTag(repo string) (string, error) {
+	rel, err := fetchLatestGithubRelease(repo)
+	return rel.tag, err
+}
+
+// fetchLatestGithubRelease returns the tag and publish time of repo's latest
+// release, sharing the fetchLatestGithubReleaseTag cache.
+func fetchLatestGithubRelease(repo string) (latestRelease, error) {
 	latestReleaseCacheMu.Lock()
 	cached, ok := latestReleaseCache[repo]
 	latestReleaseCacheMu.Unlock()
 	if ok && time.Since(cached.ts) < latestReleaseTTL {
-		return cached.tag, nil
+		return cached, nil
 	}
 
 	// Use a short client timeout
@@ -40,26 +53,28 @@ func fetchLatestGithubReleaseTag(repo string) (string, error) {
 	req.Header.Set("User-Agent", "trailarr")
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return latestRelease{}, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected response: %s", resp.Status)
+		return latestRelease{}, fmt.Errorf("unexpected response: %s", resp.Status)
 	}
 	var payload struct {
-		TagName string `json:"tag_name"`
+		TagName     string    `json:"tag_name"`
+		PublishedAt time.Time `json:"published_at"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return "", err
+		return latestRelease{}, err
+	}
+	rel := latestRelease{
+		tag:       strings.TrimPrefix(strings.TrimSpace(payload.TagName), "v"),
+		published: payload.PublishedAt,
+		ts:        time.Now(),
 	}
-	tag := strings.TrimPrefix(strings.TrimSpace(payload.TagName), "v")
 	latestReleaseCacheMu.Lock()
-	latestReleaseCache[repo] = struct {
-		tag string
-		ts  time.Time
-	}{tag, time.Now()}
+	latestReleaseCache[repo] = rel
 	latestReleaseCacheMu.Unlock()
-	return tag, nil
+	return rel, nil
 }
 
 // compareVersion tries to perform a loose semver numeric comparison of two versions.
@@ -249,6 +264,26 @@ func SystemStatusHandler() gin.HandlerFunc {
 	}
 }
 
+// LivenessHandler handles GET /health/live. It always answers 200 while the
+// HTTP server is serving requests.
+func LivenessHandler(c *gin.Context) {
+	respondJSON(c, http.StatusOK, gin.H{"status": "alive"})
+}
+
+// ReadinessHandler handles GET /health/ready. It answers 503 until the store
+// is initialised and config.yml has been loaded.
+func ReadinessHandler(c *gin.Context) {
+	if err := StoreReady(c.Request.Context()); err != nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"status": "not ready", "reason": fmt.Sprintf("store: %v", err)})
+		return
+	}
+	if Config == nil {
+		respondJSON(c, http.StatusServiceUnavailable, gin.H{"status": "not ready", "reason": "config not loaded"})
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"status": "ready"})
+}
+
 func buildHealth(radarrURL, radarrKey, sonarrURL, sonarrKey string) []HealthMsg {
 	var health []HealthMsg
 	// Radarr: report only when misconfigured or unreachable. Do not append a "reachable" info message.
@@ -289,12 +324,100 @@ func tmdbHealthCheck() *HealthMsg {
 		return &HealthMsg{Message: fmt.Sprintf("TMDB connectivity failed: %v", err), Source: "TMDB", Level: "error"}
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &HealthMsg{Message: "TMDB API key is invalid", Source: "TMDB", Level: "error"}
+	}
 	if resp.StatusCode != http.StatusOK {
 		return &HealthMsg{Message: fmt.Sprintf("TMDB connectivity failed: %s", resp.Status), Source: "TMDB", Level: "error"}
 	}
 	return nil
 }
 
+// ytdlpHealthCheck runs `yt-dlp --version` and reports an issue when the
+// binary is missing or fails.
+func ytdlpHealthCheck() *HealthMsg {
+	out, err := ytDlpRunner.CombinedOutput(YtDlpPath, []string{"--version"}, "")
+	if err != nil {
+		return &HealthMsg{Message: fmt.Sprintf("yt-dlp not available at %s: %v", YtDlpPath, err), Source: "yt-dlp", Level: "error"}
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return &HealthMsg{Message: "yt-dlp --version returned no output", Source: "yt-dlp", Level: "error"}
+	}
+	return nil
+}
+
+// ffmpegHealthCheck reports an issue when no usable ffmpeg binary is found.
+func ffmpegHealthCheck() *HealthMsg {
+	switch getFfmpegVersion() {
+	case "Not found":
+		return &HealthMsg{Message: "ffmpeg not found", Source: "ffmpeg", Level: "error"}
+	case "":
+		return &HealthMsg{Message: "ffmpeg -version failed", Source: "ffmpeg", Level: "error"}
+	}
+	return nil
+}
+
+// hwaccelHealthCheck reports an issue when ytdlpFlags.hwaccel names a method
+// the installed ffmpeg does not list in `ffmpeg -hwaccels`.
+func hwaccelHealthCheck() *HealthMsg {
+	cfg, _ := GetYtdlpFlagsConfig()
+	if err := checkHwaccel(cfg); err != nil {
+		return &HealthMsg{Message: fmt.Sprintf("Requested hardware acceleration unavailable: %v", err), Source: "ffmpeg", Level: "warning"}
+	}
+	return nil
+}
+
+// pathMappingsWritableHealthCheck reports an issue when path mappings are
+// configured but extras cannot be written to any of their targets.
+func pathMappingsWritableHealthCheck() *HealthMsg {
+	if err := CheckPathMappingsWritable(); err != nil {
+		return &HealthMsg{Message: err.Error(), Source: "Media", Level: "error"}
+	}
+	return nil
+}
+
+// CheckPathMappingsWritable verifies that at least one radarr/sonarr path
+// mapping target is writable. Without any mappings there is nothing to check.
+func CheckPathMappingsWritable() error {
+	var targets []string
+	for _, mt := range []MediaType{MediaTypeMovie, MediaTypeTV} {
+		mappings, err := GetPathMappings(mt)
+		if err != nil {
+			return fmt.Errorf("failed to read path mappings: %w", err)
+		}
+		for _, m := range mappings {
+			// A regex mapping's to is a replacement, not a directory.
+			if isRegexPathMapping(m) {
+				continue
+			}
+			targets = append(targets, m[1])
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	var failures []string
+	for _, dir := range targets {
+		err := checkDirWritable(dir)
+		if err == nil {
+			return nil
+		}
+		failures = append(failures, err.Error())
+	}
+	return fmt.Errorf("media directory not writable: no path mapping target accepts writes (%s)", strings.Join(failures, "; "))
+}
+
+// checkDirWritable creates and removes a probe file in dir.
+func checkDirWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".trailarr-write-test-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
+
 func buildPathSet(radarrURL, radarrKey, sonarrURL, sonarrKey string) map[string]bool {
 	pathSet := map[string]bool{}
 
@@ -407,7 +530,13 @@ func getFfmpegVersion() string {
 	if err != nil {
 		return ""
 	}
-	s := strings.TrimSpace(string(out))
+	return parseFfmpegVersion(string(out))
+}
+
+// parseFfmpegVersion extracts the version from the first line of
+// `ffmpeg -version` output ("ffmpeg version 6.1.1 Copyright ...").
+func parseFfmpegVersion(out string) string {
+	s := strings.TrimSpace(out)
 	if s == "" {
 		return ""
 	}
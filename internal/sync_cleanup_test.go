@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSyncMediaCleansUpExtrasOfRemovedItems(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const keptId, removedId = 9101, 9102
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/movie" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": keptId, "title": "Kept", "hasFile": true}})
+	}))
+	defer ts.Close()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"url": ts.URL, "apiKey": "x"}
+	cfg["general"].(map[string]interface{})["syncCleanupRemovedMedia"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	defer GetStoreClient().Del(ctx, MoviesStoreKey)
+
+	// Previous sync saw both movies; the next one only returns keptId.
+	prev := []map[string]interface{}{{"id": keptId, "title": "Kept"}, {"id": removedId, "title": "Removed"}}
+	if err := SaveMediaToStore(MoviesStoreKey, prev); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	for _, e := range []ExtrasEntry{
+		{MediaType: MediaTypeMovie, MediaId: keptId, ExtraType: "Trailers", ExtraTitle: "Kept Trailer", YoutubeId: "kept-yt", Status: "downloaded"},
+		{MediaType: MediaTypeMovie, MediaId: removedId, ExtraType: "Trailers", ExtraTitle: "Removed Trailer", YoutubeId: "removed-yt", Status: "downloaded"},
+	} {
+		if err := AddOrUpdateExtra(ctx, e); err != nil {
+			t.Fatalf("AddOrUpdateExtra: %v", err)
+		}
+	}
+	defer RemoveExtra(ctx, "kept-yt", MediaTypeMovie, keptId)
+	defer RemoveExtra(ctx, "removed-yt", MediaTypeMovie, removedId)
+	if err := SetExtraTypesOverride(ctx, MediaTypeMovie, removedId, ExtraTypesConfig{Featurettes: true}); err != nil {
+		t.Fatalf("SetExtraTypesOverride: %v", err)
+	}
+	defer ClearExtraTypesOverride(ctx, MediaTypeMovie, removedId)
+	if err := SetExtraPin(ctx, ExtraPin{MediaType: MediaTypeMovie, MediaId: removedId, ExtraType: "Trailers", YoutubeId: "removed-yt"}); err != nil {
+		t.Fatalf("SetExtraPin: %v", err)
+	}
+	defer RemoveExtraPin(ctx, MediaTypeMovie, removedId, "Trailers")
+
+	if err := SyncMediaType(MediaTypeMovie); err != nil {
+		t.Fatalf("SyncMediaType: %v", err)
+	}
+
+	if extras, _ := GetExtrasForMedia(ctx, MediaTypeMovie, removedId); len(extras) != 0 {
+		t.Fatalf("expected extras of removed movie to be cleaned up, got %+v", extras)
+	}
+	if e, _ := GetExtraByYoutubeId(ctx, "removed-yt", MediaTypeMovie, removedId); e != nil {
+		t.Fatalf("expected removed extra gone from the global index, got %+v", e)
+	}
+	if extras, _ := GetExtrasForMedia(ctx, MediaTypeMovie, keptId); len(extras) != 1 {
+		t.Fatalf("expected extras of kept movie untouched, got %+v", extras)
+	}
+	if o, _ := GetExtraTypesOverride(ctx, MediaTypeMovie, removedId); o != nil {
+		t.Fatalf("expected the extra types override of the removed movie cleared, got %+v", o)
+	}
+	if pins, _ := GetExtraPinsForMedia(ctx, MediaTypeMovie, removedId); len(pins) != 0 {
+		t.Fatalf("expected the pins of the removed movie cleared, got %+v", pins)
+	}
+	wanted, _ := LoadWantedIndex(MoviesStoreKey)
+	for _, it := range wanted {
+		if id, _ := parseMediaID(it["id"]); id == removedId {
+			t.Fatalf("expected the removed movie dropped from the wanted index, got %+v", wanted)
+		}
+	}
+}
+
+func TestDiffMediaItems(t *testing.T) {
+	prev := []map[string]interface{}{{"id": 1.0}, {"id": 2.0}, {"id": 3.0}}
+	items := []map[string]interface{}{{"id": 2.0}, {"id": 4.0}, {"title": "no id"}}
+	added, removed := diffMediaItems(items, prev)
+	if len(added) != 1 || added[0]["id"] != 4.0 {
+		t.Fatalf("expected id 4 added, got %+v", added)
+	}
+	if len(removed) != 2 || removed[0] != 1 || removed[1] != 3 {
+		t.Fatalf("expected ids 1 and 3 removed, got %v", removed)
+	}
+}
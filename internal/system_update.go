@@ -2,16 +2,22 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,12 +27,16 @@ import (
 // the current binary in-place. This requires write permissions to the binary path.
 func handleYtdlpUpdate(c *gin.Context) {
 	TrailarrLog(INFO, "SystemUpdate", "Request to update yt-dlp via API")
-	err := updateYtdlp()
+	res, err := updateYtdlp()
 	if err != nil {
-		respondError(c, http.StatusInternalServerError, err.Error())
+		if res.RolledBack {
+			TrailarrLog(ERROR, "SystemUpdate", "yt-dlp update rolled back: %v", err)
+		}
+		respondJSON(c, http.StatusInternalServerError, gin.H{"success": false, "error": err.Error(), "rolledBack": res.RolledBack})
 		return
 	}
-	respondJSON(c, http.StatusOK, gin.H{"success": true})
+	invalidateToolVersions()
+	respondJSON(c, http.StatusOK, gin.H{"success": true, "version": res.Version})
 }
 
 // handleFfmpegUpdate attempts to download and install an ffmpeg binary
@@ -39,9 +49,71 @@ func handleFfmpegUpdate(c *gin.Context) {
 		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
+	invalidateToolVersions()
+	invalidateFfmpegHwaccels()
 	respondJSON(c, http.StatusOK, gin.H{"success": true})
 }
 
+// ToolVersions reports the installed yt-dlp and ffmpeg versions next to the
+// Trailarr build version. A tool that cannot be run has an empty version and
+// the failure in its *Error field.
+type ToolVersions struct {
+	Trailarr    string    `json:"trailarr"`
+	Ytdlp       string    `json:"ytdlp"`
+	YtdlpError  string    `json:"ytdlpError,omitempty"`
+	Ffmpeg      string    `json:"ffmpeg"`
+	FfmpegError string    `json:"ffmpegError,omitempty"`
+	CheckedAt   time.Time `json:"checkedAt"`
+}
+
+// toolVersionsTTL bounds how long GET /api/system/versions reuses a result
+// instead of forking yt-dlp and ffmpeg again.
+var toolVersionsTTL = time.Minute
+
+var (
+	toolVersionsMu    sync.Mutex
+	toolVersionsCache *ToolVersions
+)
+
+// invalidateToolVersions drops the cached versions so the next request sees
+// a freshly updated binary.
+func invalidateToolVersions() {
+	toolVersionsMu.Lock()
+	toolVersionsCache = nil
+	toolVersionsMu.Unlock()
+}
+
+// collectToolVersions runs `yt-dlp --version` and `ffmpeg -version` using the
+// configured binaries.
+func collectToolVersions() ToolVersions {
+	v := ToolVersions{Trailarr: getModuleVersion(), CheckedAt: time.Now()}
+	if out, err := ytDlpRunner.CombinedOutput(YtDlpPath, []string{"--version"}, ""); err != nil {
+		v.YtdlpError = err.Error()
+	} else {
+		v.Ytdlp = strings.TrimSpace(string(out))
+	}
+	cfg, _ := GetYtdlpFlagsConfig()
+	bin := ffmpegBinary(cfg.FfmpegLocation)
+	if out, err := ffmpegRunner.CombinedOutput(bin, []string{"-version"}, ""); err != nil {
+		v.FfmpegError = fmt.Sprintf("%s -version failed: %v", bin, err)
+	} else {
+		v.Ffmpeg = parseFfmpegVersion(string(out))
+	}
+	return v
+}
+
+// SystemVersionsHandler handles GET /api/system/versions. Results are cached
+// for toolVersionsTTL and refreshed after an update through the API.
+func SystemVersionsHandler(c *gin.Context) {
+	toolVersionsMu.Lock()
+	defer toolVersionsMu.Unlock()
+	if toolVersionsCache == nil || time.Since(toolVersionsCache.CheckedAt) >= toolVersionsTTL {
+		v := collectToolVersions()
+		toolVersionsCache = &v
+	}
+	respondJSON(c, http.StatusOK, toolVersionsCache)
+}
+
 // updateFfmpeg downloads a ffmpeg asset (best-effort) and installs it under TrailarrRoot/bin.
 func updateFfmpeg() error {
 	// Read configured timeout (may be increased for slow Docker hosts)
@@ -111,6 +183,17 @@ func updateFfmpeg() error {
 	if err := downloadAssetToFile(assetURL, tmpFile, timeout); err != nil {
 		return err
 	}
+	// BtbN publishes checksums.sha256 next to its builds. If it is missing
+	// (or the asset came from the direct 'latest' URL instead of the
+	// release listing) the build is installed unverified.
+	assetName := releaseAssetName(payload.Assets, assetURL)
+	if sumsURL := releaseAssetURL(payload.Assets, ffmpegChecksumsAsset); sumsURL != "" && assetName != "" {
+		if err := verifyAssetChecksum(tmpFile, sumsURL, assetName, timeout); err != nil {
+			return err
+		}
+	} else {
+		TrailarrLog(WARN, "SystemUpdate", "No %s published for %s; installing ffmpeg without checksum verification", ffmpegChecksumsAsset, assetURL)
+	}
 	if runtime.GOOS != "windows" {
 		_ = tmpFile.Chmod(0755)
 	}
@@ -238,9 +321,17 @@ func chooseFfmpegAsset(assets []struct {
 	return ""
 }
 
-// updateYtdlp performs the download and installation of the latest yt-dlp release.
+// YtdlpUpdateResult describes the outcome of updateYtdlp. RolledBack is set
+// when the new binary failed validation and the previous one was restored.
+type YtdlpUpdateResult struct {
+	Version    string
+	RolledBack bool
+}
+
+// updateYtdlp performs the download and installation of the latest yt-dlp
+// release, or of the release tagged general.ytdlpVersion when it is set.
 // It returns an error if anything fails.
-func updateYtdlp() error {
+func updateYtdlp() (YtdlpUpdateResult, error) {
 	// Fetch latest release metadata
 	timeout, err := GetYtDlpDownloadTimeout()
 	if err != nil {
@@ -248,19 +339,26 @@ func updateYtdlp() error {
 		timeout = 5 * time.Minute
 	}
 	client := &http.Client{Timeout: timeout}
-	url := "https://api.github.com/repos/yt-dlp/yt-dlp/releases/latest"
-	req, _ := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+	releaseURL := "https://api.github.com/repos/yt-dlp/yt-dlp/releases/latest"
+	pin := GetYtdlpVersionPin()
+	if pin != "" {
+		releaseURL = "https://api.github.com/repos/yt-dlp/yt-dlp/releases/tags/" + url.PathEscape(pin)
+	}
+	req, _ := http.NewRequestWithContext(context.Background(), "GET", releaseURL, nil)
 	req.Header.Set("User-Agent", "trailarr")
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch latest release: %w", err)
+		return YtdlpUpdateResult{}, fmt.Errorf("failed to fetch release metadata: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound && pin != "" {
+			return YtdlpUpdateResult{}, fmt.Errorf("yt-dlp release %q not found on GitHub (404). Check general.ytdlpVersion against https://github.com/yt-dlp/yt-dlp/releases", pin)
+		}
 		if resp.StatusCode == http.StatusNotFound {
-			return fmt.Errorf("no release found for yt-dlp on GitHub (404). Please check GitHub repo and network or update manually")
+			return YtdlpUpdateResult{}, fmt.Errorf("no release found for yt-dlp on GitHub (404). Please check GitHub repo and network or update manually")
 		}
-		return fmt.Errorf("unexpected response from github: %s", resp.Status)
+		return YtdlpUpdateResult{}, fmt.Errorf("unexpected response from github: %s", resp.Status)
 	}
 
 	var payload struct {
@@ -271,19 +369,19 @@ func updateYtdlp() error {
 		} `json:"assets"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return fmt.Errorf("failed to decode release metadata: %w", err)
+		return YtdlpUpdateResult{}, fmt.Errorf("failed to decode release metadata: %w", err)
 	}
 
 	// choose asset
 	assetURL := chooseYtDlpAsset(payload.Assets)
 	if assetURL == "" {
-		return errors.New("no suitable yt-dlp asset found for this platform")
+		return YtdlpUpdateResult{}, errors.New("no suitable yt-dlp asset found for this platform")
 	}
 
 	// Download asset to temp file
 	tmpFile, err := os.CreateTemp("", "yt-dlp-update-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+		return YtdlpUpdateResult{}, fmt.Errorf("failed to create temp file: %w", err)
 	}
 	defer func() {
 		tmpFile.Close()
@@ -293,7 +391,16 @@ func updateYtdlp() error {
 	// Download asset with configured timeout and retries
 	if err := downloadAssetToFile(assetURL, tmpFile, timeout); err != nil {
 		TrailarrLog(WARN, "SystemUpdate", "download attempts failed for %s: %v", assetURL, err)
-		return err
+		return YtdlpUpdateResult{}, err
+	}
+	// yt-dlp publishes SHA2-256SUMS with every release; refuse to install
+	// a binary that cannot be verified.
+	sumsURL := releaseAssetURL(payload.Assets, ytdlpChecksumsAsset)
+	if sumsURL == "" {
+		return YtdlpUpdateResult{}, fmt.Errorf("yt-dlp release %s has no %s asset; refusing to install an unverified binary", payload.TagName, ytdlpChecksumsAsset)
+	}
+	if err := verifyAssetChecksum(tmpFile, sumsURL, releaseAssetName(payload.Assets, assetURL), timeout); err != nil {
+		return YtdlpUpdateResult{}, err
 	}
 
 	// Ensure executable bit
@@ -304,21 +411,33 @@ func updateYtdlp() error {
 		}
 	}
 
-	// Find existing yt-dlp path at our configured YtDlpPath
-	path := YtDlpPath
+	return installYtdlp(tmpFile.Name(), YtDlpPath)
+}
+
+// installYtdlp installs the yt-dlp binary at src to path, then validates it
+// with `yt-dlp --version` like updateFfmpeg does with `ffmpeg -version`. An
+// existing binary is kept as path.bak until the new one runs and restored if
+// it does not. When path does not exist the binary goes to TrailarrRoot/bin
+// and YtDlpPath is updated.
+func installYtdlp(src, path string) (YtdlpUpdateResult, error) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		// Not found at configured path: ensure TrailarrRoot/bin exists then install
 		destDir := filepath.Join(TrailarrRoot, "bin")
 		if err := os.MkdirAll(destDir, 0755); err != nil {
-			return fmt.Errorf("yt-dlp not at configured path and unable to create bin dir: %w", err)
+			return YtdlpUpdateResult{}, fmt.Errorf("yt-dlp not at configured path and unable to create bin dir: %w", err)
 		}
 		dest := filepath.Join(destDir, YtDlpCmd)
-		// Update YtDlpPath to the new dest if install successful
-		if err := installBinary(tmpFile.Name(), dest); err != nil {
-			return err
+		if err := installBinary(src, dest); err != nil {
+			return YtdlpUpdateResult{}, err
 		}
+		version, err := ytdlpInstalledVersion(dest)
+		if err != nil {
+			_ = os.Remove(dest)
+			return YtdlpUpdateResult{}, err
+		}
+		// Update YtDlpPath to the new dest once it runs
 		YtDlpPath = dest
-		return nil
+		return YtdlpUpdateResult{Version: version}, nil
 	}
 
 	// Replace the existing binary (make a backup)
@@ -326,16 +445,36 @@ func updateYtdlp() error {
 	_ = os.Remove(backup)
 	if err := os.Rename(path, backup); err != nil {
 		// If rename fails due to permissions, fail fast
-		return fmt.Errorf("failed to backup existing yt-dlp binary: %w", err)
+		return YtdlpUpdateResult{}, fmt.Errorf("failed to backup existing yt-dlp binary: %w", err)
 	}
-	if err := installBinary(tmpFile.Name(), path); err != nil {
+	if err := installBinary(src, path); err != nil {
 		// attempt to restore backup
 		_ = os.Rename(backup, path)
-		return fmt.Errorf("failed to install yt-dlp: %w", err)
+		return YtdlpUpdateResult{}, fmt.Errorf("failed to install yt-dlp: %w", err)
+	}
+	version, err := ytdlpInstalledVersion(path)
+	if err != nil {
+		if rerr := os.Rename(backup, path); rerr != nil {
+			return YtdlpUpdateResult{}, fmt.Errorf("%v; restoring %s also failed: %w", err, backup, rerr)
+		}
+		return YtdlpUpdateResult{RolledBack: true}, fmt.Errorf("%w; restored the previous yt-dlp", err)
 	}
 	// remove backup on success
 	_ = os.Remove(backup)
-	return nil
+	return YtdlpUpdateResult{Version: version}, nil
+}
+
+// ytdlpInstalledVersion runs `bin --version` and returns the version printed.
+func ytdlpInstalledVersion(bin string) (string, error) {
+	out, err := ytDlpRunner.CombinedOutput(bin, []string{"--version"}, "")
+	version := strings.TrimSpace(string(out))
+	if err != nil {
+		return "", fmt.Errorf("installed yt-dlp failed to run: %v: %s", err, version)
+	}
+	if version == "" {
+		return "", errors.New("installed yt-dlp printed no version")
+	}
+	return version, nil
 }
 
 // chooseYtDlpAsset chooses a suitable release asset for current OS/ARCH.
@@ -647,3 +786,207 @@ func downloadAssetToFile(assetURL string, dest *os.File, timeout time.Duration)
 	}
 	return fmt.Errorf("failed to download asset after %d attempts", attempts)
 }
+
+// Checksum assets published next to the yt-dlp and BtbN ffmpeg releases, in
+// sha256sum format.
+const (
+	ytdlpChecksumsAsset  = "SHA2-256SUMS"
+	ffmpegChecksumsAsset = "checksums.sha256"
+)
+
+// releaseAsset is one entry of a GitHub release's assets list.
+type releaseAsset = struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// releaseAssetURL returns the download URL of the asset called name.
+func releaseAssetURL(assets []releaseAsset, name string) string {
+	for _, a := range assets {
+		if a.Name == name {
+			return a.BrowserDownloadURL
+		}
+	}
+	return ""
+}
+
+// releaseAssetName returns the name of the asset downloaded from assetURL.
+func releaseAssetName(assets []releaseAsset, assetURL string) string {
+	for _, a := range assets {
+		if a.BrowserDownloadURL == assetURL {
+			return a.Name
+		}
+	}
+	return ""
+}
+
+// verifyAssetChecksum downloads the sha256sum-style file at sumsURL and
+// checks that f, the downloaded asset called name, matches its entry.
+func verifyAssetChecksum(f *os.File, sumsURL, name string, timeout time.Duration) error {
+	sums, err := os.CreateTemp("", "checksums-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer func() { sums.Close(); _ = os.Remove(sums.Name()) }()
+	if err := downloadAssetToFile(sumsURL, sums, timeout); err != nil {
+		return fmt.Errorf("failed to download checksums: %w", err)
+	}
+	if _, err := sums.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read checksums: %w", err)
+	}
+	data, err := io.ReadAll(sums)
+	if err != nil {
+		return fmt.Errorf("failed to read checksums: %w", err)
+	}
+	want := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			want = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if want == "" {
+		return fmt.Errorf("no checksum listed for %s in %s", name, sumsURL)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", name, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", name, want, got)
+	}
+	TrailarrLog(INFO, "SystemUpdate", "Verified sha256 of %s", name)
+	return nil
+}
+
+// btbnFfmpegRepo publishes the ffmpeg builds installed by updateFfmpeg.
+const btbnFfmpegRepo = "BtbN/FFmpeg-Builds"
+
+// ffmpegBuildDatePattern matches the build date BtbN appends to ffmpeg
+// versions, e.g. "N-116576-g9d15fe77e3-20240806".
+var ffmpegBuildDatePattern = regexp.MustCompile(`-(\d{8})$`)
+
+// ytdlpUpdateNotice returns an info HealthMsg when the latest yt-dlp release
+// tag is newer than `yt-dlp --version`, or nil when it is up to date.
+func ytdlpUpdateNotice() (*HealthMsg, error) {
+	if pin := GetYtdlpVersionPin(); pin != "" {
+		TrailarrLog(DEBUG, "SystemUpdate", "yt-dlp pinned to %s; not checking for newer releases", pin)
+		return nil, nil
+	}
+	out, err := ytDlpRunner.CombinedOutput(YtDlpPath, []string{"--version"}, "")
+	if err != nil {
+		return nil, fmt.Errorf("yt-dlp --version failed: %w", err)
+	}
+	installed := strings.TrimSpace(string(out))
+	tag, err := fetchLatestGithubReleaseTag("yt-dlp/yt-dlp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest yt-dlp release: %w", err)
+	}
+	if compareVersion(tag, installed) <= 0 {
+		return nil, nil
+	}
+	return &HealthMsg{Message: fmt.Sprintf("yt-dlp update available: %s (installed %s)", tag, installed), Source: "yt-dlp", Level: "info"}, nil
+}
+
+// ffmpegUpdateNotice returns an info HealthMsg when the latest BtbN release
+// was published after the build date of the installed ffmpeg. BtbN tags are
+// not version numbers, so an ffmpeg without a BtbN build date (e.g. a distro
+// package) cannot be compared and is reported as an error instead.
+func ffmpegUpdateNotice() (*HealthMsg, error) {
+	cfg, _ := GetYtdlpFlagsConfig()
+	bin := ffmpegBinary(cfg.FfmpegLocation)
+	out, err := ffmpegRunner.CombinedOutput(bin, []string{"-version"}, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s -version failed: %w", bin, err)
+	}
+	installed := parseFfmpegVersion(string(out))
+	m := ffmpegBuildDatePattern.FindStringSubmatch(installed)
+	if m == nil {
+		return nil, fmt.Errorf("ffmpeg %s has no build date to compare with %s releases", installed, btbnFfmpegRepo)
+	}
+	built, err := time.Parse("20060102", m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ffmpeg build date in %s: %w", installed, err)
+	}
+	rel, err := fetchLatestGithubRelease(btbnFfmpegRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest ffmpeg release: %w", err)
+	}
+	if rel.published.IsZero() {
+		return nil, fmt.Errorf("latest %s release %s has no publish date", btbnFfmpegRepo, rel.tag)
+	}
+	published := rel.published.UTC().Truncate(24 * time.Hour)
+	if !published.After(built) {
+		return nil, nil
+	}
+	return &HealthMsg{Message: fmt.Sprintf("ffmpeg update available: %s build of %s (installed %s)", rel.tag, published.Format("2006-01-02"), installed), Source: "ffmpeg", Level: "info"}, nil
+}
+
+// runUpdateCheckTask is the updatecheck task: it records yt-dlp and ffmpeg
+// update notices under UpdateNoticesStoreKey and in the health issues list,
+// replacing the notices of the previous run.
+func runUpdateCheckTask() error {
+	var notices []HealthMsg
+	var errs []error
+	for _, check := range []func() (*HealthMsg, error){ytdlpUpdateNotice, ffmpegUpdateNotice} {
+		h, err := check()
+		if err != nil {
+			TrailarrLog(WARN, "SystemUpdate", "Update check failed: %v", err)
+			errs = append(errs, err)
+			continue
+		}
+		if h != nil {
+			TrailarrLog(INFO, "SystemUpdate", "%s", h.Message)
+			notices = append(notices, *h)
+		}
+	}
+
+	client := GetStoreClient()
+	ctx := context.Background()
+	previous := loadUpdateNotices()
+	_ = client.Del(ctx, UpdateNoticesStoreKey)
+	for _, h := range notices {
+		if b, err := json.Marshal(h); err == nil {
+			_ = client.RPush(ctx, UpdateNoticesStoreKey, b)
+		}
+	}
+
+	// Swap the previous notices for the new ones in the health issues list
+	// without waiting for the next healthcheck run.
+	vals, _ := client.LRange(ctx, HealthIssuesStoreKey, 0, -1)
+	issues := make([]HealthMsg, 0, len(vals)+len(notices))
+	for _, v := range vals {
+		var h HealthMsg
+		if err := json.Unmarshal([]byte(v), &h); err == nil && !slices.Contains(previous, h) {
+			issues = append(issues, h)
+		}
+	}
+	issues = append(issues, notices...)
+	_ = client.Del(ctx, HealthIssuesStoreKey)
+	for _, h := range issues {
+		if b, err := json.Marshal(h); err == nil {
+			_ = client.RPush(ctx, HealthIssuesStoreKey, b)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadUpdateNotices returns the notices recorded by the last updatecheck run.
+func loadUpdateNotices() []HealthMsg {
+	vals, err := GetStoreClient().LRange(context.Background(), UpdateNoticesStoreKey, 0, -1)
+	if err != nil {
+		return nil
+	}
+	notices := make([]HealthMsg, 0, len(vals))
+	for _, v := range vals {
+		var h HealthMsg
+		if err := json.Unmarshal([]byte(v), &h); err == nil {
+			notices = append(notices, h)
+		}
+	}
+	return notices
+}
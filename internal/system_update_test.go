@@ -2,6 +2,10 @@ package internal
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -173,3 +177,30 @@ func TestExtractAndInstall_TarXz_Shared(t *testing.T) {
 		t.Fatalf("lib not installed: %v", err)
 	}
 }
+
+func TestVerifyAssetChecksum(t *testing.T) {
+	binary := []byte("yt-dlp binary")
+	sum := sha256.Sum256(binary)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("0", 64) + "  yt-dlp.exe\n" + hex.EncodeToString(sum[:]) + "  yt-dlp_linux\n"))
+	}))
+	defer ts.Close()
+
+	f, err := os.CreateTemp(t.TempDir(), "asset-*")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(binary); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := verifyAssetChecksum(f, ts.URL, "yt-dlp_linux", 0); err != nil {
+		t.Fatalf("expected matching checksum, got %v", err)
+	}
+	if err := verifyAssetChecksum(f, ts.URL, "yt-dlp.exe", 0); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if err := verifyAssetChecksum(f, ts.URL, "yt-dlp_macos", 0); err == nil || !strings.Contains(err.Error(), "no checksum listed") {
+		t.Fatalf("expected a missing entry error, got %v", err)
+	}
+}
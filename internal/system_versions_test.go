@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+type versionRunner struct {
+	DefaultYtDlpRunner
+	calls *int32
+	out   string
+	err   error
+}
+
+func (v *versionRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	atomic.AddInt32(v.calls, 1)
+	return []byte(v.out), v.err
+}
+
+func TestSystemVersionsHandlerParsesAndCaches(t *testing.T) {
+	CreateTempConfig(t)
+	var calls int32
+	oldYt, oldFfmpeg := ytDlpRunner, ffmpegRunner
+	ytDlpRunner = &versionRunner{calls: &calls, out: "2024.08.06\n"}
+	ffmpegRunner = &versionRunner{calls: &calls, out: "ffmpeg version 6.1.1-static Copyright (c) 2000-2023\nbuilt with gcc 8\n"}
+	defer func() { ytDlpRunner, ffmpegRunner = oldYt, oldFfmpeg }()
+	invalidateToolVersions()
+	defer invalidateToolVersions()
+
+	r := NewTestRouter()
+	r.GET("/api/system/versions", SystemVersionsHandler)
+	for i := 0; i < 2; i++ {
+		w := DoRequest(r, "GET", "/api/system/versions", nil)
+		if w.Code != 200 {
+			t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+		}
+		var v ToolVersions
+		if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if v.Ytdlp != "2024.08.06" || v.Ffmpeg != "6.1.1-static" || v.Trailarr == "" {
+			t.Fatalf("unexpected versions: %+v", v)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected one yt-dlp and one ffmpeg run across cached requests, got %d", n)
+	}
+
+	invalidateToolVersions()
+	ffmpegRunner = &versionRunner{calls: &calls, err: errors.New("exec: not found")}
+	w := DoRequest(r, "GET", "/api/system/versions", nil)
+	var v ToolVersions
+	_ = json.Unmarshal(w.Body.Bytes(), &v)
+	if v.Ffmpeg != "" || v.FfmpegError == "" || v.Ytdlp != "2024.08.06" {
+		t.Fatalf("expected ffmpeg failure reported alongside yt-dlp version, got %+v", v)
+	}
+}
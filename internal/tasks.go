@@ -3,10 +3,14 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"os"
+	"runtime/debug"
 	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -58,10 +62,12 @@ type TaskSchedule struct {
 	TaskID        TaskID    `json:"taskId"`
 	Name          string    `json:"name"`
 	Interval      int       `json:"interval"`
+	Cron          string    `json:"cron,omitempty"`
 	LastExecution time.Time `json:"lastExecution"`
 	LastDuration  float64   `json:"lastDuration"`
 	NextExecution time.Time `json:"nextExecution"`
 	Status        string    `json:"status"`
+	LastError     string    `json:"lastError,omitempty"`
 }
 
 var taskStatusClientsMu sync.Mutex
@@ -100,6 +106,8 @@ type TaskState struct {
 	LastExecution time.Time `json:"lastExecution"`
 	LastDuration  float64   `json:"lastDuration"`
 	Status        string    `json:"status"`
+	// LastError is the error of the most recent run, empty when it succeeded.
+	LastError string `json:"lastError,omitempty"`
 }
 
 // TaskStates maps TaskID to TaskState
@@ -128,10 +136,12 @@ func init() {
 		}
 	}
 	tasksMeta = map[TaskID]TaskMeta{
-		"healthcheck": {ID: "healthcheck", Name: "Health Check", Function: wrapWithQueue("healthcheck", func() error { runHealthCheckTask(); return nil }), Order: 0},
-		"radarr":      {ID: "radarr", Name: "Sync with Radarr", Function: wrapWithQueue("radarr", func() error { return SyncMediaType(MediaTypeMovie) }), Order: 1},
-		"sonarr":      {ID: "sonarr", Name: "Sync with Sonarr", Function: wrapWithQueue("sonarr", func() error { return SyncMediaType(MediaTypeTV) }), Order: 2},
-		"extras":      {ID: "extras", Name: "Search for Missing Extras", Function: wrapWithQueue("extras", func() error { processExtras(context.Background()); return nil }), Order: 3},
+		"healthcheck": {ID: "healthcheck", Name: "Health Check", Function: wrapWithQueue("healthcheck", func(context.Context) error { runHealthCheckTask(); return nil }), Order: 0},
+		"radarr":      {ID: "radarr", Name: "Sync with Radarr", Function: wrapWithQueue("radarr", func(context.Context) error { return SyncMediaType(MediaTypeMovie) }), Order: 1},
+		"sonarr":      {ID: "sonarr", Name: "Sync with Sonarr", Function: wrapWithQueue("sonarr", func(context.Context) error { return SyncMediaType(MediaTypeTV) }), Order: 2},
+		"extras":      {ID: "extras", Name: "Search for Missing Extras", Function: wrapWithQueue("extras", func(ctx context.Context) error { processExtras(ctx); return nil }), Order: 3},
+		"mediacover":  {ID: "mediacover", Name: "Clean MediaCover Cache", Function: wrapWithQueue("mediacover", func(context.Context) error { _, err := CleanupMediaCover(GetMediaCoverTTL()); return err }), Order: 4},
+		"updatecheck": {ID: "updatecheck", Name: "Check for yt-dlp/ffmpeg Updates", Function: wrapWithQueue("updatecheck", func(context.Context) error { return runUpdateCheckTask() }), Order: 5},
 	}
 }
 
@@ -189,7 +199,7 @@ func initializeDefaultStates(states TaskStates) {
 		if v, ok := Timings[string(id)]; ok {
 			interval = v
 		}
-		if interval == 0 {
+		if interval == 0 && CronTimings[string(id)] == nil {
 			states[id] = TaskState{ID: id, LastExecution: time.Now(), LastDuration: 0}
 		} else {
 			states[id] = TaskState{ID: id, LastExecution: zeroTime, LastDuration: 0}
@@ -217,12 +227,35 @@ func saveTaskStates(states TaskStates) error {
 		arrStates[k] = v
 	}
 	globalTaskStatesMu.Unlock()
+	persistTaskStates(arrStates)
+	return nil
+}
+
+// saveCurrentTaskStates persists a snapshot of GlobalTaskStates taken under
+// the lock, for callers that only updated their own task's entry.
+func saveCurrentTaskStates() {
+	globalTaskStatesMu.RLock()
+	states := make(TaskStates, len(GlobalTaskStates))
+	for k, v := range GlobalTaskStates {
+		states[k] = v
+	}
+	globalTaskStatesMu.RUnlock()
+	persistTaskStates(states)
+}
 
+// taskStatesPersistMu serializes rewrites of TaskTimesStoreKey so concurrent
+// task completions do not interleave their Del/RPush sequences.
+var taskStatesPersistMu sync.Mutex
+
+// persistTaskStates writes the last execution times and errors in states to
+// the store.
+func persistTaskStates(arrStates TaskStates) {
 	arr := make([]struct {
 		ID            TaskID    `json:"taskId"`
 		LastExecution time.Time `json:"lastExecution"`
 		LastDuration  float64   `json:"lastDuration"`
-	}, 0, len(states))
+		LastError     string    `json:"lastError,omitempty"`
+	}, 0, len(arrStates))
 	for id, t := range arrStates {
 		taskId := t.ID
 		if taskId == "" {
@@ -232,22 +265,25 @@ func saveTaskStates(states TaskStates) error {
 			ID            TaskID    `json:"taskId"`
 			LastExecution time.Time `json:"lastExecution"`
 			LastDuration  float64   `json:"lastDuration"`
+			LastError     string    `json:"lastError,omitempty"`
 		}{
 			ID:            taskId,
 			LastExecution: t.LastExecution,
 			LastDuration:  t.LastDuration,
+			LastError:     t.LastError,
 		})
 	}
 	// Persist to the store as list of task states (overwrite by deleting and RPUSH)
 	client := GetStoreClient()
 	ctx := context.Background()
+	taskStatesPersistMu.Lock()
+	defer taskStatesPersistMu.Unlock()
 	_ = client.Del(ctx, TaskTimesStoreKey)
 	for _, s := range arr {
 		if b, err := json.Marshal(s); err == nil {
 			_ = client.RPush(ctx, TaskTimesStoreKey, b)
 		}
 	}
-	return nil
 }
 
 func GetAllTasksStatus() gin.HandlerFunc {
@@ -274,6 +310,15 @@ func calcNext(lastExecution time.Time, interval int) time.Time {
 	return lastExecution.Add(time.Duration(interval) * time.Minute)
 }
 
+// calcNextForTask returns the next execution for a task, using its cron
+// expression when one is configured and the minute interval otherwise.
+func calcNextForTask(id TaskID, lastExecution time.Time) time.Time {
+	if cron := CronTimings[string(id)]; cron != nil {
+		return cron.Next(time.Now())
+	}
+	return calcNext(lastExecution, Timings[string(id)])
+}
+
 // Helper to build schedules array
 func buildSchedules(states TaskStates) []TaskSchedule {
 	schedules := make([]TaskSchedule, 0, len(tasksMeta))
@@ -289,24 +334,49 @@ func buildSchedules(states TaskStates) []TaskSchedule {
 	sort.Slice(ordered, func(i, j int) bool {
 		return ordered[i].order < ordered[j].order
 	})
+	disabled := GetDisabledTasks()
 	for _, ot := range ordered {
 		meta := tasksMeta[ot.id]
 		state := states[ot.id]
 		interval := Timings[string(ot.id)]
-		schedules = append(schedules, TaskSchedule{
+		cronExpr := ""
+		if cron := CronTimings[string(ot.id)]; cron != nil {
+			interval = 0
+			cronExpr = cron.String()
+		}
+		sched := TaskSchedule{
 			TaskID:        state.ID,
 			Name:          meta.Name,
 			Interval:      interval,
+			Cron:          cronExpr,
 			LastExecution: state.LastExecution,
 			LastDuration:  state.LastDuration,
-			NextExecution: calcNext(state.LastExecution, interval),
+			NextExecution: calcNextForTask(ot.id, state.LastExecution),
 			Status:        state.Status,
-		})
+			LastError:     state.LastError,
+		}
+		// A disabled task never runs on its own, so it has no next execution;
+		// neither does one with a zero interval and no cron expression.
+		// A forced run still reports "running" while it is in progress.
+		if (disabled[ot.id] || (interval <= 0 && cronExpr == "")) && state.Status != "running" {
+			sched.NextExecution = time.Time{}
+			sched.Status = "disabled"
+		}
+		schedules = append(schedules, sched)
 	}
 	return schedules
 }
 
 func buildTaskQueues() []TaskStatus {
+	queues := loadTaskQueueStatuses()
+	if len(queues) > 100 {
+		queues = queues[:100]
+	}
+	return queues
+}
+
+// loadTaskQueueStatuses returns every task run record from the store, newest first.
+func loadTaskQueueStatuses() []TaskStatus {
 	// Read queue from the persistent store so callers get the same data
 	// as the file-based API handler.
 	client := GetStoreClient()
@@ -332,12 +402,75 @@ func buildTaskQueues() []TaskStatus {
 		})
 	}
 	sortTaskQueuesByQueuedDesc(queues)
-	if len(queues) > 100 {
-		queues = queues[:100]
-	}
 	return queues
 }
 
+// TaskHistoryStats aggregates finished runs of a single task.
+type TaskHistoryStats struct {
+	Total           int     `json:"total"`
+	Success         int     `json:"success"`
+	Failed          int     `json:"failed"`
+	SuccessRate     float64 `json:"successRate"`
+	AverageDuration float64 `json:"averageDuration"`
+}
+
+// computeTaskHistoryStats computes stats over finished runs; running or
+// queued runs are not counted.
+func computeTaskHistoryStats(runs []TaskStatus) TaskHistoryStats {
+	var st TaskHistoryStats
+	var totalDuration float64
+	for _, r := range runs {
+		switch r.Status {
+		case "success":
+			st.Success++
+		case "failed":
+			st.Failed++
+		default:
+			continue
+		}
+		totalDuration += r.Duration
+	}
+	st.Total = st.Success + st.Failed
+	if st.Total > 0 {
+		st.SuccessRate = float64(st.Success) / float64(st.Total)
+		st.AverageDuration = totalDuration / float64(st.Total)
+	}
+	return st
+}
+
+// GetTaskHistoryHandler handles GET /api/tasks/:taskId/history and returns the
+// last N runs of a task (query param limit, default 50) with aggregate stats
+// computed over those runs.
+func GetTaskHistoryHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId := c.Param("taskId")
+		if _, ok := tasksMeta[TaskID(taskId)]; !ok {
+			respondError(c, http.StatusNotFound, "unknown task")
+			return
+		}
+		limit := 50
+		if v := c.Query("limit"); v != "" {
+			if n, err := strconv.Atoi(v); err == nil && n > 0 {
+				limit = n
+			} else {
+				respondError(c, http.StatusBadRequest, "invalid limit")
+				return
+			}
+		}
+		runs := Filter(loadTaskQueueStatuses(), func(ts TaskStatus) bool {
+			return ts.TaskId == taskId
+		})
+		if len(runs) > limit {
+			runs = runs[:limit]
+		}
+		respondJSON(c, http.StatusOK, gin.H{
+			"taskId": taskId,
+			"runs":   runs,
+			"stats":  computeTaskHistoryStats(runs),
+		})
+	}
+}
+
 func sortTaskQueuesByQueuedDesc(queues []TaskStatus) {
 	sort.Slice(queues, func(i, j int) bool {
 		return queues[i].Queued.After(queues[j].Queued)
@@ -463,35 +596,38 @@ func TaskHandler() gin.HandlerFunc {
 			respondError(c, http.StatusBadRequest, "unknown task")
 			return
 		}
-		// Run all tasks async, status managed in goroutine
+		// Run all tasks async, status managed in goroutine. Only this task's
+		// state is updated so tasks forced together (e.g. radarr and sonarr)
+		// do not overwrite each other's result.
 		go func(taskId TaskID, syncFunc func()) {
-			// Copy current in-memory state to avoid overwriting other running statuses
-			states := make(TaskStates)
-			for k, v := range GlobalTaskStates {
-				states[k] = v
-			}
-			// Set running flag for this task only
-			states[taskId] = TaskState{
+			globalTaskStatesMu.Lock()
+			prev := GlobalTaskStates[taskId]
+			GlobalTaskStates[taskId] = TaskState{
 				ID:            taskId,
-				LastExecution: states[taskId].LastExecution,
-				LastDuration:  states[taskId].LastDuration,
+				LastExecution: prev.LastExecution,
+				LastDuration:  prev.LastDuration,
 				Status:        "running",
+				LastError:     prev.LastError,
 			}
-			GlobalTaskStates = states
+			globalTaskStatesMu.Unlock()
 			broadcastTaskStatus(getCurrentTaskStatus())
 			start := time.Now()
-			syncFunc()
+			status := "idle"
+			if runTaskFunc(taskId, syncFunc) != nil {
+				status = "failed"
+			}
 			duration := time.Since(start)
-			// Set idle flag for this task only
-			states[taskId] = TaskState{
+			globalTaskStatesMu.Lock()
+			GlobalTaskStates[taskId] = TaskState{
 				ID:            taskId,
 				LastExecution: start,
 				LastDuration:  duration.Seconds(),
-				Status:        "idle",
+				Status:        status,
+				LastError:     GlobalTaskStates[taskId].LastError,
 			}
-			GlobalTaskStates = states
+			globalTaskStatesMu.Unlock()
 			broadcastTaskStatus(getCurrentTaskStatus())
-			saveTaskStates(states)
+			saveCurrentTaskStates()
 		}(t.id, t.syncFunc)
 		respondJSON(c, http.StatusOK, gin.H{"status": t.respond})
 	}
@@ -502,35 +638,43 @@ type bgTask struct {
 	started   *bool
 	syncFunc  func()
 	interval  time.Duration
+	cron      *CronSchedule
 	lastExec  time.Time
 	logPrefix string
 }
 
-func StartBackgroundTasks() {
+// StartBackgroundTasks schedules all background tasks. Schedulers stop
+// launching new runs once ctx is cancelled.
+func StartBackgroundTasks(ctx context.Context) {
 	TrailarrLog(INFO, "Tasks", "StartBackgroundTasks called. PID=%d, time=%s", os.Getpid(), time.Now().Format(time.RFC3339Nano))
 	states, err := LoadTaskStates()
 	if err != nil {
 		TrailarrLog(WARN, "Tasks", "Could not load last task times: %v", err)
 	}
 
-	taskList := buildBgTasks(states)
+	taskList := buildBgTasks(states, GetDisabledTasks())
 
 	for i := range taskList {
 		t := taskList[i]
-		if t.interval <= 0 {
+		if t.interval <= 0 && t.cron == nil {
 			TrailarrLog(WARN, "Tasks", "Task %s has non-positive interval, skipping scheduling", t.logPrefix)
 			continue
 		}
-		go scheduleTask(t)
+		go scheduleTask(ctx, t)
 	}
 	TrailarrLog(INFO, "Tasks", "Native Go scheduler started. Jobs will persist last execution times to store key %s", TaskTimesStoreKey)
 }
 
-func buildBgTasks(states TaskStates) []bgTask {
+func buildBgTasks(states TaskStates, disabled map[TaskID]bool) []bgTask {
 	var taskList []bgTask
 	for id, meta := range tasksMeta {
+		if disabled[id] {
+			TrailarrLog(INFO, "Tasks", "Task %s is disabled in config, not scheduling", meta.Name)
+			continue
+		}
+		cron := CronTimings[string(id)]
 		intervalVal, ok := Timings[string(id)]
-		if !ok {
+		if !ok && cron == nil {
 			TrailarrLog(WARN, "Tasks", "No interval found in Timings for %s", id)
 			intervalVal = 0
 		}
@@ -545,6 +689,7 @@ func buildBgTasks(states TaskStates) []bgTask {
 			started:   nil,
 			syncFunc:  meta.Function,
 			interval:  interval,
+			cron:      cron,
 			lastExec:  lastExec,
 			logPrefix: meta.Name,
 		})
@@ -552,7 +697,11 @@ func buildBgTasks(states TaskStates) []bgTask {
 	return taskList
 }
 
-func scheduleTask(t bgTask) {
+func scheduleTask(ctx context.Context, t bgTask) {
+	if t.cron != nil {
+		scheduleCronTask(ctx, t)
+		return
+	}
 	now := time.Now()
 	initialDelay := t.lastExec.Add(t.interval).Sub(now)
 	if initialDelay < 0 {
@@ -560,35 +709,189 @@ func scheduleTask(t bgTask) {
 	}
 	// Allow override for tests (TasksInitialDelay) when set to non-zero
 	if TasksInitialDelay > 0 {
-		time.Sleep(TasksInitialDelay)
+		initialDelay = TasksInitialDelay
 	} else {
-		time.Sleep(initialDelay)
+		initialDelay += startupJitter(GetTaskStartupJitter())
+	}
+	if !sleepCtx(ctx, initialDelay) {
+		return
 	}
 
 	ticker := time.NewTicker(t.interval)
 	defer ticker.Stop()
 
 	for {
-		if t.id == "extras" {
-			// Wait until radarr and sonarr have executed at least once
-			for {
-				globalTaskStatesMu.RLock()
-				st := make(TaskStates)
-				for k, v := range GlobalTaskStates {
-					st[k] = v
-				}
-				globalTaskStatesMu.RUnlock()
-				radLast := st["radarr"].LastExecution
-				sonLast := st["sonarr"].LastExecution
-				if !radLast.IsZero() && !sonLast.IsZero() {
-					break
-				}
-				TrailarrLog(INFO, "Tasks", "Waiting for radarr/sonarr to run before extras")
-				time.Sleep(TasksDepsWaitInterval)
+		if !launchScheduledTask(ctx, t) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			TrailarrLog(INFO, "Tasks", "Scheduler for %s stopped", t.logPrefix)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startupJitter returns a random delay in [0, limit) that staggers the first
+// runs of tasks due at the same time, typically at startup.
+func startupJitter(limit time.Duration) time.Duration {
+	if limit <= 0 {
+		return 0
+	}
+	return rand.N(limit)
+}
+
+// scheduleCronTask runs t at each time matched by its cron expression.
+func scheduleCronTask(ctx context.Context, t bgTask) {
+	for {
+		next := t.cron.Next(time.Now())
+		if next.IsZero() {
+			TrailarrLog(WARN, "Tasks", "Cron expression %q for %s never matches; not scheduling", t.cron, t.logPrefix)
+			return
+		}
+		TrailarrLog(DEBUG, "Tasks", "Next cron run for %s at %s", t.logPrefix, next.Format(time.RFC3339))
+		if !sleepCtx(ctx, time.Until(next)) {
+			TrailarrLog(INFO, "Tasks", "Scheduler for %s stopped", t.logPrefix)
+			return
+		}
+		if !launchScheduledTask(ctx, t) {
+			return
+		}
+	}
+}
+
+// launchScheduledTask starts one run of t, honouring the extras dependency on
+// radarr/sonarr. It returns false if ctx was cancelled while waiting.
+func launchScheduledTask(ctx context.Context, t bgTask) bool {
+	if t.id == "extras" {
+		deps, ok := waitForExtrasDependencies(ctx, extrasDependencies(GetDisabledTasks()), GetExtrasDepsMaxWait())
+		if !ok {
+			return false
+		}
+		if !extrasSyncDepsSucceeded(GetExtrasRetryFailedSync(), deps) {
+			TrailarrLog(WARN, "Tasks", "Deferring extras: last radarr/sonarr sync did not succeed")
+			return true
+		}
+	}
+	go runTaskAsync(TaskID(t.id), t.syncFunc)
+	return true
+}
+
+// extrasDependencies returns the syncs the extras task depends on: radarr and
+// sonarr, minus those whose task is disabled (managed outside the scheduler)
+// or whose provider is not configured.
+func extrasDependencies(disabled map[TaskID]bool) []TaskID {
+	var deps []TaskID
+	for _, dep := range []TaskID{"radarr", "sonarr"} {
+		if !disabled[dep] && isProviderConfigured(string(dep)) {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
+
+// waitForExtrasDependencies waits until every sync in deps has executed at
+// least once, or maxWait has passed, and returns the ones that have. ok is
+// false if ctx was cancelled while waiting.
+func waitForExtrasDependencies(ctx context.Context, deps []TaskID, maxWait time.Duration) (ran []TaskID, ok bool) {
+	deadline := time.Now().Add(maxWait)
+	for {
+		globalTaskStatesMu.RLock()
+		ran = ran[:0]
+		for _, dep := range deps {
+			if !GlobalTaskStates[dep].LastExecution.IsZero() {
+				ran = append(ran, dep)
 			}
 		}
-		go runTaskAsync(TaskID(t.id), t.syncFunc)
-		<-ticker.C
+		globalTaskStatesMu.RUnlock()
+		if len(ran) == len(deps) {
+			return ran, true
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			TrailarrLog(WARN, "Tasks", "Waited %v for %v to run before extras; proceeding with %v", maxWait, deps, ran)
+			return ran, true
+		}
+		TrailarrLog(INFO, "Tasks", "Waiting for radarr/sonarr to run before extras")
+		if !sleepCtx(ctx, min(TasksDepsWaitInterval, remaining)) {
+			return nil, false
+		}
+	}
+}
+
+// extrasSyncDepsSucceeded reports whether the latest runs of deps all
+// succeeded. When retry is true failed syncs are re-run once before giving
+// up, concurrently when several failed, so extras does not run against a
+// stale cache.
+func extrasSyncDepsSucceeded(retry bool, deps []TaskID) bool {
+	var failed []TaskID
+	for _, dep := range deps {
+		if !lastTaskRunSucceeded(dep) {
+			failed = append(failed, dep)
+		}
+	}
+	if retry {
+		var wg sync.WaitGroup
+		for _, dep := range failed {
+			meta, found := tasksMeta[dep]
+			if !found || meta.Function == nil {
+				continue
+			}
+			TrailarrLog(INFO, "Tasks", "Last %s sync did not succeed; retrying before extras", dep)
+			wg.Add(1)
+			go func(dep TaskID, fn func()) {
+				defer wg.Done()
+				runTaskAsync(dep, fn)
+			}(dep, meta.Function)
+		}
+		wg.Wait()
+	}
+	ok := true
+	for _, dep := range failed {
+		if retry && lastTaskRunSucceeded(dep) {
+			continue
+		}
+		TrailarrLog(WARN, "Tasks", "Last %s sync did not succeed", dep)
+		ok = false
+	}
+	return ok
+}
+
+// lastTaskRunSucceeded returns true when the most recent finished queue record
+// for taskId has status "success" or "cancelled"; a user-cancelled run is not
+// a failure. Running/queued records are skipped; a task with no finished
+// record is not considered successful.
+func lastTaskRunSucceeded(taskId TaskID) bool {
+	vals, err := GetStoreClient().LRange(context.Background(), TaskQueueStoreKey, 0, -1)
+	if err != nil {
+		return false
+	}
+	for i := len(vals) - 1; i >= 0; i-- {
+		var qi SyncQueueItem
+		if err := json.Unmarshal([]byte(vals[i]), &qi); err != nil || qi.TaskId != string(taskId) {
+			continue
+		}
+		if qi.Status == "running" || qi.Status == "queued" {
+			continue
+		}
+		return qi.Status == "success" || qi.Status == "cancelled"
+	}
+	return false
+}
+
+// sleepCtx sleeps for d or until ctx is cancelled; it returns false if ctx was cancelled.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
 }
 
@@ -650,8 +953,9 @@ func downloadMissingExtrasWithTypeFilter(ctx context.Context, cfg ExtraTypesConf
 
 	// Filter items: if we used the wanted index the items are already wanted-light entries
 	wantedItems := make([]map[string]interface{}, 0, len(items))
+	onlyMonitored := GetOnlyMonitored()
 	for _, item := range items {
-		if include, mediaId := shouldIncludeWantedItem(item, useWantedIndex, mediaType, enabledTypes, cacheFile); include {
+		if include, mediaId := shouldIncludeWantedItem(item, useWantedIndex, onlyMonitored, mediaType, enabledTypes, cacheFile); include {
 			wantedItems = append(wantedItems, item)
 		} else {
 			// extra debug already logged by helper when skipping
@@ -660,17 +964,24 @@ func downloadMissingExtrasWithTypeFilter(ctx context.Context, cfg ExtraTypesConf
 	}
 
 	TrailarrLog(INFO, "Tasks", "downloadMissingExtrasWithTypeFilter: %d wanted items after filtering for cache=%s mediaType=%v", len(wantedItems), cacheFile, mediaType)
-	for _, item := range wantedItems {
-		if ctx != nil && ctx.Err() != nil {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for d := range discoverWantedItems(ctx, mediaType, cacheFile, wantedItems, enabledTypes, GetExtrasDiscoveryConcurrency()) {
+		if ctx.Err() != nil {
 			TrailarrLog(INFO, "Tasks", "Extras download cancelled before processing item.")
 			break
 		}
-		processWantedItem(ctx, cfg, mediaType, cacheFile, item, enabledTypes)
+		if IsDownloadQueuePaused(ctx) {
+			TrailarrLog(INFO, "Tasks", "Downloads are paused, not enqueueing more extras.")
+			break
+		}
+		enqueueWantedExtras(ctx, cfg, mediaType, d)
 	}
 }
 
 // Helper: determine whether an item should be included in wantedItems
-func shouldIncludeWantedItem(item map[string]interface{}, useWantedIndex bool, mediaType MediaType, enabledTypes []string, cacheFile string) (bool, int) {
+func shouldIncludeWantedItem(item map[string]interface{}, useWantedIndex, onlyMonitored bool, mediaType MediaType, enabledTypes []string, cacheFile string) (bool, int) {
 	idRaw := item["id"]
 	titleRaw := item["title"]
 	TrailarrLog(DEBUG, "Tasks", "downloadMissingExtrasWithTypeFilter: inspecting item id=%v title=%v cache=%s", idRaw, titleRaw, cacheFile)
@@ -679,6 +990,10 @@ func shouldIncludeWantedItem(item map[string]interface{}, useWantedIndex bool, m
 		TrailarrLog(DEBUG, "Tasks", "downloadMissingExtrasWithTypeFilter: failed to parse media id for raw=%v, skipping", idRaw)
 		return false, 0
 	}
+	if onlyMonitored && !isMediaMonitored(item) {
+		TrailarrLog(DEBUG, "Tasks", "downloadMissingExtrasWithTypeFilter: mediaId=%d not monitored, skipping", mediaId)
+		return false, mediaId
+	}
 	if !useWantedIndex {
 		if !isMediaWanted(item) {
 			TrailarrLog(DEBUG, "Tasks", "downloadMissingExtrasWithTypeFilter: mediaId=%d not wanted, skipping", mediaId)
@@ -696,34 +1011,51 @@ func shouldIncludeWantedItem(item map[string]interface{}, useWantedIndex bool, m
 
 // processWantedItem encapsulates per-item processing previously inline in the large function.
 func processWantedItem(ctx context.Context, cfg ExtraTypesConfig, mediaType MediaType, cacheFile string, item map[string]interface{}, enabledTypes interface{}) {
+	d, ok := discoverWantedItem(ctx, mediaType, cacheFile, item, enabledTypes)
+	if !ok {
+		return
+	}
+	enqueueWantedExtras(ctx, cfg, mediaType, d)
+}
+
+// wantedDiscovery is the outcome of the discovery phase for one wanted item:
+// the extras to consider for download, from the store or from TMDB.
+type wantedDiscovery struct {
+	mediaId  int
+	title    string
+	extras   []Extra
+	usedTMDB bool
+}
+
+// discoverWantedItem looks up the extras of a wanted item and marks the ones
+// already downloaded or rejected. It only reads, so several items can be
+// discovered concurrently. ok is false when there is nothing to enqueue.
+func discoverWantedItem(ctx context.Context, mediaType MediaType, cacheFile string, item map[string]interface{}, enabledTypes interface{}) (wantedDiscovery, bool) {
 	mediaId, _ := parseMediaID(item["id"])
 	title, _ := item["title"].(string)
 
 	TrailarrLog(DEBUG, "Tasks", "processWantedItem: processing mediaType=%v mediaId=%d title=%q cache=%s enabledTypes=%v", mediaType, mediaId, title, cacheFile, enabledTypes)
 
-	extras, usedTMDB, err := fetchExtrasOrTMDB(mediaType, mediaId, title, enabledTypes)
+	extras, usedTMDB, err := fetchExtrasOrTMDB(ctx, mediaType, mediaId, title, enabledTypes)
 	if err != nil {
 		TrailarrLog(WARN, "Tasks", "SearchExtras/TMDB failed for mediaId=%v, title=%q: %v", mediaId, title, err)
-		return
+		return wantedDiscovery{}, false
 	}
 	TrailarrLog(DEBUG, "Tasks", "processWantedItem: fetched extras count=%d usedTMDB=%v for mediaId=%d title=%q", len(extras), usedTMDB, mediaId, title)
 	if len(extras) == 0 {
 		// Nothing to do
-		return
+		return wantedDiscovery{}, false
 	}
 
 	mediaPath, err := FindMediaPathByID(cacheFile, mediaId)
 	if err != nil || mediaPath == "" {
 		TrailarrLog(WARN, "Tasks", "FindMediaPathByID failed for mediaId=%v, title=%q cache=%s: %v", mediaId, title, cacheFile, err)
-		return
+		return wantedDiscovery{}, false
 	}
 
 	TrailarrLog(INFO, "Tasks", "Searching extras for %s %v: %s", mediaType, mediaId, item["title"])
 
-	var toDownload []Extra
-	if usedTMDB {
-		toDownload = extras
-	} else {
+	if !usedTMDB {
 		MarkDownloadedExtras(extras, mediaPath, "type", "title")
 		// Defensive: mark rejected extras before any download
 		rejectedExtras := GetRejectedExtrasForMedia(mediaType, mediaId)
@@ -732,43 +1064,117 @@ func processWantedItem(ctx context.Context, cfg ExtraTypesConfig, mediaType Medi
 			rejectedYoutubeIds[r.YoutubeId] = struct{}{}
 		}
 		MarkRejectedExtrasInMemory(extras, rejectedYoutubeIds)
-		toDownload = extras
 	}
-	TrailarrLog(DEBUG, "Tasks", "processWantedItem: mediaId=%d toDownload count=%d usedTMDB=%v mediaPath=%s", mediaId, len(toDownload), usedTMDB, mediaPath)
+	// Top up trailers from a YouTube search here rather than while
+	// enqueueing, so the searches of several items overlap.
+	if GetMaxTrailersPerType() > 0 {
+		global, _ := GetExtraTypesConfig()
+		extras = addSearchedTrailers(mediaType, mediaId, title, extras, extraTypesConfigForMedia(mediaType, mediaId, global))
+	}
+	TrailarrLog(DEBUG, "Tasks", "processWantedItem: mediaId=%d toDownload count=%d usedTMDB=%v mediaPath=%s", mediaId, len(extras), usedTMDB, mediaPath)
+	return wantedDiscovery{mediaId: mediaId, title: title, extras: extras, usedTMDB: usedTMDB}, true
+}
 
+// enqueueWantedExtras enqueues the discovered extras of one item, filtered by
+// its extra types override when it has one. Enqueueing stays sequential and
+// paced by the download queue; it stops when ctx is cancelled or downloads
+// are paused.
+func enqueueWantedExtras(ctx context.Context, cfg ExtraTypesConfig, mediaType MediaType, d wantedDiscovery) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cfg = extraTypesConfigForMedia(mediaType, d.mediaId, cfg)
 	// For each extra, download sequentially using a helper to reduce nesting.
-	for _, extra := range toDownload {
-		if ctx != nil && ctx.Err() != nil {
+	for _, extra := range limitExtrasPerType(mediaType, d.mediaId, d.extras) {
+		if ctx.Err() != nil {
 			TrailarrLog(INFO, "Tasks", "Extras download cancelled before processing extra.")
 			break
 		}
-		processExtraDownload(cfg, mediaType, mediaId, extra, usedTMDB)
+		if IsDownloadQueuePaused(ctx) {
+			TrailarrLog(INFO, "Tasks", "Downloads are paused, not enqueueing more extras for mediaId=%d.", d.mediaId)
+			break
+		}
+		processExtraDownload(ctx, cfg, mediaType, d.mediaId, extra, d.usedTMDB)
 	}
 }
 
+// discoverWantedItems runs discoverWantedItem for items on up to workers
+// goroutines and streams the results in completion order, so TMDB lookups
+// overlap while the caller enqueues downloads one at a time. The channel is
+// closed when every item is done or ctx is cancelled.
+func discoverWantedItems(ctx context.Context, mediaType MediaType, cacheFile string, items []map[string]interface{}, enabledTypes interface{}, workers int) <-chan wantedDiscovery {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan map[string]interface{})
+	out := make(chan wantedDiscovery)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				if ctx.Err() != nil {
+					return
+				}
+				d, ok := discoverWantedItem(ctx, mediaType, cacheFile, item, enabledTypes)
+				if !ok {
+					continue
+				}
+				select {
+				case out <- d:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, item := range items {
+			select {
+			case jobs <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
 // fetchExtrasOrTMDB centralizes SearchExtras + TMDB fallback and reduces branching in the caller.
-func fetchExtrasOrTMDB(mediaType MediaType, mediaId int, title string, enabledTypes interface{}) ([]Extra, bool, error) {
+// Pinned videos replace the candidates of their extra type in either source.
+// TMDB rate limiting is waited out while ctx allows.
+func fetchExtrasOrTMDB(ctx context.Context, mediaType MediaType, mediaId int, title string, enabledTypes interface{}) ([]Extra, bool, error) {
 	extras, err := SearchExtras(mediaType, mediaId)
 	if err != nil {
 		return nil, false, err
 	}
+	pins, err := GetExtraPinsForMedia(context.Background(), mediaType, mediaId)
+	if err != nil {
+		TrailarrLog(WARN, "Tasks", "Failed to load pinned extras for mediaId=%v: %v", mediaId, err)
+	}
 	if len(extras) == 0 {
 		TrailarrLog(INFO, "Tasks", "No extras found for mediaId=%v, title=%q, enabledTypes=%v, attempting TMDB fetch...", mediaId, title, enabledTypes)
-		tmdbExtras, err := FetchTMDBExtrasForMedia(mediaType, mediaId)
+		tmdbExtras, err := fetchTMDBExtrasWithBackoff(ctx, mediaType, mediaId)
 		if err != nil {
 			return nil, false, err
 		}
 		if len(tmdbExtras) == 0 {
 			TrailarrLog(INFO, "Tasks", "Still no extras after TMDB fetch for mediaId=%v, title=%q", mediaId, title)
-			return nil, false, nil
+			return applyExtraPins(nil, pins), false, nil
 		}
-		return tmdbExtras, true, nil
+		return applyExtraPins(tmdbExtras, pins), true, nil
 	}
-	return extras, false, nil
+	return applyExtraPins(extras, pins), false, nil
 }
 
 // processExtraDownload handles the per-extra checks and enqueues downloads when appropriate.
-func processExtraDownload(cfg ExtraTypesConfig, mediaType MediaType, mediaId int, extra Extra, usedTMDB bool) {
+func processExtraDownload(ctx context.Context, cfg ExtraTypesConfig, mediaType MediaType, mediaId int, extra Extra, usedTMDB bool) {
 	typ := canonicalizeExtraType(extra.ExtraType)
 	TrailarrLog(DEBUG, "Tasks", "processExtraDownload: mediaId=%d extraType=%s status=%s youtubeId=%s usedTMDB=%v", mediaId, extra.ExtraType, extra.Status, extra.YoutubeId, usedTMDB)
 	if !isExtraTypeEnabled(cfg, typ) {
@@ -782,8 +1188,14 @@ func processExtraDownload(cfg ExtraTypesConfig, mediaType MediaType, mediaId int
 	}
 	// For TMDB-fetched, always treat as missing if not present locally
 	if (usedTMDB && extra.YoutubeId != "") || (!usedTMDB && extra.Status == "missing" && extra.YoutubeId != "") {
+		if isExtraQueuedOrDownloaded(mediaType, mediaId, extra.YoutubeId) {
+			TrailarrLog(INFO, "Tasks", "processExtraDownload: youtubeId=%s already queued or downloaded for mediaId=%d, skipping type=%s", extra.YoutubeId, mediaId, extra.ExtraType)
+			return
+		}
 		TrailarrLog(INFO, "Tasks", "processExtraDownload: queuing extra mediaId=%d type=%s title=%q youtubeId=%s usedTMDB=%v", mediaId, extra.ExtraType, extra.ExtraTitle, extra.YoutubeId, usedTMDB)
-		if err := handleTypeFilteredExtraDownload(mediaType, mediaId, extra); err != nil {
+		if err := handleTypeFilteredExtraDownload(ctx, mediaType, mediaId, extra); errors.Is(err, errStopEnqueueing) {
+			TrailarrLog(INFO, "Tasks", "processExtraDownload: not queuing youtubeId=%s for mediaId=%d: %v", extra.YoutubeId, mediaId, err)
+		} else if err != nil {
 			TrailarrLog(WARN, "Tasks", "[SEQ] Download failed: %v", err)
 		}
 	} else {
@@ -792,19 +1204,22 @@ func processExtraDownload(cfg ExtraTypesConfig, mediaType MediaType, mediaId int
 }
 
 // Handles downloading a single extra and appending to history if successful
-func handleTypeFilteredExtraDownload(mediaType MediaType, mediaId int, extra Extra) error {
+func handleTypeFilteredExtraDownload(ctx context.Context, mediaType MediaType, mediaId int, extra Extra) error {
 	// Enqueue the extra for download using the queue system
 	item := DownloadQueueItem{
-		MediaType:  mediaType,
-		MediaId:    mediaId,
-		ExtraType:  extra.ExtraType,
-		ExtraTitle: extra.ExtraTitle,
-		YouTubeID:  extra.YoutubeId,
-		QueuedAt:   time.Now(),
+		MediaType:    mediaType,
+		MediaId:      mediaId,
+		ExtraType:    extra.ExtraType,
+		ExtraTitle:   extra.ExtraTitle,
+		YouTubeID:    extra.YoutubeId,
+		SeasonNumber: extra.SeasonNumber,
+		QueuedAt:     time.Now(),
 	}
 	// Wait for any currently queued download items to drain before enqueuing
 	// to avoid flooding the queue when many extras are discovered by the task.
-	waitForDownloadQueueDrain(mediaId, extra.YoutubeId)
+	if err := waitForDownloadQueueDrain(ctx, mediaId, extra.YoutubeId); err != nil {
+		return err
+	}
 	AddToDownloadQueue(item, "task")
 	TrailarrLog(INFO, "QUEUE", "[handleTypeFilteredExtraDownload] Enqueued extra: mediaType=%v, mediaId=%v, type=%s, title=%s, youtubeId=%s", mediaType, mediaId, extra.ExtraType, extra.ExtraTitle, extra.YoutubeId)
 
@@ -814,15 +1229,30 @@ func handleTypeFilteredExtraDownload(mediaType MediaType, mediaId int, extra Ext
 	return nil
 }
 
+// errStopEnqueueing is returned by waitForDownloadQueueDrain when the task
+// should stop enqueueing: it was cancelled, or downloads are paused and the
+// queue cannot drain.
+var errStopEnqueueing = errors.New("extras enqueueing stopped")
+
 // waitForDownloadQueueDrain polls the persistent download queue until there are
-// no items with status 'queued'. It logs and sleeps between attempts.
-func waitForDownloadQueueDrain(mediaId int, youtubeId string) {
+// no items with status 'queued'. It logs and sleeps between attempts, and
+// gives up with errStopEnqueueing once ctx is done or downloads are paused.
+func waitForDownloadQueueDrain(ctx context.Context, mediaId int, youtubeId string) error {
 	TrailarrLog(INFO, "Tasks", "Waiting for download queue to drain before enqueuing extra: mediaId=%d youtubeId=%s", mediaId, youtubeId)
 	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %v", errStopEnqueueing, ctx.Err())
+		}
+		if IsDownloadQueuePaused(ctx) {
+			return fmt.Errorf("%w: downloads are paused", errStopEnqueueing)
+		}
 		if !isDownloadQueueQueuedPresent() {
-			return
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(DownloadQueueWatcherInterval):
 		}
-		time.Sleep(DownloadQueueWatcherInterval)
 	}
 }
 
@@ -846,6 +1276,31 @@ func isDownloadQueueQueuedPresent() bool {
 	return false
 }
 
+// isExtraQueuedOrDownloaded reports whether youtubeId is already downloaded
+// for the media under any extra type, or is queued or downloading for it in
+// the live download queue. TMDB can tag one video with several types, and a
+// second enqueue would only waste a download slot.
+func isExtraQueuedOrDownloaded(mediaType MediaType, mediaId int, youtubeId string) bool {
+	ctx := context.Background()
+	if entry, err := GetExtraByYoutubeId(ctx, youtubeId, mediaType, mediaId); err == nil && entry != nil && entry.Status == "downloaded" {
+		return true
+	}
+	vals, err := GetStoreClient().LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		return false
+	}
+	for _, v := range vals {
+		var q DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &q); err != nil {
+			continue
+		}
+		if q.MediaType == mediaType && q.MediaId == mediaId && q.YouTubeID == youtubeId && (q.Status == "queued" || q.Status == "downloading") {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper: check if extra type is enabled in config
 func isExtraTypeEnabled(cfg ExtraTypesConfig, typ string) bool {
 	switch typ {
@@ -923,23 +1378,42 @@ func runTaskAsync(taskId TaskID, syncFunc func()) {
 		LastExecution: GlobalTaskStates[taskId].LastExecution, // unchanged until end
 		LastDuration:  GlobalTaskStates[taskId].LastDuration,
 		Status:        "running",
+		LastError:     GlobalTaskStates[taskId].LastError,
 	}
 	globalTaskStatesMu.Unlock()
 	broadcastTaskStatus(getCurrentTaskStatus())
 	start := time.Now()
-	syncFunc()
+	status := "idle"
+	if runTaskFunc(taskId, syncFunc) != nil {
+		status = "failed"
+	}
 	duration := time.Since(start)
-	// Set idle flag and update LastExecution to NOW (end of task)
+	// Set idle (or failed) flag and update LastExecution to NOW (end of task)
 	globalTaskStatesMu.Lock()
 	GlobalTaskStates[taskId] = TaskState{
 		ID:            taskId,
 		LastExecution: time.Now(),
 		LastDuration:  duration.Seconds(),
-		Status:        "idle",
+		Status:        status,
+		LastError:     GlobalTaskStates[taskId].LastError, // set by wrapWithQueue
 	}
 	globalTaskStatesMu.Unlock()
 	broadcastTaskStatus(getCurrentTaskStatus())
-	saveTaskStates(GlobalTaskStates)
+	saveCurrentTaskStates()
+}
+
+// runTaskFunc calls fn and recovers a panic into an error, logging it with
+// the stack, so a broken task run neither kills the process nor stops its
+// scheduler from running it again.
+func runTaskFunc(taskId TaskID, fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+			TrailarrLog(ERROR, "Tasks", "Task %s panicked: %v\n%s", taskId, r, debug.Stack())
+		}
+	}()
+	fn()
+	return nil
 }
 
 // Handler to return only the queue items as 'queues' array
@@ -953,8 +1427,66 @@ func GetTaskQueueHandler() gin.HandlerFunc {
 	}
 }
 
-// Centralized queue wrapper for all tasks
-func wrapWithQueue(taskId TaskID, syncFunc func() error) func() {
+// runningTask tracks the cancel func and queue record of an in-flight task run.
+type runningTask struct {
+	cancel context.CancelFunc
+	queued time.Time
+}
+
+var (
+	runningTasksMu sync.Mutex
+	runningTasks   = map[TaskID]*runningTask{}
+)
+
+// CancelTask cancels the context of the running task with the given id.
+// It returns the queue timestamp of the cancelled run and false if the task
+// is not running.
+func CancelTask(taskId TaskID) (time.Time, bool) {
+	runningTasksMu.Lock()
+	rt, ok := runningTasks[taskId]
+	runningTasksMu.Unlock()
+	if !ok {
+		return time.Time{}, false
+	}
+	rt.cancel()
+	return rt.queued, true
+}
+
+// CancelTaskHandler handles POST /api/tasks/:taskId/cancel. The running
+// task's context is cancelled, its state is set to idle and its queue record
+// is marked as cancelled.
+func CancelTaskHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		taskId := TaskID(c.Param("taskId"))
+		if _, ok := tasksMeta[taskId]; !ok {
+			respondError(c, http.StatusNotFound, "unknown task")
+			return
+		}
+		queued, ok := CancelTask(taskId)
+		if !ok {
+			respondError(c, http.StatusConflict, "task is not running")
+			return
+		}
+		TrailarrLog(INFO, "Tasks", "Task %s cancelled by user", taskId)
+		globalTaskStatesMu.Lock()
+		st := GlobalTaskStates[taskId]
+		st.ID = taskId
+		st.Status = "idle"
+		GlobalTaskStates[taskId] = st
+		globalTaskStatesMu.Unlock()
+		_ = updateTaskQueueItem(string(taskId), queued, func(qi *SyncQueueItem) {
+			qi.Status = "cancelled"
+			qi.Ended = time.Now()
+			qi.Duration = qi.Ended.Sub(queued)
+		})
+		broadcastTaskStatus(getCurrentTaskStatus())
+		respondJSON(c, http.StatusOK, gin.H{"status": "cancelled", "taskId": taskId})
+	}
+}
+
+// Centralized queue wrapper for all tasks. Each run gets its own cancellable
+// context which CancelTask can cancel.
+func wrapWithQueue(taskId TaskID, syncFunc func(ctx context.Context) error) func() {
 	return func() {
 		// Add new queue item to the persistent store on start
 		queued := time.Now()
@@ -966,14 +1498,36 @@ func wrapWithQueue(taskId TaskID, syncFunc func() error) func() {
 		}
 		_ = pushTaskQueueItem(item)
 
-		err := syncFunc()
+		ctx, cancel := context.WithCancel(context.Background())
+		rt := &runningTask{cancel: cancel, queued: queued}
+		runningTasksMu.Lock()
+		runningTasks[taskId] = rt
+		runningTasksMu.Unlock()
+		defer func() {
+			runningTasksMu.Lock()
+			if runningTasks[taskId] == rt {
+				delete(runningTasks, taskId)
+			}
+			runningTasksMu.Unlock()
+			cancel()
+		}()
+
+		var err error
+		if perr := runTaskFunc(taskId, func() { err = syncFunc(ctx) }); perr != nil {
+			err = perr
+		}
 		ended := time.Now()
 		duration := ended.Sub(queued)
 		status := "success"
-		if err != nil {
+		switch {
+		case ctx.Err() != nil:
+			status = "cancelled"
+			err = nil
+			TrailarrLog(INFO, "Tasks", "Task %s was cancelled.", taskId)
+		case err != nil:
 			status = "failed"
 			TrailarrLog(ERROR, "Tasks", "Task %s error: %s", taskId, err.Error())
-		} else {
+		default:
 			TrailarrLog(INFO, "Tasks", "Task %s completed successfully.", taskId)
 		}
 		// Update the last queue item for this task (by TaskId and Queued) in the persistent store
@@ -988,10 +1542,29 @@ func wrapWithQueue(taskId TaskID, syncFunc func() error) func() {
 				qi.Error = ""
 			}
 		})
+		if status != "cancelled" {
+			setTaskLastError(taskId, err)
+		}
 	}
 }
 
-// runHealthCheckTask performs provider connectivity checks and records any issues
+// setTaskLastError records err (nil clears it) as the LastError of taskId
+// in GlobalTaskStates; the run's final state update persists it.
+func setTaskLastError(taskId TaskID, err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	globalTaskStatesMu.Lock()
+	st := GlobalTaskStates[taskId]
+	st.ID = taskId
+	st.LastError = msg
+	GlobalTaskStates[taskId] = st
+	globalTaskStatesMu.Unlock()
+}
+
+// runHealthCheckTask performs provider connectivity checks plus TMDB key,
+// yt-dlp and ffmpeg checks, and records any issues
 // into the configured store key. If no issues are found the health issues key
 // is cleared so the UI badge/count reflects the current state.
 func runHealthCheckTask() {
@@ -1016,6 +1589,14 @@ func runHealthCheckTask() {
 		}
 	}
 
+	for _, check := range []func() *HealthMsg{tmdbHealthCheck, ytdlpHealthCheck, ffmpegHealthCheck, hwaccelHealthCheck, pathMappingsWritableHealthCheck} {
+		if h := check(); h != nil {
+			issues = append(issues, *h)
+		}
+	}
+	// Keep the notices recorded by the last updatecheck run.
+	issues = append(issues, loadUpdateNotices()...)
+
 	client := GetStoreClient()
 	ctx := context.Background()
 	// If no issues, clear the key so the UI stops showing stale problems
@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCancelTaskHandlerStopsRunningTask(t *testing.T) {
+	client := GetStoreClient()
+	_ = client.Del(context.Background(), TaskQueueStoreKey)
+	defer client.Del(context.Background(), TaskQueueStoreKey)
+
+	origMeta := tasksMeta
+	defer func() { tasksMeta = origMeta }()
+	started := make(chan struct{})
+	tasksMeta = map[TaskID]TaskMeta{
+		"slow": {ID: "slow", Name: "Slow", Function: wrapWithQueue("slow", func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})},
+	}
+	globalTaskStatesMu.Lock()
+	origStates := GlobalTaskStates
+	GlobalTaskStates = TaskStates{"slow": {ID: "slow", Status: "running"}}
+	globalTaskStatesMu.Unlock()
+	defer func() {
+		globalTaskStatesMu.Lock()
+		GlobalTaskStates = origStates
+		globalTaskStatesMu.Unlock()
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tasksMeta["slow"].Function()
+	}()
+	<-started
+
+	r := NewTestRouter()
+	r.POST("/api/tasks/:taskId/cancel", CancelTaskHandler())
+	if w := DoRequest(r, "POST", "/api/tasks/slow/cancel", nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("task did not stop after cancel")
+	}
+
+	globalTaskStatesMu.RLock()
+	status := GlobalTaskStates["slow"].Status
+	globalTaskStatesMu.RUnlock()
+	if status != "idle" {
+		t.Fatalf("expected task state idle, got %q", status)
+	}
+	runs := loadTaskQueueStatuses()
+	if len(runs) != 1 || runs[0].Status != "cancelled" {
+		t.Fatalf("expected a single cancelled queue record, got %+v", runs)
+	}
+
+	if w := DoRequest(r, "POST", "/api/tasks/slow/cancel", nil); w.Code != 409 {
+		t.Fatalf("expected 409 for a task that is not running, got %d", w.Code)
+	}
+	if w := DoRequest(r, "POST", "/api/tasks/nope/cancel", nil); w.Code != 404 {
+		t.Fatalf("expected 404 for unknown task, got %d", w.Code)
+	}
+}
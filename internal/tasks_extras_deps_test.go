@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func pushTaskResult(t *testing.T, taskId, status string) {
+	t.Helper()
+	b, _ := json.Marshal(SyncQueueItem{TaskId: taskId, Queued: time.Now(), Status: status})
+	if err := GetStoreClient().RPush(context.Background(), TaskQueueStoreKey, b); err != nil {
+		t.Fatalf("RPush failed: %v", err)
+	}
+}
+
+// configureProviders gives radarr and sonarr a URL and API key (or clears the
+// key when configured is false) so extras depends on their syncs.
+func configureProviders(t *testing.T, radarr, sonarr bool) {
+	t.Helper()
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	for provider, configured := range map[string]bool{"radarr": radarr, "sonarr": sonarr} {
+		apiKey := ""
+		if configured {
+			apiKey = "key"
+		}
+		cfg[provider] = map[string]interface{}{"url": "http://" + provider + ".invalid", "apiKey": apiKey}
+	}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+// setGlobalTaskStates replaces GlobalTaskStates for the rest of the test.
+func setGlobalTaskStates(t *testing.T, states TaskStates) {
+	t.Helper()
+	globalTaskStatesMu.Lock()
+	orig := GlobalTaskStates
+	GlobalTaskStates = states
+	globalTaskStatesMu.Unlock()
+	t.Cleanup(func() {
+		globalTaskStatesMu.Lock()
+		GlobalTaskStates = orig
+		globalTaskStatesMu.Unlock()
+	})
+}
+
+func runExtrasScheduler(t *testing.T) int32 {
+	t.Helper()
+	var calls int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduleTask(ctx, bgTask{
+			id:        "extras",
+			syncFunc:  func() { atomic.AddInt32(&calls, 1) },
+			interval:  20 * time.Millisecond,
+			logPrefix: "extras-test",
+		})
+	}()
+	time.Sleep(150 * time.Millisecond)
+	cancel()
+	<-done
+	return atomic.LoadInt32(&calls)
+}
+
+func TestExtrasSchedulerDefersWhenSyncsFailed(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, TaskQueueStoreKey)
+	defer client.Del(ctx, TaskQueueStoreKey)
+
+	configureProviders(t, true, true)
+	setGlobalTaskStates(t, TaskStates{
+		"radarr": {ID: "radarr", LastExecution: time.Now()},
+		"sonarr": {ID: "sonarr", LastExecution: time.Now()},
+	})
+
+	pushTaskResult(t, "radarr", "failed")
+	pushTaskResult(t, "sonarr", "success")
+	if n := runExtrasScheduler(t); n != 0 {
+		t.Fatalf("expected extras to be deferred after failed radarr sync, ran %d times", n)
+	}
+
+	pushTaskResult(t, "radarr", "success")
+	// an in-progress record must not hide the last finished result
+	pushTaskResult(t, "sonarr", "running")
+	if n := runExtrasScheduler(t); n == 0 {
+		t.Fatalf("expected extras to run once both syncs succeeded")
+	}
+
+	// a user-cancelled sync is not a failure
+	pushTaskResult(t, "radarr", "cancelled")
+	if n := runExtrasScheduler(t); n == 0 {
+		t.Fatalf("expected extras to run after a cancelled radarr sync")
+	}
+}
+
+func TestExtrasSchedulerWaitsOnlyForConfiguredProvidersAndBounded(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, TaskQueueStoreKey)
+	defer client.Del(ctx, TaskQueueStoreKey)
+
+	// radarr never ran: with only sonarr configured extras does not wait for it.
+	configureProviders(t, false, true)
+	setGlobalTaskStates(t, TaskStates{"sonarr": {ID: "sonarr", LastExecution: time.Now()}})
+	pushTaskResult(t, "sonarr", "success")
+	if n := runExtrasScheduler(t); n == 0 {
+		t.Fatalf("expected extras to run without waiting for unconfigured radarr")
+	}
+
+	// With both configured it waits for radarr, but only up to the max wait.
+	configureProviders(t, true, true)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["extrasDepsMaxWaitSeconds"] = 60
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if n := runExtrasScheduler(t); n != 0 {
+		t.Fatalf("expected extras to wait for radarr's first run, ran %d times", n)
+	}
+	ran, ok := waitForExtrasDependencies(ctx, []TaskID{"radarr", "sonarr"}, 30*time.Millisecond)
+	if !ok || len(ran) != 1 || ran[0] != "sonarr" {
+		t.Fatalf("expected the wait to give up on radarr and proceed with sonarr, got %v ok=%v", ran, ok)
+	}
+	cfg["general"].(map[string]interface{})["extrasDepsMaxWaitSeconds"] = 0
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if n := runExtrasScheduler(t); n == 0 {
+		t.Fatalf("expected extras to proceed with sonarr once the max wait is over")
+	}
+}
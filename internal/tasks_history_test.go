@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskHistoryHandlerFiltersAndAggregates(t *testing.T) {
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, TaskQueueStoreKey)
+	defer client.Del(ctx, TaskQueueStoreKey)
+
+	base := time.Now().Add(-time.Hour)
+	records := []SyncQueueItem{
+		{TaskId: "radarr", Queued: base, Status: "success", Duration: 2 * time.Second},
+		{TaskId: "sonarr", Queued: base.Add(time.Minute), Status: "failed", Duration: time.Second},
+		{TaskId: "radarr", Queued: base.Add(2 * time.Minute), Status: "failed", Duration: 4 * time.Second, Error: "boom"},
+		{TaskId: "radarr", Queued: base.Add(3 * time.Minute), Status: "running"},
+	}
+	for _, rec := range records {
+		b, _ := json.Marshal(rec)
+		if err := client.RPush(ctx, TaskQueueStoreKey, b); err != nil {
+			t.Fatalf("RPush: %v", err)
+		}
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/tasks/:taskId/history", GetTaskHistoryHandler())
+
+	w := DoRequest(r, "GET", "/api/tasks/radarr/history", nil)
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Runs  []TaskStatus     `json:"runs"`
+		Stats TaskHistoryStats `json:"stats"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Runs) != 3 {
+		t.Fatalf("expected 3 radarr runs, got %+v", resp.Runs)
+	}
+	if resp.Runs[0].Status != "running" || resp.Runs[1].Error != "boom" {
+		t.Fatalf("expected newest-first runs with error preserved, got %+v", resp.Runs)
+	}
+	if resp.Stats.Total != 2 || resp.Stats.Success != 1 || resp.Stats.Failed != 1 {
+		t.Fatalf("unexpected stats: %+v", resp.Stats)
+	}
+	if resp.Stats.SuccessRate != 0.5 || resp.Stats.AverageDuration != 3 {
+		t.Fatalf("unexpected success rate/average: %+v", resp.Stats)
+	}
+
+	w = DoRequest(r, "GET", "/api/tasks/radarr/history?limit=1", nil)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.Runs) != 1 {
+		t.Fatalf("expected limit=1 to return 1 run, got %d", len(resp.Runs))
+	}
+
+	if w := DoRequest(r, "GET", "/api/tasks/nope/history", nil); w.Code != 404 {
+		t.Fatalf("expected 404 for unknown task, got %d", w.Code)
+	}
+	if w := DoRequest(r, "GET", "/api/tasks/radarr/history?limit=x", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for invalid limit, got %d", w.Code)
+	}
+}
+
+func TestTaskStatusReportsLastError(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, TaskQueueStoreKey)
+	defer client.Del(ctx, TaskQueueStoreKey)
+	setGlobalTaskStates(t, TaskStates{})
+
+	r := NewTestRouter()
+	r.GET("/api/tasks/status", GetAllTasksStatus())
+	lastError := func() string {
+		t.Helper()
+		w := DoRequest(r, "GET", "/api/tasks/status", nil)
+		var resp struct {
+			Schedules []TaskSchedule `json:"schedules"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		for _, s := range resp.Schedules {
+			if s.TaskID == "radarr" {
+				return s.LastError
+			}
+		}
+		t.Fatalf("radarr missing from %s", w.Body.String())
+		return ""
+	}
+
+	runTaskAsync("radarr", wrapWithQueue("radarr", func(ctx context.Context) error {
+		return errors.New("radarr returned status 401")
+	}))
+	if got := lastError(); got != "radarr returned status 401" {
+		t.Fatalf("expected the failed run's error, got %q", got)
+	}
+	if states, _ := LoadTaskStates(); states["radarr"].LastError != "radarr returned status 401" {
+		t.Fatalf("expected the error persisted, got %+v", states["radarr"])
+	}
+	runTaskAsync("radarr", wrapWithQueue("radarr", func(ctx context.Context) error { return nil }))
+	if got := lastError(); got != "" {
+		t.Fatalf("expected a successful run to clear the error, got %q", got)
+	}
+}
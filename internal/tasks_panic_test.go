@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPanickingTaskKeepsBeingScheduled(t *testing.T) {
+	client := GetStoreClient()
+	_ = client.Del(context.Background(), TaskQueueStoreKey)
+	defer client.Del(context.Background(), TaskQueueStoreKey)
+
+	globalTaskStatesMu.Lock()
+	origStates := GlobalTaskStates
+	GlobalTaskStates = TaskStates{"boom": {ID: "boom", Status: "idle"}}
+	globalTaskStatesMu.Unlock()
+	defer func() {
+		globalTaskStatesMu.Lock()
+		GlobalTaskStates = origStates
+		globalTaskStatesMu.Unlock()
+	}()
+
+	var runs int32
+	fn := wrapWithQueue("boom", func(context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		panic("task exploded")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduleTask(ctx, bgTask{id: "boom", syncFunc: fn, interval: 20 * time.Millisecond, logPrefix: "Boom"})
+	}()
+	deadline := time.After(2 * time.Second)
+	for atomic.LoadInt32(&runs) < 3 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected the scheduler to keep running the task after a panic, got %d runs", atomic.LoadInt32(&runs))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	cancel()
+	<-done
+
+	// Wait for runs launched before the cancel to finish.
+	var runsRec []TaskStatus
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		runsRec = loadTaskQueueStatuses()
+		running := false
+		for _, rec := range runsRec {
+			running = running || rec.Status == "running"
+		}
+		if !running || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, rec := range runsRec {
+		if rec.Status != "failed" || !strings.Contains(rec.Error, "task exploded") {
+			t.Fatalf("expected every run recorded as failed with the panic, got %+v", runsRec)
+		}
+	}
+
+	// An unwrapped panicking function is recovered too and marks the task failed.
+	runTaskAsync("rawboom", func() { panic("raw panic") })
+	globalTaskStatesMu.RLock()
+	status := GlobalTaskStates["rawboom"].Status
+	globalTaskStatesMu.RUnlock()
+	if status != "failed" {
+		t.Fatalf("expected task state failed after a panic, got %q", status)
+	}
+}
@@ -0,0 +1,42 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskStartupJitterConfigAndBounds(t *testing.T) {
+	CreateTempConfig(t)
+	if got := GetTaskStartupJitter(); got != DefaultTaskStartupJitterSeconds*time.Second {
+		t.Fatalf("expected the default jitter, got %v", got)
+	}
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	for value, want := range map[int]time.Duration{0: 0, 5: 5 * time.Second, -1: DefaultTaskStartupJitterSeconds * time.Second} {
+		general["taskStartupJitterSeconds"] = value
+		if err := writeConfigFile(cfg); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+		if got := GetTaskStartupJitter(); got != want {
+			t.Fatalf("taskStartupJitterSeconds=%d: got %v, want %v", value, got, want)
+		}
+	}
+
+	if d := startupJitter(0); d != 0 {
+		t.Fatalf("expected no jitter when disabled, got %v", d)
+	}
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		d := startupJitter(time.Second)
+		if d < 0 || d >= time.Second {
+			t.Fatalf("jitter %v out of [0, 1s)", d)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Fatalf("expected tasks to get different delays, got %v", seen)
+	}
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestCreateTempPathsUsesConfiguredTempDir(t *testing.T) {
+	CreateTempConfig(t)
+	base := filepath.Join(t.TempDir(), "scratch")
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["tempDir"] = base
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	tempDir, tempFile, err := createTempPaths("Trailer", "mkv")
+	if err != nil {
+		t.Fatalf("createTempPaths: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+	if filepath.Dir(tempDir) != base || !strings.HasPrefix(tempFile, tempDir) {
+		t.Fatalf("expected temp paths under %s, got %s / %s", base, tempDir, tempFile)
+	}
+	regMutex.Lock()
+	registered := len(regTempDirs) > 0 && regTempDirs[len(regTempDirs)-1] == tempDir
+	regMutex.Unlock()
+	if !registered {
+		t.Fatalf("expected %s registered for cleanup", tempDir)
+	}
+
+	// A rename across filesystems is retried as a copy.
+	if err := os.WriteFile(tempFile, []byte("video"), 0o644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	outFile := filepath.Join(t.TempDir(), "Trailer.mkv")
+	exdev := &os.LinkError{Op: "rename", Old: tempFile, New: outFile, Err: syscall.EXDEV}
+	if err := handleCrossDeviceMove(tempFile, outFile, exdev); err != nil {
+		t.Fatalf("handleCrossDeviceMove: %v", err)
+	}
+	if b, err := os.ReadFile(outFile); err != nil || string(b) != "video" {
+		t.Fatalf("expected copied file, got %q err=%v", b, err)
+	}
+	if _, err := os.Stat(tempFile); !os.IsNotExist(err) {
+		t.Fatalf("expected temp file removed after copy")
+	}
+	if _, err := os.Stat(outFile + ".part"); !os.IsNotExist(err) {
+		t.Fatalf("expected no leftover .part file")
+	}
+}
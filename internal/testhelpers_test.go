@@ -2,6 +2,7 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -120,3 +121,45 @@ func DoRequest(r http.Handler, method, path string, body []byte) *httptest.Respo
 	r.ServeHTTP(w, req)
 	return w
 }
+
+// SeedMovieWithPath stores a single movie with a temp library path so
+// downloads for it resolve an output directory, and returns that path.
+func SeedMovieWithPath(t *testing.T, id int) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": id, "title": "Seeded", "path": dir}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	return dir
+}
+
+// SeedMovieExtraStatuses seeds movie id with one extra per status: a
+// downloaded trailer with its file on disk, a missing trailer, a missing
+// featurette and a rejected trailer. YouTube ids are prefix + "-got",
+// "-missing", "-feat" and "-rejected". Everything is removed on cleanup.
+func SeedMovieExtraStatuses(t *testing.T, id int, prefix string) {
+	t.Helper()
+	ctx := context.Background()
+	dir := SeedMovieWithPath(t, id)
+	if err := os.MkdirAll(filepath.Join(dir, "Trailers"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Trailers", "Got It.mkv"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Cleanup(func() { _ = SaveRejectedIndex() })
+	for _, e := range []ExtrasEntry{
+		{MediaType: MediaTypeMovie, MediaId: id, ExtraType: "Trailers", ExtraTitle: "Got It", YoutubeId: prefix + "-got", Status: "downloaded"},
+		{MediaType: MediaTypeMovie, MediaId: id, ExtraType: "Trailers", ExtraTitle: "Not Yet", YoutubeId: prefix + "-missing", Status: "missing"},
+		{MediaType: MediaTypeMovie, MediaId: id, ExtraType: "Featurettes", ExtraTitle: "Making Of", YoutubeId: prefix + "-feat", Status: "missing"},
+		{MediaType: MediaTypeMovie, MediaId: id, ExtraType: "Trailers", ExtraTitle: "Bad", YoutubeId: prefix + "-rejected", Status: "rejected", Reason: "manual"},
+	} {
+		if err := AddOrUpdateExtra(ctx, e); err != nil {
+			t.Fatalf("AddOrUpdateExtra: %v", err)
+		}
+		t.Cleanup(func() { _ = RemoveExtra(ctx, e.YoutubeId, MediaTypeMovie, id) })
+	}
+	if err := SaveRejectedIndex(); err != nil {
+		t.Fatalf("SaveRejectedIndex: %v", err)
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
 )
 
 // ErrTMDBNotFound is returned when a media entry exists in the cache but has no tmdbId
@@ -31,7 +35,7 @@ func FetchTMDBCast(mediaType MediaType, tmdbId int, tmdbKey string) ([]TMDBCastM
 	default:
 		return nil, fmt.Errorf("unsupported mediaType for cast fetch: %s", mediaType)
 	}
-	resp, err := http.Get(url)
+	resp, err := tmdbGet(url)
 	if err != nil {
 		return nil, err
 	}
@@ -126,9 +130,137 @@ func getCachedTMDBId(cachePath string, mediaId int) (int, error) {
 	return 0, ErrTMDBNotFound
 }
 
+// tmdbExtrasLimiter bounds the number of simultaneous TMDB extras fetches
+// across all callers (UI handlers, new-media detection and the extras task).
+var tmdbExtrasLimiter = newConcurrencyLimiter()
+
+// TMDBRateLimitError is returned for a TMDB request that was not sent because
+// the shared request budget is exhausted, or that TMDB answered with 429.
+// Callers should wait RetryAfter and try again instead of treating the media
+// as failed.
+type TMDBRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *TMDBRateLimitError) Error() string {
+	return fmt.Sprintf("TMDB rate limit reached, retry after %v", e.RetryAfter)
+}
+
+// tmdbRateLimiter is a token bucket shared by every TMDB request. The bucket
+// holds up to the configured number of requests and refills over the
+// window; the limit is read on every acquire so config changes apply
+// without restart.
+type tmdbRateLimiter struct {
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+var tmdbRequestLimiter = &tmdbRateLimiter{}
+
+// acquire reserves a request, sleeping until the bucket has room. A caller
+// that would have to wait longer than one window gets a TMDBRateLimitError
+// without reserving anything.
+func (l *tmdbRateLimiter) acquire() error {
+	requests, window := GetTMDBRateLimit()
+	if requests <= 0 {
+		return nil
+	}
+	capacity := float64(requests)
+	perToken := window / time.Duration(requests)
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.last.IsZero() {
+		l.tokens = capacity
+	} else {
+		l.tokens += float64(now.Sub(l.last)) / float64(perToken)
+		if l.tokens > capacity {
+			l.tokens = capacity
+		}
+	}
+	l.last = now
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) * float64(perToken))
+		if wait > window {
+			l.mu.Unlock()
+			return &TMDBRateLimitError{RetryAfter: wait}
+		}
+	}
+	l.tokens--
+	l.mu.Unlock()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return nil
+}
+
+// tmdbGet issues a GET to TMDB within the shared rate limit. A 429 response
+// is turned into a TMDBRateLimitError honoring TMDB's Retry-After, or one
+// second when TMDB sends none.
+func tmdbGet(url string) (*http.Response, error) {
+	if err := tmdbRequestLimiter.acquire(); err != nil {
+		return nil, err
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		resp.Body.Close()
+		retry := time.Second
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			retry = time.Duration(secs) * time.Second
+		}
+		return nil, &TMDBRateLimitError{RetryAfter: retry}
+	}
+	return resp, nil
+}
+
 func FetchTMDBExtras(mediaType MediaType, tmdbId int, tmdbKey string) ([]Extra, error) {
 	videosURL := fmt.Sprintf("https://api.themoviedb.org/3/%s/%d/videos?api_key=%s", mediaType, tmdbId, tmdbKey)
-	resp, err := http.Get(videosURL)
+	return fetchTMDBVideosByLanguage(videosURL)
+}
+
+// FetchTMDBSeasonExtras fetches the videos TMDB lists for one season of a TV
+// series and tags them with the season number.
+func FetchTMDBSeasonExtras(tmdbId, seasonNumber int, tmdbKey string) ([]Extra, error) {
+	videosURL := fmt.Sprintf("https://api.themoviedb.org/3/tv/%d/season/%d/videos?api_key=%s", tmdbId, seasonNumber, tmdbKey)
+	extras, err := fetchTMDBVideosByLanguage(videosURL)
+	if err != nil {
+		return nil, err
+	}
+	for i := range extras {
+		extras[i].SeasonNumber = seasonNumber
+	}
+	return extras, nil
+}
+
+// fetchTMDBVideosByLanguage requests videosURL in each of GetTMDBLanguages
+// and returns the videos of the first language that has any.
+func fetchTMDBVideosByLanguage(videosURL string) ([]Extra, error) {
+	langs := GetTMDBLanguages()
+	if len(langs) == 0 {
+		return fetchTMDBVideos(videosURL)
+	}
+	var extras []Extra
+	for _, lang := range langs {
+		var err error
+		extras, err = fetchTMDBVideos(videosURL + "&language=" + url.QueryEscape(lang))
+		if err != nil {
+			return nil, err
+		}
+		if len(extras) > 0 {
+			break
+		}
+		TrailarrLog(DEBUG, "TMDB", "No videos in language %s, trying next", lang)
+	}
+	return extras, nil
+}
+
+func fetchTMDBVideos(videosURL string) ([]Extra, error) {
+	resp, err := tmdbGet(videosURL)
 	if err != nil {
 		return nil, err
 	}
@@ -139,11 +271,12 @@ func FetchTMDBExtras(mediaType MediaType, tmdbId int, tmdbKey string) ([]Extra,
 	}
 	var result struct {
 		Results []struct {
-			ID   string `json:"id"`
-			Name string `json:"name"`
-			Key  string `json:"key"`
-			Site string `json:"site"`
-			Type string `json:"type"`
+			ID       string `json:"id"`
+			Name     string `json:"name"`
+			Key      string `json:"key"`
+			Site     string `json:"site"`
+			Type     string `json:"type"`
+			Official bool   `json:"official"`
 		} `json:"results"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
@@ -157,6 +290,7 @@ func FetchTMDBExtras(mediaType MediaType, tmdbId int, tmdbKey string) ([]Extra,
 				ExtraType:  r.Type,
 				ExtraTitle: r.Name,
 				YoutubeId:  r.Key,
+				Official:   r.Official,
 			})
 		}
 	}
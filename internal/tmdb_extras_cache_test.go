@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTMDBExtrasCachedAndRefreshed(t *testing.T) {
+	var hits int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/3/movie/8801/videos" {
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		n := atomic.AddInt32(&hits, 1)
+		_, _ = fmt.Fprintf(w, `{"results":[{"id":"v","name":"Trailer %d","key":"yt-cache-%d","site":"YouTube","type":"Trailer"}]}`, n, n)
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["tmdbKey"] = "dummy"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+
+	const movieId = 8802
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": movieId, "tmdbId": 8801, "title": "Cached"}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	cacheKey := fmt.Sprintf(TMDBExtrasCacheKeyFmt, MediaTypeMovie, movieId)
+	_ = GetStoreClient().Del(context.Background(), cacheKey)
+	defer GetStoreClient().Del(context.Background(), cacheKey)
+
+	for i := 0; i < 2; i++ {
+		extras, err := cachedTMDBExtrasForMedia(MediaTypeMovie, movieId, false)
+		if err != nil || len(extras) != 1 || extras[0].YoutubeId != "yt-cache-1" {
+			t.Fatalf("call %d: expected the first fetch to be served, got %+v err=%v", i, extras, err)
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("expected a single TMDB request, got %d", n)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/extras/:mediaType/:id/refresh", RefreshTMDBExtrasHandler)
+	if w := DoRequest(r, "POST", fmt.Sprintf("/api/extras/movie/%d/refresh", movieId), nil); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if extras, _ := cachedTMDBExtrasForMedia(MediaTypeMovie, movieId, false); len(extras) != 1 || extras[0].YoutubeId != "yt-cache-2" {
+		t.Fatalf("expected the refreshed extras cached, got %+v", extras)
+	}
+	if w := DoRequest(r, "POST", "/api/extras/music/1/refresh", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for an invalid mediaType, got %d", w.Code)
+	}
+
+	cfg["general"].(map[string]interface{})["tmdbExtrasCacheTtlMinutes"] = 0
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, _ = cachedTMDBExtrasForMedia(MediaTypeMovie, movieId, false)
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Fatalf("expected a fetch per call with the cache disabled, got %d requests", n)
+	}
+}
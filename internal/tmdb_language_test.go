@@ -0,0 +1,54 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestTMDBVideosUseLanguageWithFallback(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := r.URL.Query().Get("language")
+		mu.Lock()
+		requested = append(requested, lang)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if lang != "fr-FR" {
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"a","name":"Bande-annonce","key":"yt-fr","site":"YouTube","type":"Trailer"}]}`))
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["tmdbLanguage"] = "de-DE"
+	general["tmdbFallbackLanguages"] = []string{"not a language", "fr-FR", "en-US"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	extras, err := FetchTMDBExtras(MediaTypeMovie, 42, "dummy")
+	if err != nil {
+		t.Fatalf("FetchTMDBExtras: %v", err)
+	}
+	if len(extras) != 1 || extras[0].YoutubeId != "yt-fr" {
+		t.Fatalf("expected the fr-FR videos, got %+v", extras)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requested) != 2 || requested[0] != "de-DE" || requested[1] != "fr-FR" {
+		t.Fatalf("expected de-DE then fr-FR to be requested, got %v", requested)
+	}
+}
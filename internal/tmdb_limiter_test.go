@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Concurrent TMDB extras fetches from the UI handler path, new-media detection
+// and the extras task must share a single bound.
+func TestTMDBExtrasFetchesNeverExceedConcurrencyLimit(t *testing.T) {
+	const limit = 2
+	var inFlight, peak int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[]}`))
+	}))
+	defer ts.Close()
+
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["maxConcurrentTmdbFetches"] = limit
+	general["tmdbKey"] = "dummy"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+
+	const mediaId = 9301
+	items := []map[string]interface{}{{"id": mediaId, "tmdbId": 5501, "path": t.TempDir()}}
+	if err := SaveMediaToStore(MoviesStoreKey, items); err != nil {
+		t.Fatalf("failed to seed movies cache: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			_, _ = FetchTMDBExtrasForMedia(MediaTypeMovie, mediaId)
+		}()
+		go func() {
+			defer wg.Done()
+			processNewMediaExtras(MediaTypeMovie, mediaId, ExtraTypesConfig{Trailers: true})
+		}()
+		go func() {
+			defer wg.Done()
+			_, _, _ = fetchExtrasOrTMDB(context.Background(), MediaTypeMovie, mediaId, "Limited", nil)
+		}()
+	}
+	wg.Wait()
+
+	if p := atomic.LoadInt32(&peak); p > limit {
+		t.Fatalf("expected at most %d concurrent TMDB fetches, saw %d", limit, p)
+	} else if p == 0 {
+		t.Fatalf("expected TMDB to be called")
+	}
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOfficialTrailersOnlyFiltersAutoDownloadExtras(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"a","name":"Official Trailer","key":"yt-official","site":"YouTube","type":"Trailer","official":true},
+			{"id":"b","name":"Fan Trailer","key":"yt-fan","site":"YouTube","type":"Trailer","official":false},
+			{"id":"c","name":"Fan Featurette","key":"yt-featurette","site":"YouTube","type":"Featurette","official":false}
+		]}`))
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["tmdbKey"] = "dummy"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+	const mediaId = 9311
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": mediaId, "tmdbId": 5511}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+
+	ids := func() []string {
+		extras, err := FetchTMDBExtrasForMedia(MediaTypeMovie, mediaId)
+		if err != nil {
+			t.Fatalf("FetchTMDBExtrasForMedia: %v", err)
+		}
+		var out []string
+		for _, e := range extras {
+			out = append(out, e.YoutubeId)
+		}
+		return out
+	}
+	if got := ids(); len(got) != 3 {
+		t.Fatalf("expected all extras while disabled, got %v", got)
+	}
+
+	general["officialTrailersOnly"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if got := ids(); len(got) != 2 || got[0] != "yt-official" || got[1] != "yt-featurette" {
+		t.Fatalf("expected the fan trailer to be filtered, got %v", got)
+	}
+	if extras, err := fetchTMDBExtrasForMedia(MediaTypeMovie, mediaId, false); err != nil || len(extras) != 3 {
+		t.Fatalf("expected the UI path to stay unrestricted, got %d extras err=%v", len(extras), err)
+	}
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTMDBRateLimiterPacesRequestsAndReports429(t *testing.T) {
+	var hits, throttle int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		if atomic.CompareAndSwapInt32(&throttle, 1, 0) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"results":[{"id":"v","name":"Trailer","key":"yt-limited","site":"YouTube","type":"Trailer"}]}`))
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String()}
+	defer func() { http.DefaultTransport = oldTransport }()
+	oldLimiter := tmdbRequestLimiter
+	tmdbRequestLimiter = &tmdbRateLimiter{}
+	defer func() { tmdbRequestLimiter = oldLimiter }()
+
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["tmdbKey"] = "dummy"
+	general["tmdbRateLimitRequests"] = 4
+	general["tmdbRateLimitWindowSeconds"] = 1
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	origConfig := Config
+	Config = cfg
+	defer func() { Config = origConfig }()
+	const mediaId = 9401
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": mediaId, "tmdbId": 6601}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+
+	// A full bucket of 4 goes out at once; the next two wait 250ms each.
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if _, err := FetchTMDBExtras(MediaTypeMovie, 6601, "dummy"); err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected requests beyond the budget to be paced, took %v", elapsed)
+	}
+
+	atomic.StoreInt32(&throttle, 1)
+	_, err = FetchTMDBExtrasForMedia(MediaTypeMovie, mediaId)
+	var rl *TMDBRateLimitError
+	if !errors.As(err, &rl) || rl.RetryAfter != time.Second {
+		t.Fatalf("expected a TMDBRateLimitError with Retry-After, got %v", err)
+	}
+
+	atomic.StoreInt32(&throttle, 1)
+	extras, err := fetchTMDBExtrasWithBackoff(context.Background(), MediaTypeMovie, mediaId)
+	if err != nil || len(extras) != 1 || extras[0].YoutubeId != "yt-limited" {
+		t.Fatalf("expected the backoff to retry past the 429, got %+v err=%v", extras, err)
+	}
+}
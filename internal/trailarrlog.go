@@ -1,22 +1,59 @@
 package internal
 
 import (
+	"bufio"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 var (
 	logWriter     *os.File
 	logWriterOnce sync.Once
 	logFileBase   string
+	// logWriterSize tracks the size of the open log file; guarded by logMu.
+	logWriterSize int64
+	logMu         sync.Mutex
+)
+
+// Default log file rotation: rotate past 1 MB and keep 5 rotated files.
+const (
+	DefaultLogMaxSizeMB = 1
+	DefaultLogMaxFiles  = 5
+)
+
+// logSettingsRefreshInterval bounds how long a hand edit of config.yml takes
+// to reach the logger; writeConfigFile refreshes it immediately.
+var logSettingsRefreshInterval = 5 * time.Second
+
+// logSettings is the logger's view of general.logLevel, logMaxSizeMb and
+// logMaxFiles, cached so logging does not read config.yml for every line.
+type logSettings struct {
+	level    LogLevel
+	maxBytes int64
+	maxFiles int
+	loadedAt time.Time
+}
+
+var (
+	cachedLogSettings  atomic.Pointer[logSettings]
+	logSettingsLoading atomic.Bool
 )
 
 // Call this once at startup to set up the log file writer and reset log counter
 func InitTrailarrLogWriter(logPath string) {
 	logWriterOnce.Do(func() {
+		logMu.Lock()
+		defer logMu.Unlock()
 		logFileBase = logPath
 		openLogFile()
 	})
@@ -30,6 +67,10 @@ func openLogFile() {
 	f, err := os.OpenFile(logFileBase, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err == nil {
 		logWriter = f
+		logWriterSize = 0
+		if fi, err := f.Stat(); err == nil {
+			logWriterSize = fi.Size()
+		}
 	}
 }
 
@@ -58,47 +99,61 @@ func TrailarrLog(level LogLevel, component, message string, args ...interface{})
 	ms := now.Nanosecond() / 1e8 // tenths of a second
 	logLine := fmt.Sprintf("%s.%d|%s|%s|%s\n", timestamp, ms, level.Name, component, msg)
 	fmt.Fprint(os.Stdout, logLine)
-	if logWriter != nil {
-		logWriter.Write([]byte(logLine))
-		// Check file size and rotate if needed
-		logWriter.Sync() // flush to disk
-		fi, err := os.Stat(logFileBase)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "[TrailarrLog] Stat error: %v\n", err)
-		} else if fi.Size() > 1024*1024 { // 1MB
-			logWriter.Close()
-			ext := filepath.Ext(logFileBase)
-			base := logFileBase[:len(logFileBase)-len(ext)]
-			// Find all existing rotated log files and renumber them
-			pattern := fmt.Sprintf("%s-*.txt", base)
-			files, _ := filepath.Glob(pattern)
-			// Sort descending by number
-			type logFile struct {
-				path string
-				num  int
-			}
-			var logFiles []logFile
-			for _, f := range files {
-				var n int
-				fmt.Sscanf(f, base+"-%d.txt", &n)
-				if n > 0 {
-					logFiles = append(logFiles, logFile{f, n})
-				}
-			}
-			// Renumber from highest to lowest
-			for i := len(logFiles) - 1; i >= 0; i-- {
-				lf := logFiles[i]
-				newName := fmt.Sprintf("%s-%d.txt", base, lf.num+1)
-				os.Rename(lf.path, newName)
-			}
-			// Rename trailarr.txt to trailarr-1.txt
-			os.Rename(logFileBase, fmt.Sprintf("%s-1.txt", base))
-			openLogFile()
-			if logWriter == nil {
-				fmt.Fprintf(os.Stderr, "[TrailarrLog] Failed to open new trailarr.txt after rotation\n")
-			}
+	writeLogLine(logLine)
+}
+
+// writeLogLine appends a line to the log file and rotates it once it grows
+// past general.logMaxSizeMb. Goroutines log concurrently; logMu keeps writes
+// and rotation from interleaving. The settings are read before taking logMu:
+// loading them may read config.yml, which can log.
+func writeLogLine(logLine string) {
+	settings := currentLogSettings()
+	logMu.Lock()
+	defer logMu.Unlock()
+	if logWriter == nil {
+		return
+	}
+	n, _ := logWriter.WriteString(logLine)
+	logWriterSize += int64(n)
+	if settings.maxBytes > 0 && logWriterSize > settings.maxBytes {
+		rotateLogFile(settings.maxFiles)
+	}
+}
+
+// rotateLogFile renames trailarr.txt to trailarr-1.txt, shifting older files
+// up by one and removing those past maxFiles (0 keeps all). Callers hold
+// logMu.
+func rotateLogFile(maxFiles int) {
+	logWriter.Close()
+	logWriter = nil
+	ext := filepath.Ext(logFileBase)
+	base := logFileBase[:len(logFileBase)-len(ext)]
+	// Find all existing rotated log files and renumber them
+	files, _ := filepath.Glob(fmt.Sprintf("%s-*.txt", base))
+	var nums []int
+	for _, f := range files {
+		var n int
+		fmt.Sscanf(f, base+"-%d.txt", &n)
+		if n > 0 {
+			nums = append(nums, n)
 		}
 	}
+	// Renumber from highest to lowest
+	sort.Sort(sort.Reverse(sort.IntSlice(nums)))
+	for _, n := range nums {
+		old := fmt.Sprintf("%s-%d.txt", base, n)
+		if maxFiles > 0 && n >= maxFiles {
+			os.Remove(old)
+			continue
+		}
+		os.Rename(old, fmt.Sprintf("%s-%d.txt", base, n+1))
+	}
+	// Rename trailarr.txt to trailarr-1.txt
+	os.Rename(logFileBase, fmt.Sprintf("%s-1.txt", base))
+	openLogFile()
+	if logWriter == nil {
+		fmt.Fprintf(os.Stderr, "[TrailarrLog] Failed to open new trailarr.txt after rotation\n")
+	}
 }
 
 // Helper to get log level from config
@@ -109,23 +164,133 @@ func GetLogLevel() LogLevel {
 	}
 	if general, ok := config["general"].(map[string]interface{}); ok {
 		if v, ok := general["logLevel"].(string); ok {
-			switch v {
-			case "Debug":
-				return DEBUG
-			case "Info":
-				return INFO
-			case "Warn":
-				return WARN
-			case "Error":
-				return ERROR
+			if level, ok := parseLogLevel(v); ok {
+				return level
 			}
 		}
 	}
 	return DEBUG
 }
 
+// parseLogLevel maps a level name ("Debug", "Info", "Warn", "Error") to its
+// LogLevel.
+func parseLogLevel(name string) (LogLevel, bool) {
+	for _, l := range []LogLevel{DEBUG, INFO, WARN, ERROR, FATAL} {
+		if strings.EqualFold(l.Name, name) {
+			return l, true
+		}
+	}
+	return LogLevel{}, false
+}
+
+// currentLogSettings returns the cached logger settings, reloading them
+// from config.yml when older than logSettingsRefreshInterval. Log calls made
+// while loading (config reads may log) use the previous settings.
+func currentLogSettings() logSettings {
+	cur := cachedLogSettings.Load()
+	if cur != nil && time.Since(cur.loadedAt) < logSettingsRefreshInterval {
+		return *cur
+	}
+	if !logSettingsLoading.CompareAndSwap(false, true) {
+		if cur != nil {
+			return *cur
+		}
+		return logSettings{level: DEBUG, maxBytes: DefaultLogMaxSizeMB << 20, maxFiles: DefaultLogMaxFiles}
+	}
+	defer logSettingsLoading.Store(false)
+	next := &logSettings{
+		level:    GetLogLevel(),
+		maxBytes: int64(getGeneralInt("logMaxSizeMb", DefaultLogMaxSizeMB)) << 20,
+		maxFiles: getGeneralInt("logMaxFiles", DefaultLogMaxFiles),
+		loadedAt: time.Now(),
+	}
+	cachedLogSettings.Store(next)
+	return *next
+}
+
+// refreshLogSettings drops the cached logger settings so the next log call
+// picks up a changed logLevel or rotation setting.
+func refreshLogSettings() {
+	cachedLogSettings.Store(nil)
+}
+
 // ShouldLog returns true if the message should be logged at the given level
 func ShouldLog(level LogLevel) bool {
-	cur := GetLogLevel().Value
-	return level.Value >= cur
+	return level.Value >= currentLogSettings().level.Value
+}
+
+// Default and maximum number of lines returned by GET /api/logs.
+const (
+	defaultLogTailLines = 200
+	maxLogTailLines     = 5000
+)
+
+// tailLogLines returns the last n lines of the current log file whose level
+// is at least minLevel. Lines without a level (e.g. continuation lines of a
+// multi-line message) take the level of the entry they follow.
+func tailLogLines(n int, minLevel LogLevel) ([]string, error) {
+	logMu.Lock()
+	path := logFileBase
+	logMu.Unlock()
+	if path == "" {
+		return nil, fmt.Errorf("no log file configured")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var lines []string
+	current := DEBUG
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if parts := strings.SplitN(line, "|", 4); len(parts) == 4 {
+			if level, ok := parseLogLevel(parts[1]); ok {
+				current = level
+			}
+		}
+		if current.Value < minLevel.Value {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	if lines == nil {
+		lines = []string{}
+	}
+	return lines, scanner.Err()
+}
+
+// logsTailHandler serves GET /api/logs?lines=N&level=Warn: the last lines
+// (default 200, at most 5000) of the current log file, optionally only those
+// at or above level.
+func logsTailHandler(c *gin.Context) {
+	n := defaultLogTailLines
+	if v := c.Query("lines"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			respondError(c, http.StatusBadRequest, "lines must be a positive integer")
+			return
+		}
+		n = min(parsed, maxLogTailLines)
+	}
+	minLevel := DEBUG
+	if v := c.Query("level"); v != "" {
+		level, ok := parseLogLevel(v)
+		if !ok {
+			respondError(c, http.StatusBadRequest, "level must be one of Debug, Info, Warn, Error, Fatal")
+			return
+		}
+		minLevel = level
+	}
+	lines, err := tailLogLines(n, minLevel)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"lines": lines, "level": minLevel.Name})
 }
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestLogRotationLevelAndTail(t *testing.T) {
+	CreateTempConfig(t)
+	dir := t.TempDir()
+	logMu.Lock()
+	oldBase, oldWriter := logFileBase, logWriter
+	logWriter = nil
+	logFileBase = filepath.Join(dir, "trailarr.txt")
+	openLogFile()
+	logMu.Unlock()
+	defer func() {
+		logMu.Lock()
+		if logWriter != nil {
+			logWriter.Close()
+		}
+		logFileBase, logWriter = oldBase, oldWriter
+		logMu.Unlock()
+		refreshLogSettings()
+	}()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["logLevel"] = "Warn"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	// A logLevel change applies on the next line, without a restart.
+	TrailarrLog(INFO, "Test", "hidden info")
+	TrailarrLog(WARN, "Test", "visible warn")
+	TrailarrLog(ERROR, "Test", "visible error\ncontinuation")
+
+	r := NewTestRouter()
+	r.GET("/api/logs", logsTailHandler)
+	w := DoRequest(r, "GET", "/api/logs?level=error", nil)
+	var resp struct {
+		Lines []string `json:"lines"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if len(resp.Lines) != 2 || resp.Lines[1] != "continuation" {
+		t.Fatalf("expected the error entry and its continuation, got %q", resp.Lines)
+	}
+	w = DoRequest(r, "GET", "/api/logs?lines=1", nil)
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if len(resp.Lines) != 1 || resp.Lines[0] != "continuation" {
+		t.Fatalf("expected only the last line, got %q", resp.Lines)
+	}
+	if w := DoRequest(r, "GET", "/api/logs?level=loud", nil); w.Code != 400 {
+		t.Fatalf("expected 400 for an unknown level, got %d", w.Code)
+	}
+
+	general["logMaxSizeMb"] = 1
+	general["logMaxFiles"] = 2
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	line := fmt.Sprintf("%0512d", 0)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				TrailarrLog(WARN, "Test", "%s", line)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := os.Stat(filepath.Join(dir, "trailarr-2.txt")); err != nil {
+		t.Fatalf("expected rotated files: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "trailarr-3.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected only logMaxFiles rotated files kept, stat err=%v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestTrustedProxiesIPv6HostnamesAndValidation(t *testing.T) {
+	CreateTempConfig(t)
+	oldLookup := lookupTrustedProxyHost
+	lookupTrustedProxyHost = func(host string) ([]net.IP, error) {
+		if host == "traefik" {
+			return []net.IP{net.ParseIP("172.18.0.5"), net.ParseIP("fd00::5")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	defer func() { lookupTrustedProxyHost = oldLookup }()
+
+	if got, err := GetTrustedProxies(); err != nil || strings.Join(got, ",") != "127.0.0.1,::1" {
+		t.Fatalf("expected IPv4 and IPv6 loopback by default, got %v err=%v", got, err)
+	}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["trustedProxies"] = []string{"fd00::/8", "10.0.0.0/8", "traefik", "gone", "10.0.0.300", "::1/200"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	got, err := GetTrustedProxies()
+	if want := "fd00::/8,10.0.0.0/8,172.18.0.5,fd00::5"; strings.Join(got, ",") != want {
+		t.Fatalf("GetTrustedProxies = %v, want %s", got, want)
+	}
+	if err == nil || !strings.Contains(err.Error(), `"10.0.0.300"`) || !strings.Contains(err.Error(), `"::1/200"`) {
+		t.Fatalf("expected an error listing the invalid entries, got %v", err)
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/settings/trustedproxies", GetTrustedProxiesHandler)
+	r.POST("/api/settings/trustedproxies", SaveTrustedProxiesHandler)
+	body, _ := json.Marshal(map[string]interface{}{"trustedProxies": []string{"::1", "bad/cidr", "192.168.1.0/33"}})
+	w := DoRequest(r, "POST", "/api/settings/trustedproxies", body)
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "bad/cidr") || !strings.Contains(w.Body.String(), "192.168.1.0/33") {
+		t.Fatalf("expected 400 listing invalid entries, got %d body=%s", w.Code, w.Body.String())
+	}
+	body, _ = json.Marshal(map[string]interface{}{"trustedProxies": []string{"traefik", "gone"}})
+	w = DoRequest(r, "POST", "/api/settings/trustedproxies", body)
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "gone") || strings.Contains(w.Body.String(), "traefik") {
+		t.Fatalf("expected 400 listing only the unresolvable host, got %d body=%s", w.Code, w.Body.String())
+	}
+	body, _ = json.Marshal(map[string]interface{}{"trustedProxies": []string{" 2001:db8::/32 ", "10.1.0.0/16"}})
+	if w := DoRequest(r, "POST", "/api/settings/trustedproxies", body); w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	w = DoRequest(r, "GET", "/api/settings/trustedproxies", nil)
+	var resp struct {
+		TrustedProxies []string `json:"trustedProxies"`
+		Effective      []string `json:"effective"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if strings.Join(resp.TrustedProxies, ",") != "2001:db8::/32,10.1.0.0/16" || len(resp.Effective) != 2 {
+		t.Fatalf("expected the saved list, got %+v", resp)
+	}
+}
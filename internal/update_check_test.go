@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type updateCheckRunner struct {
+	DefaultYtDlpRunner
+	out string
+}
+
+func (u *updateCheckRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	return []byte(u.out), nil
+}
+
+func TestRunUpdateCheckTaskRecordsNotices(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	_ = client.Del(ctx, UpdateNoticesStoreKey)
+	_ = client.Del(ctx, HealthIssuesStoreKey)
+	defer client.Del(ctx, UpdateNoticesStoreKey)
+	defer client.Del(ctx, HealthIssuesStoreKey)
+
+	oldYt, oldFfmpeg := ytDlpRunner, ffmpegRunner
+	ytDlpRunner = &updateCheckRunner{out: "2024.07.01\n"}
+	ffmpegRunner = &updateCheckRunner{out: "ffmpeg version N-116576-g9d15fe77e3-20240801 Copyright (c) 2000-2024\n"}
+	defer func() { ytDlpRunner, ffmpegRunner = oldYt, oldFfmpeg }()
+
+	setLatest := func(repo, tag string, published time.Time) {
+		latestReleaseCacheMu.Lock()
+		latestReleaseCache[repo] = latestRelease{tag: tag, published: published, ts: time.Now()}
+		latestReleaseCacheMu.Unlock()
+	}
+	defer FlushInMemoryCaches()
+	setLatest("yt-dlp/yt-dlp", "2024.08.06", time.Time{})
+	setLatest(btbnFfmpegRepo, "latest", time.Date(2024, 8, 6, 12, 52, 0, 0, time.UTC))
+
+	other, _ := json.Marshal(HealthMsg{Message: "Radarr not configured", Source: "Radarr", Level: "error"})
+	_ = client.RPush(ctx, HealthIssuesStoreKey, other)
+
+	if err := runUpdateCheckTask(); err != nil {
+		t.Fatalf("runUpdateCheckTask: %v", err)
+	}
+	notices := loadUpdateNotices()
+	if len(notices) != 2 || notices[0].Source != "yt-dlp" || notices[1].Source != "ffmpeg" || notices[0].Level != "info" {
+		t.Fatalf("expected yt-dlp and ffmpeg info notices, got %+v", notices)
+	}
+	if vals, _ := client.LRange(ctx, HealthIssuesStoreKey, 0, -1); len(vals) != 3 {
+		t.Fatalf("expected notices appended to existing health issues, got %v", vals)
+	}
+
+	// Once up to date, the previous notices are removed again.
+	setLatest("yt-dlp/yt-dlp", "2024.07.01", time.Time{})
+	setLatest(btbnFfmpegRepo, "latest", time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC))
+	if err := runUpdateCheckTask(); err != nil {
+		t.Fatalf("runUpdateCheckTask: %v", err)
+	}
+	if n := loadUpdateNotices(); len(n) != 0 {
+		t.Fatalf("expected no notices when up to date, got %+v", n)
+	}
+	if vals, _ := client.LRange(ctx, HealthIssuesStoreKey, 0, -1); len(vals) != 1 {
+		t.Fatalf("expected only the unrelated health issue to remain, got %v", vals)
+	}
+
+	ffmpegRunner = &updateCheckRunner{out: "ffmpeg version 6.1.1-3ubuntu5 Copyright (c) 2000-2023\n"}
+	if err := runUpdateCheckTask(); err == nil {
+		t.Fatalf("expected an error for an ffmpeg without a build date")
+	}
+}
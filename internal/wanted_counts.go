@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// WantedCounts is the number of movies and series currently missing extras,
+// as held by the wanted index.
+type WantedCounts struct {
+	Movies int `json:"movies"`
+	Series int `json:"series"`
+}
+
+var wantedCountClientsMu sync.Mutex
+var wantedCountClients = make(map[*websocket.Conn]struct{})
+
+// lastWantedCounts is the last value broadcast, so saves that leave the
+// counts unchanged do not notify clients.
+var lastWantedCounts *WantedCounts
+
+// addWantedCountClient registers a /ws/wanted client and sends it the current counts.
+func addWantedCountClient(conn *websocket.Conn) {
+	data := wantedCountsMessage(getWantedCounts())
+	wantedCountClientsMu.Lock()
+	defer wantedCountClientsMu.Unlock()
+	wantedCountClients[conn] = struct{}{}
+	if data != nil {
+		_ = conn.WriteMessage(websocket.TextMessage, data)
+	}
+}
+
+func removeWantedCountClient(conn *websocket.Conn) {
+	wantedCountClientsMu.Lock()
+	delete(wantedCountClients, conn)
+	wantedCountClientsMu.Unlock()
+}
+
+// getWantedCounts reads the sizes of both wanted indexes.
+func getWantedCounts() WantedCounts {
+	movies, _ := LoadWantedIndex(MoviesStoreKey)
+	series, _ := LoadWantedIndex(SeriesStoreKey)
+	return WantedCounts{Movies: len(movies), Series: len(series)}
+}
+
+func wantedCountsMessage(counts WantedCounts) []byte {
+	data, err := json.Marshal(map[string]interface{}{
+		"type":   "wanted_counts",
+		"movies": counts.Movies,
+		"series": counts.Series,
+	})
+	if err != nil {
+		TrailarrLog(DEBUG, "WebSocket", "Failed to marshal wanted counts: %v", err)
+		return nil
+	}
+	return data
+}
+
+// broadcastWantedCounts sends the wanted counts to every /ws/wanted client
+// when they differ from the last broadcast. Called after the wanted index is
+// saved.
+func broadcastWantedCounts() {
+	counts := getWantedCounts()
+	wantedCountClientsMu.Lock()
+	defer wantedCountClientsMu.Unlock()
+	if lastWantedCounts != nil && *lastWantedCounts == counts {
+		return
+	}
+	lastWantedCounts = &counts
+	data := wantedCountsMessage(counts)
+	if data == nil {
+		return
+	}
+	for conn := range wantedCountClients {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			TrailarrLog(DEBUG, "WebSocket", "Failed to send wanted counts to client: %v", err)
+		}
+	}
+}
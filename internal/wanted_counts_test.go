@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestWantedCountsBroadcastOverWebSocket(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	defer GetStoreClient().Del(ctx, MoviesWantedStoreKey)
+	defer GetStoreClient().Del(ctx, SeriesWantedStoreKey)
+	if err := SaveWantedIndex(MoviesStoreKey, []map[string]interface{}{{"id": 1.0}}); err != nil {
+		t.Fatalf("SaveWantedIndex: %v", err)
+	}
+	if err := SaveWantedIndex(SeriesStoreKey, []map[string]interface{}{}); err != nil {
+		t.Fatalf("SaveWantedIndex: %v", err)
+	}
+
+	r := NewTestRouter()
+	registerTaskWebSocketRoutes(r)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws/wanted", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	read := func() WantedCounts {
+		t.Helper()
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg struct {
+			Type string `json:"type"`
+			WantedCounts
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		if msg.Type != "wanted_counts" {
+			t.Fatalf("unexpected message type %q", msg.Type)
+		}
+		return msg.WantedCounts
+	}
+	if got := read(); got != (WantedCounts{Movies: 1, Series: 0}) {
+		t.Fatalf("unexpected initial counts: %+v", got)
+	}
+
+	// Saving the same counts again is not broadcast; the next change is.
+	_ = SaveWantedIndex(MoviesStoreKey, []map[string]interface{}{{"id": 2.0}})
+	_ = SaveWantedIndex(SeriesStoreKey, []map[string]interface{}{{"id": 3.0}, {"id": 4.0}})
+	if got := read(); got != (WantedCounts{Movies: 1, Series: 2}) {
+		t.Fatalf("expected the series change to be broadcast, got %+v", got)
+	}
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOnlyMonitoredExcludesUnmonitoredMedia(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	client := GetStoreClient()
+	defer client.Del(ctx, MoviesStoreKey)
+	defer client.Del(ctx, MoviesWantedStoreKey)
+
+	items := []map[string]interface{}{
+		{"id": 1.0, "title": "Monitored", "monitored": true},
+		{"id": 2.0, "title": "Unmonitored", "monitored": false},
+		{"id": 3.0, "title": "Legacy"},
+	}
+	if err := SaveMediaToStore(MoviesStoreKey, items); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	wantedIDs := func() map[int]bool {
+		if err := updateWantedStatusInStore(MoviesStoreKey); err != nil {
+			t.Fatalf("updateWantedStatusInStore: %v", err)
+		}
+		wanted, err := LoadWantedIndex(MoviesStoreKey)
+		if err != nil {
+			t.Fatalf("LoadWantedIndex: %v", err)
+		}
+		ids := map[int]bool{}
+		for _, w := range wanted {
+			if id, ok := parseMediaID(w["id"]); ok {
+				ids[id] = true
+			}
+		}
+		return ids
+	}
+
+	if ids := wantedIDs(); len(ids) != 3 {
+		t.Fatalf("expected all media wanted by default, got %v", ids)
+	}
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["onlyMonitored"] = true
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if ids := wantedIDs(); len(ids) != 2 || ids[2] {
+		t.Fatalf("expected unmonitored media excluded, got %v", ids)
+	}
+
+	light := buildLightItem(items[1])
+	if include, _ := shouldIncludeWantedItem(light, true, true, MediaTypeMovie, []string{"Trailers"}, MoviesStoreKey); include {
+		t.Fatalf("expected unmonitored wanted-index entry to be skipped")
+	}
+	if include, _ := shouldIncludeWantedItem(light, true, false, MediaTypeMovie, []string{"Trailers"}, MoviesStoreKey); !include {
+		t.Fatalf("expected entry included with onlyMonitored off")
+	}
+}
@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Radarr/Sonarr webhook event types handled by WebhookHandler. The "Test"
+// event is sent when the connection is saved in Radarr/Sonarr.
+const (
+	webhookEventTest         = "Test"
+	webhookEventMovieDelete  = "MovieDelete"
+	webhookEventSeriesDelete = "SeriesDelete"
+)
+
+// webhookPayload holds the fields of a Radarr/Sonarr webhook used here.
+type webhookPayload struct {
+	EventType string `json:"eventType"`
+	Movie     *struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	} `json:"movie"`
+	Series *struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	} `json:"series"`
+}
+
+// WebhookHandler handles POST /api/webhook/<provider>, the Radarr/Sonarr
+// webhook connection. A MovieDelete/SeriesDelete event removes the media from
+// the store, its stored extras and its wanted-index entry right away instead
+// of waiting for the next sync. Other events are acknowledged and ignored.
+func WebhookHandler(provider string) gin.HandlerFunc {
+	mediaType, cacheFile, deleteEvent := MediaTypeMovie, MoviesStoreKey, webhookEventMovieDelete
+	if provider == "sonarr" {
+		mediaType, cacheFile, deleteEvent = MediaTypeTV, SeriesStoreKey, webhookEventSeriesDelete
+	}
+	return func(c *gin.Context) {
+		var payload webhookPayload
+		if err := c.BindJSON(&payload); err != nil {
+			respondError(c, http.StatusBadRequest, ErrInvalidRequest)
+			return
+		}
+		switch payload.EventType {
+		case webhookEventTest:
+			respondJSON(c, http.StatusOK, gin.H{"status": "ok"})
+			return
+		case deleteEvent:
+		default:
+			TrailarrLog(DEBUG, "Webhook", "Ignoring %s event %q", provider, payload.EventType)
+			respondJSON(c, http.StatusOK, gin.H{"status": "ignored"})
+			return
+		}
+		id := 0
+		if payload.Movie != nil && mediaType == MediaTypeMovie {
+			id = payload.Movie.ID
+		} else if payload.Series != nil && mediaType == MediaTypeTV {
+			id = payload.Series.ID
+		}
+		if id <= 0 {
+			respondError(c, http.StatusBadRequest, "missing "+string(mediaType)+" id")
+			return
+		}
+		removed, err := removeDeletedMedia(c.Request.Context(), mediaType, cacheFile, id)
+		if err != nil {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		TrailarrLog(INFO, "Webhook", "%s deleted %s id=%d: removed it and %d extras", provider, mediaType, id, removed)
+		respondJSON(c, http.StatusOK, gin.H{"status": "deleted", "id": id, "extrasRemoved": removed})
+	}
+}
+
+// removeDeletedMedia drops a media item deleted in Radarr/Sonarr from
+// cacheFile, removes its stored extras and rebuilds the wanted index. It
+// returns the number of extras removed.
+func removeDeletedMedia(ctx context.Context, mediaType MediaType, cacheFile string, mediaId int) (int, error) {
+	if err := removeMediaFromStore(cacheFile, mediaId); err != nil {
+		return 0, err
+	}
+	removed, err := removeMediaExtras(ctx, mediaType, mediaId)
+	if err != nil {
+		return 0, err
+	}
+	if err := SaveRejectedIndex(); err != nil {
+		TrailarrLog(WARN, "Webhook", "failed to save rejected index: %v", err)
+	}
+	if err := updateWantedStatusInStore(cacheFile); err != nil {
+		TrailarrLog(WARN, "Webhook", "updateWantedStatusInStore failed for %s: %v", cacheFile, err)
+	}
+	return removed, nil
+}
+
+// removeMediaFromStore drops mediaId from the media store at cacheFile under
+// mediaStoreWriteMu, so a concurrent sync cannot save it back.
+func removeMediaFromStore(cacheFile string, mediaId int) error {
+	mediaStoreWriteMu.Lock()
+	defer mediaStoreWriteMu.Unlock()
+	items, err := LoadMediaFromStore(cacheFile)
+	if err != nil {
+		return err
+	}
+	kept := Filter(items, func(m map[string]interface{}) bool {
+		id, ok := parseMediaID(m["id"])
+		return !ok || id != mediaId
+	})
+	if len(kept) == len(items) {
+		return nil
+	}
+	return SaveMediaToStore(cacheFile, kept)
+}
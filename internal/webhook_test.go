@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookMovieDeleteRemovesMediaAndExtras(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const keptId, deletedId = 9201, 9202
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{
+		{"id": keptId, "title": "Kept"},
+		{"id": deletedId, "title": "Deleted"},
+	}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	defer GetStoreClient().Del(ctx, MoviesStoreKey)
+	for _, e := range []ExtrasEntry{
+		{MediaType: MediaTypeMovie, MediaId: keptId, ExtraType: "Trailers", ExtraTitle: "Kept Trailer", YoutubeId: "wh-kept", Status: "downloaded"},
+		{MediaType: MediaTypeMovie, MediaId: deletedId, ExtraType: "Trailers", ExtraTitle: "Deleted Trailer", YoutubeId: "wh-deleted", Status: "downloaded"},
+	} {
+		if err := AddOrUpdateExtra(ctx, e); err != nil {
+			t.Fatalf("AddOrUpdateExtra: %v", err)
+		}
+		defer RemoveExtra(ctx, e.YoutubeId, MediaTypeMovie, e.MediaId)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/webhook/radarr", WebhookHandler("radarr"))
+	r.POST("/api/webhook/sonarr", WebhookHandler("sonarr"))
+	if w := DoRequest(r, "POST", "/api/webhook/radarr", []byte(`{"eventType":"Test"}`)); w.Code != 200 {
+		t.Fatalf("expected 200 for the test event, got %d", w.Code)
+	}
+	// A Sonarr delete does not touch movies.
+	if w := DoRequest(r, "POST", "/api/webhook/sonarr", []byte(`{"eventType":"MovieDelete","movie":{"id":9202}}`)); w.Code != 200 || !strings.Contains(w.Body.String(), "ignored") {
+		t.Fatalf("expected the event ignored, got %d body=%s", w.Code, w.Body.String())
+	}
+	w := DoRequest(r, "POST", "/api/webhook/radarr", []byte(`{"eventType":"MovieDelete","movie":{"id":9202,"title":"Deleted"},"deletedFiles":true}`))
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	items, _ := LoadMediaFromStore(MoviesStoreKey)
+	if len(items) != 1 {
+		t.Fatalf("expected only the kept movie in the store, got %+v", items)
+	}
+	if id, _ := parseMediaID(items[0]["id"]); id != keptId {
+		t.Fatalf("expected the kept movie in the store, got %+v", items)
+	}
+	if extras, _ := GetExtrasForMedia(ctx, MediaTypeMovie, deletedId); len(extras) != 0 {
+		t.Fatalf("expected extras of the deleted movie removed, got %+v", extras)
+	}
+	if extras, _ := GetExtrasForMedia(ctx, MediaTypeMovie, keptId); len(extras) != 1 {
+		t.Fatalf("expected extras of the kept movie untouched, got %+v", extras)
+	}
+	wanted, _ := LoadWantedIndex(MoviesStoreKey)
+	for _, it := range wanted {
+		if id, _ := parseMediaID(it["id"]); id == deletedId {
+			t.Fatalf("expected the deleted movie dropped from the wanted index, got %+v", wanted)
+		}
+	}
+	if w := DoRequest(r, "POST", "/api/webhook/radarr", []byte(`{"eventType":"MovieDelete"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 without a movie id, got %d", w.Code)
+	}
+}
+
+func TestWebhookDeleteDuringSyncIsNotSavedBack(t *testing.T) {
+	CreateTempConfig(t)
+	ctx := context.Background()
+	const deletedId = 9203
+	fetching, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/movie" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// The provider answers with the movie as it was before the delete.
+		once.Do(func() { close(fetching) })
+		<-release
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{{"id": deletedId, "title": "Deleted", "hasFile": true}})
+	}))
+	defer ts.Close()
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["radarr"] = map[string]interface{}{"url": ts.URL, "apiKey": "x"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := SaveMediaToStore(MoviesStoreKey, []map[string]interface{}{{"id": deletedId, "title": "Deleted"}}); err != nil {
+		t.Fatalf("SaveMediaToStore: %v", err)
+	}
+	defer GetStoreClient().Del(ctx, MoviesStoreKey)
+
+	synced := make(chan error, 1)
+	go func() { synced <- SyncMediaType(MediaTypeMovie) }()
+	<-fetching
+	r := NewTestRouter()
+	r.POST("/api/webhook/radarr", WebhookHandler("radarr"))
+	deleted := make(chan int, 1)
+	go func() {
+		deleted <- DoRequest(r, "POST", "/api/webhook/radarr", []byte(`{"eventType":"MovieDelete","movie":{"id":9203}}`)).Code
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	if err := <-synced; err != nil {
+		t.Fatalf("SyncMediaType: %v", err)
+	}
+	if code := <-deleted; code != 200 {
+		t.Fatalf("expected 200 for the delete, got %d", code)
+	}
+	items, _ := LoadMediaFromStore(MoviesStoreKey)
+	for _, it := range items {
+		if id, _ := parseMediaID(it["id"]); id == deletedId {
+			t.Fatalf("expected the deleted movie to stay removed after the sync, got %+v", items)
+		}
+	}
+}
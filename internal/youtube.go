@@ -5,15 +5,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -45,6 +51,14 @@ func init() {
 	UpdateYtDlpPath()
 }
 
+// errMediaHasNoPath is returned for media the Arr reports without a path,
+// typically a movie or series that has not been imported yet.
+var errMediaHasNoPath = errors.New("media has no path yet")
+
+// StatusNoPath is the queue/download status for extras skipped because their
+// media has no path yet.
+const StatusNoPath = "no_path"
+
 var (
 	// Test hooks: when true, yt-dlp calls are mocked/simulated for faster tests.
 	YtDlpTestMode = false
@@ -56,14 +70,25 @@ var (
 	// Additional configurable timings used across the package. Tests can shorten these.
 	DownloadQueueWatcherInterval    = 1 * time.Second
 	TooManyRequestsPauseDuration    = 5 * time.Minute
+	TooManyRequestsMaxPause         = 60 * time.Minute
 	TooManyRequestsPauseLogInterval = 30 * time.Second
 	TasksDepsWaitInterval           = 5 * time.Second
+	DownloadQueueCompactInterval    = 1 * time.Minute
+	ShutdownDrainTimeout            = 30 * time.Second
 
 	// runtime state (moved here for tidy grouping)
 	downloadStatusMap = make(map[string]*DownloadStatus) // keyed by YouTubeID
 	queueMutex        sync.Mutex
 )
 
+// setDownloadStatus records the in-memory status of youtubeID. It takes
+// queueMutex, which resolveDownloadStatuses holds while reading the map.
+func setDownloadStatus(youtubeID string, st *DownloadStatus) {
+	queueMutex.Lock()
+	downloadStatusMap[youtubeID] = st
+	queueMutex.Unlock()
+}
+
 // YouTube trailer search SSE handler (progressive results)
 func YouTubeTrailerSearchStreamHandler(c *gin.Context) {
 	c.Writer.Header().Set(HeaderContentType, "text/event-stream")
@@ -135,7 +160,7 @@ func streamYtDlpSearchForTerm(term string, remaining int, videoIdSet map[string]
 		return 0, nil
 	}
 	searchQuery := term + " trailer"
-	ytDlpArgs := []string{"-j", ytDlpSearchPrefix + searchQuery, ytDlpSkipDownload}
+	ytDlpArgs := ytDlpSearchArgs(searchQuery)
 	TrailarrLog(INFO, "YouTube", "yt-dlp command (SSE): yt-dlp %v", ytDlpArgs)
 
 	if YtDlpTestMode {
@@ -155,9 +180,14 @@ func streamYtDlpSearchForTerm(term string, remaining int, videoIdSet map[string]
 		return added, nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
+	// Tie yt-dlp to the client connection so a closed stream kills the process.
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
 	defer cancel()
 
+	if err := ytDlpProcessLimiter.acquireCtx(ctx, GetMaxYtdlpProcesses()); err != nil {
+		return 0, fmt.Errorf("waiting for a yt-dlp slot: %w", err)
+	}
+	defer ytDlpProcessLimiter.release()
 	reader, cmd, err := startYtDlpCommand(ctx, YtDlpPath, ytDlpArgs)
 	if err != nil {
 		return 0, err
@@ -233,12 +263,13 @@ func readProcessLine(reader *bufio.Reader, added *int, remaining int, videoIdSet
 // and returns (increment, true) on success or (0, false) if nothing was emitted.
 func handleYtDlpJSONLine(line []byte, videoIdSet map[string]bool, c *gin.Context) (int, bool) {
 	var item struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Thumbnail   string `json:"thumbnail"`
-		Channel     string `json:"channel"`
-		ChannelID   string `json:"channel_id"`
+		ID          string  `json:"id"`
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		Thumbnail   string  `json:"thumbnail"`
+		Channel     string  `json:"channel"`
+		ChannelID   string  `json:"channel_id"`
+		Duration    float64 `json:"duration"`
 	}
 	if err := json.Unmarshal(bytes.TrimSpace(line), &item); err != nil {
 		// ignore unparsable lines silently for streaming
@@ -250,6 +281,19 @@ func handleYtDlpJSONLine(line []byte, videoIdSet map[string]bool, c *gin.Context
 	if item.ID == "" || videoIdSet[item.ID] {
 		return 0, false
 	}
+	if !trailerDurationAllowed(item.Duration) {
+		TrailarrLog(DEBUG, "YouTube", "[SSE] Skipping %s: duration %.0fs outside configured range", item.ID, item.Duration)
+		return 0, false
+	}
+	if !channelAllowed(item.ChannelID) {
+		TrailarrLog(DEBUG, "YouTube", "[SSE] Skipping %s: channel %s filtered", item.ID, item.ChannelID)
+		return 0, false
+	}
+	official, keep := officialChannelResult(item.Channel)
+	if !keep {
+		TrailarrLog(DEBUG, "YouTube", "[SSE] Skipping %s: channel %q is not an official channel", item.ID, item.Channel)
+		return 0, false
+	}
 
 	videoIdSet[item.ID] = true
 	result := gin.H{
@@ -263,6 +307,8 @@ func handleYtDlpJSONLine(line []byte, videoIdSet map[string]bool, c *gin.Context
 			"channelTitle": item.Channel,
 			"channelId":    item.ChannelID,
 		},
+		"duration": item.Duration,
+		"official": official,
 	}
 	b, _ := json.Marshal(result)
 	fmt.Fprintf(c.Writer, "data: %s\n\n", b)
@@ -277,6 +323,7 @@ type YtdlpFlagsConfig struct {
 	WriteAutoSubs    bool    `yaml:"writeautosubs" json:"writeautosubs"`
 	EmbedSubs        bool    `yaml:"embedsubs" json:"embedsubs"`
 	SubLangs         string  `yaml:"sublangs" json:"sublangs"`
+	SubFormat        string  `yaml:"subFormat" json:"subFormat"`
 	RequestedFormats string  `yaml:"requestedformats" json:"requestedformats"`
 	Timeout          float64 `yaml:"timeout" json:"timeout"`
 	SleepInterval    float64 `yaml:"sleepInterval" json:"sleepInterval"`
@@ -285,6 +332,27 @@ type YtdlpFlagsConfig struct {
 	SleepRequests    float64 `yaml:"sleepRequests" json:"sleepRequests"`
 	MaxSleepInterval float64 `yaml:"maxSleepInterval" json:"maxSleepInterval"`
 	FfmpegLocation   string  `yaml:"ffmpegLocation" json:"ffmpegLocation"`
+	// ImpersonateTarget is passed to --impersonate; empty disables impersonation.
+	ImpersonateTarget string `yaml:"impersonateTarget" json:"impersonateTarget"`
+	// PostProcessing is "remux" (default) or "reencode"; see PostProcessRemux.
+	PostProcessing string `yaml:"postProcessing" json:"postProcessing"`
+	// Hwaccel is an ffmpeg -hwaccel method used when re-encoding; empty
+	// disables hardware acceleration.
+	Hwaccel string `yaml:"hwaccel" json:"hwaccel"`
+	// RateSchedule overrides LimitRate during the listed time windows.
+	RateSchedule []RateWindow `yaml:"rateSchedule" json:"rateSchedule"`
+	// ExtraArgs are extra yt-dlp options appended after the built-in flags;
+	// see ValidateExtraArgs for what is accepted.
+	ExtraArgs []string `yaml:"extraArgs" json:"extraArgs"`
+}
+
+// RateWindow applies Rate between Start and End ("HH:MM", container local
+// time). A window whose End is before its Start wraps past midnight. An empty
+// Rate downloads at full speed.
+type RateWindow struct {
+	Start string `yaml:"start" json:"start"`
+	End   string `yaml:"end" json:"end"`
+	Rate  string `yaml:"rate" json:"rate"`
 }
 
 // YtdlpFlagsConfig holds configuration flags for yt-dlp command-line invocations.
@@ -301,13 +369,30 @@ type DownloadQueueItem struct {
 	QueuedAt   time.Time `json:"queuedAt"`
 	Status     string    `json:"status"` // "queued", "downloading", etc.
 	Reason     string    `json:"reason,omitempty"`
+	// SeasonNumber is set for season-level TV extras; 0 means the whole series.
+	SeasonNumber int `json:"seasonNumber,omitempty"`
+	// Force re-downloads the extra, overwriting an existing file.
+	Force bool `json:"force,omitempty"`
+	// FinishedAt is when the item reached its final status.
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
 }
 
 // DownloadStatus holds the status of a download
 type DownloadStatus struct {
-	Status    string // e.g. "queued", "downloading", "downloaded", "failed", "exists", "rejected"
+	Status    string // e.g. "queued", "paused", "downloading", "downloaded", "failed", "exists", "rejected"
 	UpdatedAt time.Time
 	Error     string
+	// QueuePosition is the 1-based place of a queued item in line; 1 is
+	// the item NextQueuedItem returns next.
+	QueuePosition int `json:",omitempty"`
+	// ETASeconds estimates when a queued item finishes from the average of
+	// recent download durations; 0 when no downloads have finished yet.
+	ETASeconds int `json:",omitempty"`
+	// Media context, set only when BatchStatusRequest.IncludeMedia is true.
+	MediaType  MediaType `json:",omitempty"`
+	MediaId    int       `json:",omitempty"`
+	ExtraType  string    `json:",omitempty"`
+	ExtraTitle string    `json:",omitempty"`
 }
 
 // runtime state (declared above in the package var block)
@@ -315,6 +400,13 @@ type DownloadStatus struct {
 // BatchStatusRequest is the request body for batch status queries
 type BatchStatusRequest struct {
 	YoutubeIds []string `json:"youtubeIds"`
+	// IncludeMedia adds the media and extra each YouTube ID belongs to.
+	IncludeMedia bool `json:"includeMedia"`
+	// MediaType and MediaId name the media whose stored extras fill in the
+	// context of IDs that are not queued. Without them only queued IDs get
+	// media context.
+	MediaType MediaType `json:"mediaType"`
+	MediaId   int       `json:"mediaId"`
 }
 
 // BatchStatusResponse is the response body for batch status queries
@@ -332,9 +424,31 @@ func GetBatchDownloadStatusHandler(c *gin.Context) {
 	}
 	TrailarrLog(INFO, "BATCH", "/api/extras/status/batch request: %+v", req)
 
-	statuses := make(map[string]*DownloadStatus, len(req.YoutubeIds))
-
 	ctx := context.Background()
+	statuses, queue := resolveDownloadStatuses(ctx, req.YoutubeIds)
+	if req.IncludeMedia {
+		addMediaContext(ctx, statuses, queue, req.MediaType, req.MediaId)
+	}
+
+	// Log actual status values, not just pointers
+	statusLog := make(map[string]DownloadStatus)
+	for k, v := range statuses {
+		if v != nil {
+			statusLog[k] = *v
+		}
+	}
+	TrailarrLog(INFO, "BATCH", "/api/extras/status/batch response: %+v", statusLog)
+	c.JSON(http.StatusOK, BatchStatusResponse{Statuses: statuses})
+}
+
+// resolveDownloadStatuses returns the status of each YouTube ID, taken from
+// the first source that knows it: the in-memory status map, the persisted
+// download queue, the rejected extras, then the media caches ("exists").
+// Unknown IDs are "missing". Only the first source is lost on restart, so
+// statuses survive restarts. Queued entries get their queue position; the
+// loaded queue is returned for callers that need more of it.
+func resolveDownloadStatuses(ctx context.Context, ids []string) (map[string]*DownloadStatus, []DownloadQueueItem) {
+	statuses := make(map[string]*DownloadStatus, len(ids))
 	queue := loadQueueFromStore(ctx)
 	rejectedMap := buildRejectedMap(ctx)
 	movieCache, _ := LoadMediaFromStore(MoviesStoreKey)
@@ -342,7 +456,7 @@ func GetBatchDownloadStatusHandler(c *gin.Context) {
 	existsInCache := makeExistsInCacheFunc(movieCache, seriesCache)
 
 	queueMutex.Lock()
-	for _, id := range req.YoutubeIds {
+	for _, id := range ids {
 		// 1. In-memory status
 		if st, ok := downloadStatusMap[id]; ok {
 			statuses[id] = st
@@ -367,16 +481,25 @@ func GetBatchDownloadStatusHandler(c *gin.Context) {
 		statuses[id] = &DownloadStatus{Status: "missing"}
 	}
 	queueMutex.Unlock()
+	addQueuePositions(statuses, queue)
+	if IsDownloadQueuePaused(ctx) {
+		markQueuedPaused(statuses)
+	}
+	return statuses, queue
+}
 
-	// Log actual status values, not just pointers
-	statusLog := make(map[string]DownloadStatus)
-	for k, v := range statuses {
-		if v != nil {
-			statusLog[k] = *v
+// markQueuedPaused reports queued entries of statuses as "paused", keeping
+// their queue position. Entries are copied so downloadStatusMap is left
+// untouched.
+func markQueuedPaused(statuses map[string]*DownloadStatus) {
+	for id, st := range statuses {
+		if st == nil || st.Status != "queued" {
+			continue
 		}
+		cp := *st
+		cp.Status = "paused"
+		statuses[id] = &cp
 	}
-	TrailarrLog(INFO, "BATCH", "/api/extras/status/batch response: %+v", statusLog)
-	c.JSON(http.StatusOK, BatchStatusResponse{Statuses: statuses})
 }
 
 // loadQueueFromStore returns the persisted queue entries from the store as DownloadQueueItem slice.
@@ -429,6 +552,103 @@ func findLastQueueStatus(queue []DownloadQueueItem, youtubeId string) *DownloadS
 	return nil
 }
 
+// addQueuePositions sets QueuePosition and ETASeconds on the queued entries
+// of statuses. Entries are copied so downloadStatusMap is left untouched.
+func addQueuePositions(statuses map[string]*DownloadStatus, queue []DownloadQueueItem) {
+	positions := queuePositions(queue)
+	avg := averageDownloadDuration()
+	for id, st := range statuses {
+		pos, ok := positions[id]
+		if st == nil || st.Status != "queued" || !ok {
+			continue
+		}
+		cp := *st
+		cp.QueuePosition = pos
+		if avg > 0 {
+			cp.ETASeconds = int((avg * time.Duration(pos)).Seconds())
+		}
+		statuses[id] = &cp
+	}
+}
+
+// addMediaContext fills the media fields of statuses from the latest queue
+// item of each YouTube ID, or from the stored extras of mediaType/mediaId when
+// it is not queued. Entries are copied so downloadStatusMap is left untouched.
+func addMediaContext(ctx context.Context, statuses map[string]*DownloadStatus, queue []DownloadQueueItem, mediaType MediaType, mediaId int) {
+	byId := map[string]DownloadQueueItem{}
+	for _, item := range queue {
+		byId[item.YouTubeID] = item
+	}
+	if mediaType != "" && mediaId > 0 {
+		extras, err := GetExtrasForMedia(ctx, mediaType, mediaId)
+		if err != nil {
+			TrailarrLog(WARN, "BATCH", "Failed to load extras of %s %d for media context: %v", mediaType, mediaId, err)
+		}
+		for _, e := range extras {
+			if _, ok := byId[e.YoutubeId]; !ok {
+				byId[e.YoutubeId] = DownloadQueueItem{MediaType: e.MediaType, MediaId: e.MediaId, ExtraType: e.ExtraType, ExtraTitle: e.ExtraTitle}
+			}
+		}
+	}
+	for id, st := range statuses {
+		item, ok := byId[id]
+		if st == nil || !ok {
+			continue
+		}
+		cp := *st
+		cp.MediaType, cp.MediaId, cp.ExtraType, cp.ExtraTitle = item.MediaType, item.MediaId, item.ExtraType, item.ExtraTitle
+		statuses[id] = &cp
+	}
+}
+
+// queuePositions maps the YouTube ID of each queued item to its 1-based
+// position, following NextQueuedItem's order (first queued entry first).
+func queuePositions(queue []DownloadQueueItem) map[string]int {
+	positions := map[string]int{}
+	n := 0
+	for _, item := range queue {
+		if item.Status != "queued" {
+			continue
+		}
+		n++
+		if _, seen := positions[item.YouTubeID]; !seen {
+			positions[item.YouTubeID] = n
+		}
+	}
+	return positions
+}
+
+// recentDownloadDurations holds how long the last successful downloads took,
+// newest last, for queue ETA estimates.
+var (
+	recentDownloadDurationsMu sync.Mutex
+	recentDownloadDurations   []time.Duration
+)
+
+const recentDownloadDurationsMax = 20
+
+func recordDownloadDuration(d time.Duration) {
+	recentDownloadDurationsMu.Lock()
+	defer recentDownloadDurationsMu.Unlock()
+	recentDownloadDurations = append(recentDownloadDurations, d)
+	if len(recentDownloadDurations) > recentDownloadDurationsMax {
+		recentDownloadDurations = recentDownloadDurations[len(recentDownloadDurations)-recentDownloadDurationsMax:]
+	}
+}
+
+func averageDownloadDuration() time.Duration {
+	recentDownloadDurationsMu.Lock()
+	defer recentDownloadDurationsMu.Unlock()
+	if len(recentDownloadDurations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range recentDownloadDurations {
+		total += d
+	}
+	return total / time.Duration(len(recentDownloadDurations))
+}
+
 // makeExistsInCacheFunc returns a function that checks the provided movie/series caches for a youtubeId.
 func makeExistsInCacheFunc(movieCache, seriesCache []map[string]interface{}) func(string) bool {
 	return func(yid string) bool {
@@ -448,7 +668,9 @@ func makeExistsInCacheFunc(movieCache, seriesCache []map[string]interface{}) fun
 
 // AddToDownloadQueue adds a new download request to the queue and persists in the store
 // source: "task" (block if queue not empty), "api" (always append)
-func AddToDownloadQueue(item DownloadQueueItem, source string) {
+// It returns false without enqueuing when the same extra (YouTubeID and
+// MediaId) is already queued or downloading.
+func AddToDownloadQueue(item DownloadQueueItem, source string) bool {
 	TrailarrLog(INFO, "QUEUE", "[AddToDownloadQueue] Entered. YouTubeID=%s, source=%s", item.YouTubeID, source)
 	ctx := context.Background()
 	client := GetStoreClient()
@@ -465,10 +687,20 @@ func AddToDownloadQueue(item DownloadQueueItem, source string) {
 	TrailarrLog(INFO, "QUEUE", "[AddToDownloadQueue] Marshaled JSON: %s", string(b))
 	if err != nil {
 		TrailarrLog(ERROR, "QUEUE", "[AddToDownloadQueue] Failed to marshal item: %v", err)
-		return
+		return false
+	}
+	// The duplicate check and the push happen under queueMutex so two
+	// concurrent requests for the same extra cannot both enqueue it.
+	queueMutex.Lock()
+	if isPendingInQueue(loadQueueFromStore(ctx), item.YouTubeID, item.MediaId) {
+		queueMutex.Unlock()
+		TrailarrLog(INFO, "QUEUE", "[AddToDownloadQueue] Already queued: mediaType=%v, mediaId=%v, youtubeId=%s, source=%s", item.MediaType, item.MediaId, item.YouTubeID, source)
+		return false
 	}
 	err = client.RPush(ctx, DownloadQueue, b)
 	TrailarrLog(INFO, "QUEUE", "[AddToDownloadQueue] RPush error: %v", err)
+	downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: "queued", UpdatedAt: time.Now()}
+	queueMutex.Unlock()
 	if err != nil {
 		TrailarrLog(ERROR, "QUEUE", "[AddToDownloadQueue] Failed to push to store: %v", err)
 	} else {
@@ -476,8 +708,19 @@ func AddToDownloadQueue(item DownloadQueueItem, source string) {
 		// Broadcast updated queue to all WebSocket clients
 		BroadcastDownloadQueueChanges([]DownloadQueueItem{item})
 	}
-	downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: "queued", UpdatedAt: time.Now()}
 	TrailarrLog(INFO, "QUEUE", "[AddToDownloadQueue] Enqueued: mediaType=%v, mediaId=%v, extraType=%s, extraTitle=%s, youtubeId=%s, source=%s", item.MediaType, item.MediaId, item.ExtraType, item.ExtraTitle, item.YouTubeID, source)
+	return err == nil
+}
+
+// isPendingInQueue reports whether the queue holds a queued or downloading
+// entry for the given YouTubeID and MediaId.
+func isPendingInQueue(queue []DownloadQueueItem, youtubeID string, mediaId int) bool {
+	for _, q := range queue {
+		if q.YouTubeID == youtubeID && q.MediaId == mediaId && (q.Status == "queued" || q.Status == "downloading") {
+			return true
+		}
+	}
+	return false
 }
 
 // fillMediaTitleIfMissing attempts to populate MediaTitle on the queue item using the cache.
@@ -501,19 +744,20 @@ func fillMediaTitleIfMissing(item *DownloadQueueItem) {
 	}
 }
 
-// GetDownloadStatus returns the status for a YouTube ID
+// GetDownloadStatus returns the status for a YouTube ID, resolved like the
+// batch status endpoint. It is never nil; unknown IDs are "missing".
 func GetDownloadStatus(youtubeID string) *DownloadStatus {
-	queueMutex.Lock()
-	defer queueMutex.Unlock()
-	if status, ok := downloadStatusMap[youtubeID]; ok {
-		return status
-	}
-	return nil
+	statuses, _ := resolveDownloadStatuses(context.Background(), []string{youtubeID})
+	return statuses[youtubeID]
 }
 
-// NextQueuedItem fetches the next queued item from the store and its index
+// NextQueuedItem fetches the next queued item from the store and its index.
+// Nothing is returned while downloads are paused.
 func NextQueuedItem() (int, DownloadQueueItem, bool) {
 	ctx := context.Background()
+	if IsDownloadQueuePaused(ctx) {
+		return -1, DownloadQueueItem{}, false
+	}
 	client := GetStoreClient()
 	queue, err := client.LRange(ctx, DownloadQueue, 0, -1)
 	if err != nil {
@@ -530,24 +774,235 @@ func NextQueuedItem() (int, DownloadQueueItem, bool) {
 	return -1, DownloadQueueItem{}, false
 }
 
-// StartDownloadQueueWorker starts a goroutine to process the download queue from the store
-func StartDownloadQueueWorker() {
+// IsDownloadQueuePaused reports whether downloads are paused. The flag is
+// stored, so a pause survives restarts.
+func IsDownloadQueuePaused(ctx context.Context) bool {
+	v, err := GetStoreClient().Get(ctx, DownloadQueuePausedKey)
+	return err == nil && v != ""
+}
+
+// SetDownloadQueuePaused pauses or resumes the download queue worker. Items
+// keep being enqueued while paused; an item already downloading finishes.
+func SetDownloadQueuePaused(ctx context.Context, paused bool) error {
+	if !paused {
+		return GetStoreClient().Del(ctx, DownloadQueuePausedKey)
+	}
+	return GetStoreClient().Set(ctx, DownloadQueuePausedKey, []byte(time.Now().Format(time.RFC3339)))
+}
+
+// PauseDownloadsHandler handles POST /api/download/pause.
+func PauseDownloadsHandler(c *gin.Context) {
+	setDownloadsPaused(c, true)
+}
+
+// ResumeDownloadsHandler handles POST /api/download/resume.
+func ResumeDownloadsHandler(c *gin.Context) {
+	setDownloadsPaused(c, false)
+}
+
+func setDownloadsPaused(c *gin.Context, paused bool) {
+	if err := SetDownloadQueuePaused(c.Request.Context(), paused); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	TrailarrLog(INFO, "QUEUE", "Downloads paused=%v", paused)
+	respondJSON(c, http.StatusOK, gin.H{"paused": paused})
+}
+
+// StartDownloadQueueWorker starts a goroutine to process the download queue from the store.
+// The worker stops picking up new items once ctx is cancelled; an item that is
+// already downloading is allowed to finish. The returned channel is closed
+// when the worker has exited.
+func StartDownloadQueueWorker(ctx context.Context) <-chan struct{} {
+	done := make(chan struct{})
 	go func() {
-		ctx := context.Background()
-		client := GetStoreClient()
-		// Clean the queue at startup
-		_ = client.Del(ctx, DownloadQueue)
+		defer close(done)
+		// Items left "downloading" by a crash or an undrained shutdown are
+		// queued again; the rest of the queue is kept across restarts.
+		ResetDownloadingQueueItems()
+		lastCompact := time.Now()
 		for {
+			if ctx.Err() != nil {
+				TrailarrLog(INFO, "QUEUE", "[StartDownloadQueueWorker] Shutdown requested, worker stopped")
+				return
+			}
+			if time.Since(lastCompact) >= DownloadQueueCompactInterval {
+				lastCompact = time.Now()
+				if _, err := CompactDownloadQueue(ctx, GetDownloadQueueRetention()); err != nil {
+					TrailarrLog(WARN, "QUEUE", "[StartDownloadQueueWorker] queue compaction failed: %v", err)
+				}
+				if _, err := ExpireFinishedQueueItems(ctx, GetDownloadQueueRetentionAge()); err != nil {
+					TrailarrLog(WARN, "QUEUE", "[StartDownloadQueueWorker] queue expiry failed: %v", err)
+				}
+			}
 			idx, item, ok := NextQueuedItem()
 			if !ok {
-				time.Sleep(2 * time.Second)
+				select {
+				case <-ctx.Done():
+				case <-time.After(2 * time.Second):
+				}
 				continue
 			}
-			if err := processQueueItem(ctx, idx, item); err != nil {
+			// Detach from cancellation so an in-flight item is finalized in the store.
+			if err := processQueueItem(context.WithoutCancel(ctx), idx, item); err != nil {
 				TrailarrLog(ERROR, "QUEUE", "[StartDownloadQueueWorker] processQueueItem error: %v", err)
 			}
 		}
 	}()
+	return done
+}
+
+// isTerminalQueueStatus reports whether a download queue status is final.
+func isTerminalQueueStatus(status string) bool {
+	switch status {
+	case "downloaded", "failed", "exists", "rejected":
+		return true
+	}
+	return false
+}
+
+// CompactDownloadQueue removes the oldest terminal-status entries from the
+// download queue so that at most retain of them remain. Queued and in-flight
+// entries are never touched. A retain value <= 0 disables compaction. It
+// returns the number of removed entries.
+func CompactDownloadQueue(ctx context.Context, retain int) (int, error) {
+	if retain <= 0 {
+		return 0, nil
+	}
+	client := GetStoreClient()
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	var terminal []string
+	for _, v := range vals {
+		var item DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &item); err != nil {
+			continue
+		}
+		if isTerminalQueueStatus(item.Status) {
+			terminal = append(terminal, v)
+		}
+	}
+	excess := len(terminal) - retain
+	if excess <= 0 {
+		return 0, nil
+	}
+	removed := 0
+	// The queue is appended to, so the oldest entries come first.
+	for _, v := range terminal[:excess] {
+		if err := client.LRem(ctx, DownloadQueue, 1, []byte(v)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	TrailarrLog(INFO, "QUEUE", "[CompactDownloadQueue] Removed %d terminal entries (retention=%d)", removed, retain)
+	return removed, nil
+}
+
+// DrainDownloadQueueWorker waits up to ShutdownDrainTimeout for the worker
+// started by StartDownloadQueueWorker to exit, then resets any item still
+// marked "downloading" so it is picked up again on the next start.
+func DrainDownloadQueueWorker(done <-chan struct{}) {
+	select {
+	case <-done:
+		TrailarrLog(INFO, "QUEUE", "[DrainDownloadQueueWorker] Download worker drained")
+	case <-time.After(ShutdownDrainTimeout):
+		TrailarrLog(WARN, "QUEUE", "[DrainDownloadQueueWorker] Download worker did not finish within %v", ShutdownDrainTimeout)
+	}
+	ResetDownloadingQueueItems()
+}
+
+// inFlightDownloads holds the YouTube IDs currently being processed by the
+// download worker.
+var (
+	inFlightDownloadsMu sync.Mutex
+	inFlightDownloads   = map[string]bool{}
+)
+
+func setDownloadInFlight(youtubeId string, on bool) {
+	inFlightDownloadsMu.Lock()
+	defer inFlightDownloadsMu.Unlock()
+	if on {
+		inFlightDownloads[youtubeId] = true
+	} else {
+		delete(inFlightDownloads, youtubeId)
+	}
+}
+
+func isDownloadInFlight(youtubeId string) bool {
+	inFlightDownloadsMu.Lock()
+	defer inFlightDownloadsMu.Unlock()
+	return inFlightDownloads[youtubeId]
+}
+
+// UnstickDownloadQueue resets "downloading" queue items that no worker is
+// processing back to "queued", broadcasts the change and returns the items.
+func UnstickDownloadQueue(ctx context.Context) ([]DownloadQueueItem, error) {
+	client := GetStoreClient()
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	var requeued []DownloadQueueItem
+	for i, v := range vals {
+		var item DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &item); err != nil || item.Status != "downloading" {
+			continue
+		}
+		if isDownloadInFlight(item.YouTubeID) {
+			continue
+		}
+		item.Status = "queued"
+		b, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		if err := client.LSet(ctx, DownloadQueue, int64(i), b); err != nil {
+			return requeued, err
+		}
+		setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: "queued", UpdatedAt: time.Now()})
+		TrailarrLog(INFO, "QUEUE", "[UnstickDownloadQueue] Re-queued stuck youtubeId=%s", item.YouTubeID)
+		requeued = append(requeued, item)
+	}
+	BroadcastDownloadQueueChanges(requeued)
+	return requeued, nil
+}
+
+// UnstickDownloadQueueHandler handles POST /api/queue/unstick.
+func UnstickDownloadQueueHandler(c *gin.Context) {
+	items, err := UnstickDownloadQueue(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if items == nil {
+		items = []DownloadQueueItem{}
+	}
+	respondJSON(c, http.StatusOK, gin.H{"requeued": len(items), "items": items})
+}
+
+// ResetDownloadingQueueItems sets any queue item left in "downloading" back to
+// "queued", mirroring the startup reset of "running" tasks. It runs on
+// shutdown and again when the worker starts.
+func ResetDownloadingQueueItems() {
+	client := GetStoreClient()
+	ctx := context.Background()
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		return
+	}
+	for i, v := range vals {
+		var item DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &item); err != nil || item.Status != "downloading" {
+			continue
+		}
+		item.Status = "queued"
+		if b, err := json.Marshal(item); err == nil {
+			_ = client.LSet(ctx, DownloadQueue, int64(i), b)
+		}
+		TrailarrLog(INFO, "QUEUE", "[ResetDownloadingQueueItems] Reset youtubeId=%s to queued", item.YouTubeID)
+	}
 }
 
 // processQueueItem handles a single queue item end-to-end and returns an error only for unexpected conditions.
@@ -561,6 +1016,10 @@ func processQueueItem(ctx context.Context, idx int, item DownloadQueueItem) erro
 		return nil
 	}
 
+	// Track the item as in flight so /api/queue/unstick leaves it alone.
+	setDownloadInFlight(item.YouTubeID, true)
+	defer setDownloadInFlight(item.YouTubeID, false)
+
 	// 2) Mark as downloading
 	if err := markItemDownloading(ctx, idx, item); err != nil {
 		// log and continue attempting download even if marking failed
@@ -568,7 +1027,8 @@ func processQueueItem(ctx context.Context, idx int, item DownloadQueueItem) erro
 	}
 
 	// 3) Perform the download
-	meta, metaErr := DownloadYouTubeExtra(item.MediaType, item.MediaId, item.ExtraType, item.ExtraTitle, item.YouTubeID)
+	started := time.Now()
+	meta, metaErr := DownloadYouTubeSeasonExtra(item.MediaType, item.MediaId, item.SeasonNumber, item.ExtraType, item.ExtraTitle, item.YouTubeID, item.Force)
 
 	// 4) If 429, pause the queue (handled inside)
 	if metaErr != nil {
@@ -579,33 +1039,50 @@ func processQueueItem(ctx context.Context, idx int, item DownloadQueueItem) erro
 
 	// 5) Determine final status and update in-memory map
 	var finalStatus, failReason string
-	if metaErr != nil {
+	if errors.Is(metaErr, errMediaHasNoPath) {
+		// Not a failure: the extra stays missing and the next sync retries it.
+		finalStatus = StatusNoPath
+		failReason = metaErr.Error()
+		setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now(), Error: failReason})
+	} else if metaErr != nil {
 		finalStatus = "failed"
 		failReason = metaErr.Error()
-		downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now(), Error: failReason}
+		setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now(), Error: failReason})
 	} else if meta != nil {
 		finalStatus = meta.Status
-		downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now()}
+		setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now()})
+		if finalStatus == "downloaded" {
+			recordDownloadDuration(time.Since(started))
+			resetTooManyRequestsBackoff()
+		}
 	} else {
 		finalStatus = "failed"
 		failReason = "No metadata returned from download"
-		downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now(), Error: failReason}
+		setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: finalStatus, UpdatedAt: time.Now(), Error: failReason})
 	}
 
 	// 6) Update the queue entry in the store and broadcast final status
-	if err := updateFinalStatusInStore(ctx, idx, finalStatus, failReason); err != nil {
+	stored, err := updateFinalStatusInStore(ctx, idx, finalStatus, failReason)
+	if err != nil {
 		// If updating the store failed, still broadcast the status using the item
 		item.Status = finalStatus
-		if finalStatus == "failed" && failReason != "" {
+		if (finalStatus == "failed" || finalStatus == StatusNoPath) && failReason != "" {
 			item.Reason = failReason
 		}
 		BroadcastDownloadQueueChanges([]DownloadQueueItem{item})
 	}
 
-	// 7) Wait briefly then remove from queue (configurable for tests)
-	time.Sleep(QueueItemRemoveDelay)
-	b, _ := json.Marshal(item)
-	_ = client.LRem(ctx, DownloadQueue, 1, b)
+	// 7) Finished items stay in the queue for CompactDownloadQueue to trim
+	// when general.keepFinishedQueueItems is set; otherwise they are removed
+	// after general.queueItemRemoveDelaySeconds.
+	if GetKeepFinishedQueueItems() {
+		return nil
+	}
+	time.Sleep(GetQueueItemRemoveDelay())
+	if stored == nil {
+		stored, _ = json.Marshal(item)
+	}
+	_ = client.LRem(ctx, DownloadQueue, 1, stored)
 
 	return nil
 }
@@ -639,70 +1116,153 @@ func markItemDownloading(ctx context.Context, idx int, item DownloadQueueItem) e
 			q.Status = "downloading"
 			b, _ := json.Marshal(q)
 			_ = GetStoreClient().LSet(ctx, DownloadQueue, int64(idx), b)
-			downloadStatusMap[item.YouTubeID] = &DownloadStatus{Status: "downloading", UpdatedAt: time.Now()}
+			setDownloadStatus(item.YouTubeID, &DownloadStatus{Status: "downloading", UpdatedAt: time.Now()})
 			BroadcastDownloadQueueChanges([]DownloadQueueItem{q})
 		}
 	}
 	return nil
 }
 
+// consecutiveTooManyRequests counts 429s since the last successful download.
+var (
+	tooManyRequestsMu          sync.Mutex
+	consecutiveTooManyRequests int
+)
+
+// nextTooManyRequestsPause records a 429 and returns how long to pause: the
+// base pause multiplied once per earlier consecutive 429, capped at the max.
+func nextTooManyRequestsPause() time.Duration {
+	base, multiplier, maxPause := GetTooManyRequestsBackoff()
+	tooManyRequestsMu.Lock()
+	consecutiveTooManyRequests++
+	n := consecutiveTooManyRequests
+	tooManyRequestsMu.Unlock()
+	pause := float64(base)
+	for i := 1; i < n && pause < float64(maxPause); i++ {
+		pause *= multiplier
+	}
+	if maxPause > 0 && pause > float64(maxPause) {
+		return maxPause
+	}
+	return time.Duration(pause)
+}
+
+// resetTooManyRequestsBackoff is called after a successful download.
+func resetTooManyRequestsBackoff() {
+	tooManyRequestsMu.Lock()
+	consecutiveTooManyRequests = 0
+	tooManyRequestsMu.Unlock()
+}
+
 func handleTooManyRequestsPause(err429 *TooManyRequestsError) {
-	TrailarrLog(WARN, "QUEUE", "[StartDownloadQueueWorker] 429 detected, pausing queue for %v: %s", TooManyRequestsPauseDuration, err429.Error())
-	pauseUntil := time.Now().Add(TooManyRequestsPauseDuration)
+	pause := nextTooManyRequestsPause()
+	TrailarrLog(WARN, "QUEUE", "[StartDownloadQueueWorker] 429 detected, pausing queue for %v: %s", pause, err429.Error())
+	pauseUntil := time.Now().Add(pause)
 	for time.Now().Before(pauseUntil) {
 		TrailarrLog(INFO, "QUEUE", "[StartDownloadQueueWorker] Queue paused for 429. Resuming in %v seconds...", int(time.Until(pauseUntil).Seconds()))
-		time.Sleep(TooManyRequestsPauseLogInterval)
+		time.Sleep(min(TooManyRequestsPauseLogInterval, time.Until(pauseUntil)))
 	}
-	TrailarrLog(INFO, "QUEUE", "[StartDownloadQueueWorker] %v pause for 429 complete. Resuming queue.", TooManyRequestsPauseDuration)
+	TrailarrLog(INFO, "QUEUE", "[StartDownloadQueueWorker] %v pause for 429 complete. Resuming queue.", pause)
 }
 
-func updateFinalStatusInStore(ctx context.Context, idx int, finalStatus, failReason string) error {
+// updateFinalStatusInStore sets the final status of the queue entry at idx and
+// returns the stored entry, or nil when idx is out of range.
+func updateFinalStatusInStore(ctx context.Context, idx int, finalStatus, failReason string) ([]byte, error) {
 	queue, err := GetStoreClient().LRange(ctx, DownloadQueue, 0, -1)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if idx >= 0 && idx < len(queue) {
 		var q DownloadQueueItem
 		if err := json.Unmarshal([]byte(queue[idx]), &q); err == nil {
 			q.Status = finalStatus
-			if finalStatus == "failed" && failReason != "" {
+			q.FinishedAt = time.Now()
+			if (finalStatus == "failed" || finalStatus == StatusNoPath) && failReason != "" {
 				q.Reason = failReason
 			}
 			b, _ := json.Marshal(q)
 			_ = GetStoreClient().LSet(ctx, DownloadQueue, int64(idx), b)
 			BroadcastDownloadQueueChanges([]DownloadQueueItem{q})
+			return b, nil
 		}
 	}
-	return nil
+	return nil, nil
+}
+
+// ExpireFinishedQueueItems removes terminal-status entries of the download
+// queue that finished more than maxAge ago. Entries written before FinishedAt
+// existed use their QueuedAt. A maxAge <= 0 disables expiry. It returns the
+// number of removed entries.
+func ExpireFinishedQueueItems(ctx context.Context, maxAge time.Duration) (int, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+	client := GetStoreClient()
+	vals, err := client.LRange(ctx, DownloadQueue, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+	for _, v := range vals {
+		var item DownloadQueueItem
+		if err := json.Unmarshal([]byte(v), &item); err != nil || !isTerminalQueueStatus(item.Status) {
+			continue
+		}
+		finished := item.FinishedAt
+		if finished.IsZero() {
+			finished = item.QueuedAt
+		}
+		if finished.After(cutoff) {
+			continue
+		}
+		if err := client.LRem(ctx, DownloadQueue, 1, []byte(v)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	if removed > 0 {
+		TrailarrLog(INFO, "QUEUE", "[ExpireFinishedQueueItems] Removed %d entries finished over %v ago", removed, maxAge)
+	}
+	return removed, nil
+}
+
+// GetDownloadQueueHandler handles GET /api/download/queue: the queued,
+// downloading and retained finished items, oldest first.
+func GetDownloadQueueHandler(c *gin.Context) {
+	queue := GetCurrentDownloadQueue()
+	if queue == nil {
+		queue = []DownloadQueueItem{}
+	}
+	respondJSON(c, http.StatusOK, gin.H{"queue": queue, "paused": IsDownloadQueuePaused(c.Request.Context())})
 }
 
 // GetDownloadStatusHandler returns the status of a download by YouTube ID
 func GetDownloadStatusHandler(c *gin.Context) {
 	youtubeId := c.Param("youtubeId")
-	status := GetDownloadStatus(youtubeId)
-	if status == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Not found"})
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{"status": status})
+	c.JSON(http.StatusOK, gin.H{"status": GetDownloadStatus(youtubeId)})
 }
 
 func DefaultYtdlpFlagsConfig() YtdlpFlagsConfig {
 	return YtdlpFlagsConfig{
-		Quiet:            false,
-		NoProgress:       false,
-		WriteSubs:        true,
-		WriteAutoSubs:    true,
-		EmbedSubs:        true,
-		SubLangs:         "es.*",
-		RequestedFormats: "best[height<=1080]",
-		Timeout:          3.0,
-		SleepInterval:    5.0,
-		MaxDownloads:     5,
-		LimitRate:        "30M",
-		SleepRequests:    3.0,
-		MaxSleepInterval: 120.0,
-		FfmpegLocation:   "",
+		Quiet:             false,
+		NoProgress:        false,
+		WriteSubs:         true,
+		WriteAutoSubs:     true,
+		EmbedSubs:         true,
+		SubLangs:          "es.*",
+		SubFormat:         DefaultSubFormat,
+		RequestedFormats:  "best[height<=1080]",
+		Timeout:           3.0,
+		SleepInterval:     5.0,
+		MaxDownloads:      5,
+		LimitRate:         "30M",
+		SleepRequests:     3.0,
+		MaxSleepInterval:  120.0,
+		FfmpegLocation:    "",
+		ImpersonateTarget: "chrome",
+		PostProcessing:    PostProcessRemux,
+		Hwaccel:           "",
 	}
 }
 
@@ -761,10 +1321,16 @@ type RejectedExtra struct {
 // for the given media and returns metadata about the downloaded file. If
 // forceDownload is provided and true, an existing file may be re-downloaded.
 func DownloadYouTubeExtra(mediaType MediaType, mediaId int, extraType, extraTitle, youtubeId string, forceDownload ...bool) (*ExtraDownloadMetadata, error) {
-	TrailarrLog(DEBUG, "YouTube", "DownloadYouTubeExtra called with mediaType=%s, mediaId=%d, extraType=%s, extraTitle=%s, youtubeId=%s, forceDownload=%v",
-		mediaType, mediaId, extraType, extraTitle, youtubeId, forceDownload)
+	return DownloadYouTubeSeasonExtra(mediaType, mediaId, 0, extraType, extraTitle, youtubeId, forceDownload...)
+}
+
+// DownloadYouTubeSeasonExtra is DownloadYouTubeExtra for a season-level TV
+// extra; seasonNumber 0 targets the series root.
+func DownloadYouTubeSeasonExtra(mediaType MediaType, mediaId, seasonNumber int, extraType, extraTitle, youtubeId string, forceDownload ...bool) (*ExtraDownloadMetadata, error) {
+	TrailarrLog(DEBUG, "YouTube", "DownloadYouTubeExtra called with mediaType=%s, mediaId=%d, season=%d, extraType=%s, extraTitle=%s, youtubeId=%s, forceDownload=%v",
+		mediaType, mediaId, seasonNumber, extraType, extraTitle, youtubeId, forceDownload)
 
-	downloadInfo, err := prepareDownloadInfo(mediaType, mediaId, extraType, extraTitle, youtubeId)
+	downloadInfo, err := prepareDownloadInfo(mediaType, mediaId, extraType, extraTitle, youtubeId, seasonNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -781,7 +1347,8 @@ func DownloadYouTubeExtra(mediaType MediaType, mediaId int, extraType, extraTitl
 		downloadInfo.MediaType, downloadInfo.MediaTitle, extraType, extraTitle, youtubeId)
 
 	// Check if extra is rejected or already exists
-	if meta, err := checkExistingExtra(downloadInfo, youtubeId); meta != nil || err != nil {
+	force := len(forceDownload) > 0 && forceDownload[0]
+	if meta, err := checkExistingExtra(downloadInfo, youtubeId, force); meta != nil || err != nil {
 		return meta, err
 	}
 
@@ -793,6 +1360,8 @@ type downloadInfo struct {
 	MediaType  MediaType
 	MediaId    int
 	MediaTitle string
+	// MediaPath is the mapped folder of the movie or series.
+	MediaPath  string
 	OutDir     string
 	OutFile    string
 	TempDir    string
@@ -801,9 +1370,15 @@ type downloadInfo struct {
 	ExtraType  string
 	ExtraTitle string
 	SafeTitle  string
+	// SeasonNumber is set for season-level TV extras; 0 means the whole series.
+	SeasonNumber int
+	// StartedAt is when performDownload started, for the history duration.
+	StartedAt time.Time
 }
 
-func prepareDownloadInfo(mediaType MediaType, mediaId int, extraType, extraTitle, youtubeID string) (*downloadInfo, error) {
+// prepareDownloadInfo resolves the output and temp paths of a download. TV
+// extras with a season number go under the series' "Season XX" folder.
+func prepareDownloadInfo(mediaType MediaType, mediaId int, extraType, extraTitle, youtubeID string, seasonNumber int) (*downloadInfo, error) {
 	// Resolve cache file and media title
 	cacheFile, mediaTitle := resolveCacheAndTitle(mediaType, mediaId)
 
@@ -813,13 +1388,20 @@ func prepareDownloadInfo(mediaType MediaType, mediaId int, extraType, extraTitle
 	// Try to find a mapped media path using cache + mappings
 	mappedMediaPath := findMappedMediaPath(cacheFile, mappings, mediaId)
 
-	// Derive base path using mapped path, mappings fallback, or media title
-	basePath := deriveBasePath(mappedMediaPath, mappings, mediaTitle)
+	// Derive base path from the mapped media path; skip media without one
+	basePath, err := deriveBasePath(mappedMediaPath)
+	if err != nil {
+		TrailarrLog(WARN, "YouTube", "Skipping download: mediaType=%s, mediaId=%d, youtubeId=%s: %v", mediaType, mediaId, youtubeID, err)
+		return nil, fmt.Errorf("%w: mediaType=%s, mediaId=%d", err, mediaType, mediaId)
+	}
 
 	// Build output directory and sanitize title
 	canonicalType := canonicalizeExtraType(extraType)
+	if mediaType == MediaTypeTV && seasonNumber > 0 {
+		basePath = filepath.Join(basePath, fmt.Sprintf("Season %02d", seasonNumber))
+	}
 	outDir := filepath.Join(basePath, canonicalType)
-	safeTitle := sanitizeFileName(extraTitle)
+	safeTitle := extraFileBaseName(extraType, extraTitle, youtubeID)
 
 	// Prepare filenames
 	outExt := "mkv"
@@ -838,17 +1420,19 @@ func prepareDownloadInfo(mediaType MediaType, mediaId int, extraType, extraTitle
 		mediaType, mediaTitle, canonicalType, outDir, outFile, tempDir, tempFile)
 
 	return &downloadInfo{
-		MediaType:  mediaType,
-		MediaId:    mediaId,
-		MediaTitle: mediaTitle,
-		OutDir:     outDir,
-		OutFile:    outFile,
-		TempDir:    tempDir,
-		TempFile:   tempFile,
-		YouTubeID:  youtubeID,
-		ExtraType:  extraType,
-		ExtraTitle: extraTitle,
-		SafeTitle:  safeTitle,
+		MediaType:    mediaType,
+		MediaId:      mediaId,
+		MediaTitle:   mediaTitle,
+		MediaPath:    mappedMediaPath,
+		OutDir:       outDir,
+		OutFile:      outFile,
+		TempDir:      tempDir,
+		TempFile:     tempFile,
+		YouTubeID:    youtubeID,
+		ExtraType:    extraType,
+		ExtraTitle:   extraTitle,
+		SafeTitle:    safeTitle,
+		SeasonNumber: seasonNumber,
 	}, nil
 }
 
@@ -882,58 +1466,48 @@ func getPathMappingsSafe(mediaType MediaType) [][]string {
 
 // Helper: find mapped media path by applying mappings to the media path found in cache
 func findMappedMediaPath(cacheFile string, mappings [][]string, mediaId int) string {
-	if cacheFile == "" || len(mappings) == 0 {
+	if cacheFile == "" {
 		return ""
 	}
 	mediaPath, lookupErr := FindMediaPathByID(cacheFile, mediaId)
 	if lookupErr != nil || mediaPath == "" {
 		return ""
 	}
-	// Apply first mapping that matches prefix
-	for _, m := range mappings {
-		if len(m) > 1 && strings.HasPrefix(mediaPath, m[0]) {
-			return m[1] + mediaPath[len(m[0]):]
-		}
-	}
-	// If no mapping matched, return the raw media path
-	return mediaPath
+	return mapMediaPath(mediaPath, mappings)
 }
 
-// Helper: derive base path from mapped path, fallback to mappings[0][1] + title, or title, or empty
-func deriveBasePath(mappedMediaPath string, mappings [][]string, mediaTitle string) string {
-	if mappedMediaPath != "" {
-		return mappedMediaPath
-	}
-	if len(mappings) > 0 && len(mappings[0]) > 1 && mappings[0][1] != "" {
-		if mediaTitle != "" {
-			return filepath.Join(mappings[0][1], mediaTitle)
+// mapMediaPath applies the most specific matching mapping to a provider
+// path, returning the raw path when none matches.
+func mapMediaPath(mediaPath string, mappings [][]string) string {
+	for _, m := range byPathMappingSpecificity(mappings) {
+		if mapped, ok := applyPathMapping(mediaPath, m); ok {
+			return mapped
 		}
-		return mappings[0][1]
 	}
-	if mediaTitle != "" {
-		return mediaTitle
-	}
-	return ""
+	return mediaPath
 }
 
-// Helper: sanitize a filename/title for use as a file (replace forbidden chars with _)
-func sanitizeFileName(name string) string {
-	forbidden := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
-	safe := name
-	for _, c := range forbidden {
-		safe = strings.ReplaceAll(safe, c, "_")
+// Helper: derive base path from the mapped media path. Media the Arr has not
+// imported yet has no path; it returns errMediaHasNoPath rather than guessing
+// a directory, so the extra is retried once a sync picks up the path.
+func deriveBasePath(mappedMediaPath string) (string, error) {
+	if strings.TrimSpace(mappedMediaPath) == "" {
+		return "", errMediaHasNoPath
 	}
-	return safe
+	return mappedMediaPath, nil
 }
 
 // Helper: create temp dir and return tempDir and tempFile path
 func createTempPaths(safeTitle, ext string) (string, string, error) {
-	// Create temp dirs under the package-level TrailarrRoot so we only create
-	// a single top-level test temp directory during test runs instead of
-	// multiple top-level /tmp entries.
-	// Ensure TrailarrRoot exists (TestMain should have set it for tests).
-	_ = os.MkdirAll(TrailarrRoot, 0755)
-	tempDir, err := os.MkdirTemp(TrailarrRoot, "yt-dlp-tmp-*")
+	// Create temp dirs under general.tempDir (TrailarrRoot by default) so we
+	// only create a single top-level test temp directory during test runs
+	// instead of multiple top-level /tmp entries.
+	// Ensure the base exists (TestMain should have set TrailarrRoot for tests).
+	base := GetTempDir()
+	if err := os.MkdirAll(base, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create tempDir %s: %w", base, err)
+	}
+	tempDir, err := os.MkdirTemp(base, "yt-dlp-tmp-*")
 	if err != nil {
 		return "", "", err
 	}
@@ -943,14 +1517,18 @@ func createTempPaths(safeTitle, ext string) (string, string, error) {
 	return tempDir, tempFile, nil
 }
 
-func checkExistingExtra(info *downloadInfo, youtubeId string) (*ExtraDownloadMetadata, error) {
+// checkExistingExtra short-circuits downloads of rejected extras and, unless
+// force is set, of extras whose file already exists.
+func checkExistingExtra(info *downloadInfo, youtubeId string, force bool) (*ExtraDownloadMetadata, error) {
 	// Check if extra is in rejected_extras.json
 	if meta := checkRejectedExtras(info, youtubeId); meta != nil {
 		return meta, nil
 	}
 
-	// Skip download if file already exists
-	if _, err := os.Stat(info.OutFile); err == nil {
+	// Skip download if file already exists; a forced download overwrites it.
+	if _, err := os.Stat(info.OutFile); err == nil && force {
+		TrailarrLog(INFO, "YouTube", "File already exists, re-downloading (force): %s", info.OutFile)
+	} else if err == nil {
 		TrailarrLog(INFO, "YouTube", "File already exists, skipping: %s", info.OutFile)
 		return NewExtraDownloadMetadata(info, youtubeId, "exists"), nil
 	}
@@ -969,16 +1547,18 @@ func checkRejectedExtras(info *downloadInfo, youtubeId string) *ExtraDownloadMet
 }
 
 func performDownload(info *downloadInfo, youtubeId string) (*ExtraDownloadMetadata, error) {
+	info.StartedAt = time.Now()
 	args := buildYtDlpArgs(info, youtubeId, true)
 	// Execute yt-dlp command via configurable runner
-	output, err := ytDlpRunner.CombinedOutput(YtDlpPath, args, info.TempDir)
+	output, err := runYtDlpDownload(args, info.TempDir)
 
 	if err != nil && isImpersonationErrorNative(string(output)) {
 		TrailarrLog(WARN, "YouTube", "Impersonation failed for %s, retrying without impersonation", youtubeId)
 		args = buildYtDlpArgs(info, youtubeId, false)
-		output, err = ytDlpRunner.CombinedOutput(YtDlpPath, args, info.TempDir)
+		output, err = runYtDlpDownload(args, info.TempDir)
 	}
 	TrailarrLog(DEBUG, "YouTube", "yt-dlp command executed: %s %s", YtDlpPath, strings.Join(args, " "))
+	saveDownloadLog(youtubeId, output, err)
 
 	if len(output) > 0 {
 		for _, line := range strings.Split(string(output), "\n") {
@@ -987,6 +1567,11 @@ func performDownload(info *downloadInfo, youtubeId string) (*ExtraDownloadMetada
 			}
 		}
 	}
+	if err == nil && strings.Contains(string(output), "does not pass filter") {
+		// yt-dlp exits 0 when --match-filter skips the video; reject it so the
+		// extras task does not retry it on every run.
+		return nil, handleDownloadErrorNative(info, youtubeId, errors.New("video does not pass the configured duration/channel filters"), string(output))
+	}
 	if err != nil {
 		// Check for 429/Too Many Requests in output
 		if strings.Contains(string(output), "429") || strings.Contains(strings.ToLower(string(output)), "too many requests") {
@@ -995,8 +1580,21 @@ func performDownload(info *downloadInfo, youtubeId string) (*ExtraDownloadMetada
 		return nil, handleDownloadErrorNative(info, youtubeId, err, string(output))
 	}
 
+	if GetVerifyDownloads() {
+		cfg, _ := GetYtdlpFlagsConfig()
+		if err := ProbeVideoFile(ffprobeBinary(cfg.FfmpegLocation), info.TempFile); err != nil {
+			// Not rejected: the extras task retries failed downloads.
+			TrailarrLog(ERROR, "YouTube", "Downloaded file for %s failed verification: %v", youtubeId, err)
+			return nil, fmt.Errorf("downloaded file failed verification: %w", err)
+		}
+	}
+
 	// Move file to final location
 	if err := moveDownloadedFile(info); err != nil {
+		if isNotWritableError(err) {
+			// Reject with a readable reason instead of the raw EACCES/EROFS error.
+			return nil, handleDownloadErrorNative(info, youtubeId, fmt.Errorf("media directory not writable: %s", info.OutDir), "")
+		}
 		return nil, err
 	}
 
@@ -1004,6 +1602,17 @@ func performDownload(info *downloadInfo, youtubeId string) (*ExtraDownloadMetada
 	return createSuccessMetadata(info, youtubeId)
 }
 
+// ytDlpProcessLimiter bounds the yt-dlp processes running at once, searches
+// and downloads together (general.maxYtdlpProcesses).
+var ytDlpProcessLimiter = newConcurrencyLimiter()
+
+// runYtDlpDownload runs one yt-dlp download once a process slot is free.
+func runYtDlpDownload(args []string, dir string) ([]byte, error) {
+	ytDlpProcessLimiter.acquire(GetMaxYtdlpProcesses())
+	defer ytDlpProcessLimiter.release()
+	return ytDlpRunner.CombinedOutput(YtDlpPath, args, dir)
+}
+
 // TooManyRequestsError is returned when a 429/Too Many Requests is detected
 type TooManyRequestsError struct {
 	Message string
@@ -1013,6 +1622,95 @@ func (e *TooManyRequestsError) Error() string {
 	return e.Message
 }
 
+// impersonateClients lists the client names yt-dlp accepts for --impersonate.
+var impersonateClients = []string{"chrome", "edge", "safari", "firefox", "tor"}
+
+// ValidateImpersonateTarget checks a yt-dlp impersonate target of the form
+// CLIENT[-VERSION][:OS[-VERSION]] against the known client names.
+func ValidateImpersonateTarget(target string) error {
+	client, _, _ := strings.Cut(strings.ToLower(target), ":")
+	client, _, _ = strings.Cut(client, "-")
+	if slices.Contains(impersonateClients, client) {
+		return nil
+	}
+	return fmt.Errorf("invalid impersonate target %q (known clients: %s)", target, strings.Join(impersonateClients, ", "))
+}
+
+// trailerDurationAllowed reports whether a video of the given length in
+// seconds is within general.minTrailerSeconds/maxTrailerSeconds. Unknown
+// durations (0) are allowed.
+func trailerDurationAllowed(seconds float64) bool {
+	if seconds <= 0 {
+		return true
+	}
+	minSec, maxSec := GetTrailerDurationRange()
+	if minSec > 0 && seconds < float64(minSec) {
+		return false
+	}
+	if maxSec > 0 && seconds > float64(maxSec) {
+		return false
+	}
+	return true
+}
+
+// channelAllowed applies general.blockedChannelIds and
+// general.allowedChannelIds to a result's channel id. Blocked takes
+// precedence; a non-empty allow list rejects every other channel.
+func channelAllowed(channelId string) bool {
+	allowed, blocked := GetChannelFilters()
+	if slices.Contains(blocked, channelId) {
+		return false
+	}
+	return len(allowed) == 0 || slices.Contains(allowed, channelId)
+}
+
+// officialChannelResult reports whether a result's channel is one of
+// general.officialChannels and whether the result is kept: with
+// officialChannelsMode "require" only official results are. An empty list
+// keeps every result as not official.
+func officialChannelResult(channel string) (official, keep bool) {
+	names := GetOfficialChannels()
+	if len(names) == 0 {
+		return false, true
+	}
+	channel = strings.TrimSpace(channel)
+	for _, n := range names {
+		if strings.EqualFold(n, channel) {
+			return true, true
+		}
+	}
+	return false, !GetOfficialChannelsRequired()
+}
+
+// ytDlpMatchFilter returns the yt-dlp --match-filter expression enforcing the
+// configured duration range and channel lists on downloads, or "" when none
+// is set.
+func ytDlpMatchFilter() string {
+	minSec, maxSec := GetTrailerDurationRange()
+	var parts []string
+	if minSec > 0 {
+		parts = append(parts, fmt.Sprintf("duration >=? %d", minSec))
+	}
+	if maxSec > 0 {
+		parts = append(parts, fmt.Sprintf("duration <=? %d", maxSec))
+	}
+	allowed, blocked := GetChannelFilters()
+	for _, id := range blocked {
+		parts = append(parts, fmt.Sprintf("channel_id !=? '%s'", id))
+	}
+	if len(allowed) > 0 {
+		parts = append(parts, fmt.Sprintf("channel_id ~= '^(%s)$'", strings.Join(allowed, "|")))
+	}
+	if names := GetOfficialChannels(); len(names) > 0 && GetOfficialChannelsRequired() {
+		quoted := make([]string, len(names))
+		for i, n := range names {
+			quoted[i] = regexp.QuoteMeta(n)
+		}
+		parts = append(parts, fmt.Sprintf("channel ~= '(?i)^(%s)$'", strings.Join(quoted, "|")))
+	}
+	return strings.Join(parts, " & ")
+}
+
 func isImpersonationErrorNative(output string) bool {
 	return strings.Contains(output, "Impersonate target") ||
 		strings.Contains(output, "is not available") ||
@@ -1023,16 +1721,18 @@ func buildYtDlpArgs(info *downloadInfo, youtubeId string, impersonate bool) []st
 	cfg, _ := GetYtdlpFlagsConfig()
 	args := []string{
 		"--cookies", CookiesFile,
-		"--remux-video", "mkv",
 		"--format", cfg.RequestedFormats,
 		"--output", info.TempFile,
 		"--max-downloads", fmt.Sprintf("%d", cfg.MaxDownloads),
-		"--limit-rate", cfg.LimitRate,
 		"--sleep-interval", fmt.Sprintf("%.0f", cfg.SleepInterval),
 		"--sleep-requests", fmt.Sprintf("%.0f", cfg.SleepRequests),
 		"--max-sleep-interval", fmt.Sprintf("%.0f", cfg.MaxSleepInterval),
 		"--socket-timeout", fmt.Sprintf("%.0f", cfg.Timeout),
 	}
+	if rate := scheduledRate(cfg, time.Now()); rate != "" {
+		args = append(args, "--limit-rate", rate)
+	}
+	args = append(args, postProcessingArgs(cfg)...)
 	if cfg.Quiet {
 		args = append(args, "--quiet")
 	}
@@ -1041,7 +1741,7 @@ func buildYtDlpArgs(info *downloadInfo, youtubeId string, impersonate bool) []st
 	}
 	if cfg.WriteSubs {
 		args = append(args, "--write-subs")
-		args = append(args, "--sub-format", "srt")
+		args = append(args, "--sub-format", subtitleFormat(cfg))
 		if cfg.WriteAutoSubs {
 			args = append(args, "--write-auto-subs")
 		}
@@ -1052,8 +1752,15 @@ func buildYtDlpArgs(info *downloadInfo, youtubeId string, impersonate bool) []st
 			args = append(args, "--sub-langs", cfg.SubLangs)
 		}
 	}
-	if impersonate {
-		args = append(args, "--impersonate", "chrome")
+	if filter := ytDlpMatchFilter(); filter != "" {
+		args = append(args, "--match-filter", filter)
+	}
+	if impersonate && cfg.ImpersonateTarget != "" {
+		if err := ValidateImpersonateTarget(cfg.ImpersonateTarget); err != nil {
+			TrailarrLog(ERROR, "YouTube", "Skipping impersonation: %v", err)
+		} else {
+			args = append(args, "--impersonate", cfg.ImpersonateTarget)
+		}
 	}
 	// Add ffmpeg-location preference: explicit config overrides Trailarr-managed FfmpegPath
 	if cfg.FfmpegLocation != "" {
@@ -1065,10 +1772,202 @@ func buildYtDlpArgs(info *downloadInfo, youtubeId string, impersonate bool) []st
 		}
 	}
 
+	args = append(args, extraYtDlpArgs(cfg)...)
 	args = append(args, "--", youtubeId)
 	return args
 }
 
+// allowedExtraArgs are the yt-dlp options ExtraArgs may set: network,
+// pacing, retry and filtering options. Anything else is rejected, since many
+// yt-dlp options run programs (--exec, --downloader, --ffmpeg-location), pass
+// arguments to them (--postprocessor-args), load code (--plugin-dirs) or read
+// and write arbitrary files (--output, --cookies, --batch-file).
+var allowedExtraArgs = map[string]bool{
+	"--retries":              true,
+	"--fragment-retries":     true,
+	"--extractor-retries":    true,
+	"--file-access-retries":  true,
+	"--retry-sleep":          true,
+	"--socket-timeout":       true,
+	"--source-address":       true,
+	"--force-ipv4":           true,
+	"--force-ipv6":           true,
+	"--proxy":                true,
+	"--geo-bypass":           true,
+	"--no-geo-bypass":        true,
+	"--geo-bypass-country":   true,
+	"--xff":                  true,
+	"--limit-rate":           true,
+	"--throttled-rate":       true,
+	"--concurrent-fragments": true,
+	"--http-chunk-size":      true,
+	"--sleep-interval":       true,
+	"--max-sleep-interval":   true,
+	"--sleep-requests":       true,
+	"--sleep-subtitles":      true,
+	"--min-filesize":         true,
+	"--max-filesize":         true,
+	"--age-limit":            true,
+	"--no-playlist":          true,
+	"--extractor-args":       true,
+	"--user-agent":           true,
+	"--referer":              true,
+	"--add-headers":          true,
+	"--format-sort":          true,
+	"--prefer-free-formats":  true,
+	"--no-warnings":          true,
+	"--verbose":              true,
+}
+
+// ValidateExtraArgs checks every extra argument is one of allowedExtraArgs,
+// with any value attached as "--name=value". Positional arguments such as
+// URLs, short options, the "--" separator and any other option are rejected.
+func ValidateExtraArgs(extra []string) error {
+	for i, arg := range extra {
+		name, _, _ := strings.Cut(arg, "=")
+		switch {
+		case arg == "--":
+			return fmt.Errorf("extraArgs[%d]: the \"--\" separator is not allowed", i)
+		case !strings.HasPrefix(arg, "--"):
+			return fmt.Errorf("extraArgs[%d]: %q must be a long option (--name or --name=value)", i, arg)
+		case !allowedExtraArgs[name]:
+			return fmt.Errorf("extraArgs[%d]: %s is not allowed", i, name)
+		}
+	}
+	return nil
+}
+
+// extraYtDlpArgs returns cfg.ExtraArgs, or none when they fail validation.
+func extraYtDlpArgs(cfg YtdlpFlagsConfig) []string {
+	if err := ValidateExtraArgs(cfg.ExtraArgs); err != nil {
+		TrailarrLog(ERROR, "YouTube", "Ignoring ytdlpFlags.extraArgs: %v", err)
+		return nil
+	}
+	return cfg.ExtraArgs
+}
+
+// ytDlpSearchArgs returns the yt-dlp arguments of a search for searchQuery,
+// with ytdlpFlags.extraArgs before the query.
+func ytDlpSearchArgs(searchQuery string) []string {
+	cfg, _ := GetYtdlpFlagsConfig()
+	args := append([]string{"-j", ytDlpSkipDownload}, extraYtDlpArgs(cfg)...)
+	return append(args, "--", ytDlpSearchPrefix+searchQuery)
+}
+
+// parseClock parses an "HH:MM" time of day into minutes since midnight.
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", v)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ValidateRateSchedule checks every window has valid, distinct start and end
+// times.
+func ValidateRateSchedule(schedule []RateWindow) error {
+	for i, w := range schedule {
+		start, err := parseClock(w.Start)
+		if err != nil {
+			return fmt.Errorf("rateSchedule[%d]: %w", i, err)
+		}
+		end, err := parseClock(w.End)
+		if err != nil {
+			return fmt.Errorf("rateSchedule[%d]: %w", i, err)
+		}
+		if start == end {
+			return fmt.Errorf("rateSchedule[%d]: start and end must differ", i)
+		}
+	}
+	return nil
+}
+
+// scheduledRate returns the rate of the first RateSchedule window containing
+// now, or LimitRate when none does. now is read in its own location, which for
+// time.Now() is the container's local time (set TZ to change it).
+func scheduledRate(cfg YtdlpFlagsConfig, now time.Time) string {
+	if err := ValidateRateSchedule(cfg.RateSchedule); err != nil {
+		TrailarrLog(ERROR, "YouTube", "Using limitRate instead of the schedule: %v", err)
+		return cfg.LimitRate
+	}
+	minute := now.Hour()*60 + now.Minute()
+	for _, w := range cfg.RateSchedule {
+		start, _ := parseClock(w.Start)
+		end, _ := parseClock(w.End)
+		if start < end && minute >= start && minute < end {
+			return w.Rate
+		}
+		if start > end && (minute >= start || minute < end) {
+			return w.Rate
+		}
+	}
+	return cfg.LimitRate
+}
+
+// postProcessingArgs returns the yt-dlp flags converting the download to mkv:
+// a remux, or a re-encode with the ffmpeg hwaccel method passed through
+// --postprocessor-args when the installed ffmpeg supports it.
+func postProcessingArgs(cfg YtdlpFlagsConfig) []string {
+	if err := ValidatePostProcessing(cfg.PostProcessing); err != nil {
+		TrailarrLog(ERROR, "YouTube", "Remuxing instead: %v", err)
+	}
+	if cfg.PostProcessing != PostProcessReencode {
+		return []string{"--remux-video", "mkv"}
+	}
+	args := []string{"--recode-video", "mkv"}
+	if cfg.Hwaccel != "" {
+		if err := checkHwaccel(cfg); err != nil {
+			TrailarrLog(ERROR, "YouTube", "Re-encoding without hwaccel: %v", err)
+		} else {
+			args = append(args, "--postprocessor-args", "VideoConvertor+ffmpeg_i:-hwaccel "+cfg.Hwaccel)
+		}
+	}
+	return args
+}
+
+// DefaultSubFormat is the subtitle format downloaded when ytdlpFlags.subFormat
+// is unset or unusable.
+const DefaultSubFormat = "srt"
+
+// subFormatsEmbeddable lists, per format yt-dlp can download subtitles in,
+// whether it can be embedded in the mkv container extras are remuxed or
+// re-encoded into (see postProcessingArgs).
+var subFormatsEmbeddable = map[string]bool{
+	"srt":  true,
+	"vtt":  true,
+	"ass":  true,
+	"ttml": false,
+	"srv3": false,
+	"lrc":  false,
+}
+
+// ValidateSubFormat checks a ytdlpFlags.subFormat value. Empty means
+// DefaultSubFormat.
+func ValidateSubFormat(format string) error {
+	if _, ok := subFormatsEmbeddable[format]; format == "" || ok {
+		return nil
+	}
+	return fmt.Errorf("invalid subFormat %q (allowed: srt, vtt, ass, ttml, srv3, lrc)", format)
+}
+
+// subtitleFormat returns the --sub-format value for cfg. An invalid format,
+// or one mkv cannot hold while EmbedSubs is on, falls back to
+// DefaultSubFormat with a warning.
+func subtitleFormat(cfg YtdlpFlagsConfig) string {
+	if cfg.SubFormat == "" {
+		return DefaultSubFormat
+	}
+	if err := ValidateSubFormat(cfg.SubFormat); err != nil {
+		TrailarrLog(WARN, "YouTube", "Using %s subtitles: %v", DefaultSubFormat, err)
+		return DefaultSubFormat
+	}
+	if cfg.EmbedSubs && !subFormatsEmbeddable[cfg.SubFormat] {
+		TrailarrLog(WARN, "YouTube", "Using %s subtitles: %s cannot be embedded in mkv", DefaultSubFormat, cfg.SubFormat)
+		return DefaultSubFormat
+	}
+	return cfg.SubFormat
+}
+
 func handleDownloadErrorNative(info *downloadInfo, youtubeId string, err error, output string) error {
 	reason := err.Error()
 	if output != "" {
@@ -1125,8 +2024,15 @@ func moveDownloadedFile(info *downloadInfo) error {
 	return nil
 }
 
+// isNotWritableError reports whether err comes from a permission-denied or
+// read-only filesystem failure.
+func isNotWritableError(err error) bool {
+	return errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS)
+}
+
 func handleCrossDeviceMove(tempFile, outFile string, moveErr error) error {
-	if linkErr, ok := moveErr.(*os.LinkError); ok && strings.Contains(linkErr.Error(), "cross-device link") {
+	// general.tempDir may sit on another filesystem than the media library.
+	if linkErr, ok := moveErr.(*os.LinkError); ok && (errors.Is(linkErr, syscall.EXDEV) || strings.Contains(linkErr.Error(), "cross-device link")) {
 		return copyFileAcrossDevices(tempFile, outFile)
 	}
 	TrailarrLog(ERROR, "YouTube", "Failed to move downloaded file to output dir: %v", moveErr)
@@ -1141,11 +2047,15 @@ func copyFileAcrossDevices(tempFile, outFile string) error {
 	}
 	defer in.Close()
 
-	out, err := os.Create(outFile)
+	// Copy next to the destination first so a failed copy never leaves a
+	// truncated extra in the library.
+	partFile := outFile + ".part"
+	out, err := os.Create(partFile)
 	if err != nil {
 		TrailarrLog(ERROR, "YouTube", "Failed to create output file for copy: %v", err)
 		return fmt.Errorf("failed to create output file for copy: %w", err)
 	}
+	defer os.Remove(partFile)
 	defer out.Close()
 
 	if _, err := io.Copy(out, in); err != nil {
@@ -1157,6 +2067,13 @@ func copyFileAcrossDevices(tempFile, outFile string) error {
 		TrailarrLog(ERROR, "YouTube", "Failed to sync output file: %v", err)
 		return fmt.Errorf("failed to sync output file: %w", err)
 	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to close output file: %w", err)
+	}
+	if err := os.Rename(partFile, outFile); err != nil {
+		TrailarrLog(ERROR, "YouTube", "Failed to rename copied file into place: %v", err)
+		return fmt.Errorf("failed to rename copied file into place: %w", err)
+	}
 
 	if rmErr := os.Remove(tempFile); rmErr != nil {
 		TrailarrLog(WARN, "YouTube", "Failed to remove temp file after copy: %v", rmErr)
@@ -1170,13 +2087,14 @@ func createSuccessMetadata(info *downloadInfo, youtubeId string) (*ExtraDownload
 
 	// Persist the extra entry
 	entry := ExtrasEntry{
-		MediaType:  info.MediaType,
-		MediaId:    info.MediaId,
-		ExtraTitle: info.ExtraTitle,
-		ExtraType:  info.ExtraType,
-		FileName:   info.OutFile,
-		YoutubeId:  youtubeId,
-		Status:     "downloaded",
+		MediaType:    info.MediaType,
+		MediaId:      info.MediaId,
+		ExtraTitle:   info.ExtraTitle,
+		ExtraType:    info.ExtraType,
+		FileName:     info.OutFile,
+		YoutubeId:    youtubeId,
+		Status:       "downloaded",
+		SeasonNumber: info.SeasonNumber,
 	}
 	persistExtraEntry(entry)
 
@@ -1186,25 +2104,18 @@ func createSuccessMetadata(info *downloadInfo, youtubeId string) (*ExtraDownload
 	// Record history and write the metadata file
 	recordDownloadHistory(info)
 	writeMetaFile(meta, info.OutFile)
+	if GetSaveExtraThumbnails() {
+		if thumb, err := SaveExtraThumbnail(youtubeId, info.OutFile); err != nil {
+			TrailarrLog(WARN, "YouTube", "Failed to save thumbnail for %s: %v", youtubeId, err)
+		} else {
+			TrailarrLog(DEBUG, "YouTube", "Saved thumbnail %s", thumb)
+		}
+	}
 
 	TrailarrLog(INFO, "YouTube", "Downloaded %s to %s", info.ExtraTitle, info.OutFile)
 
-	// If this was a TV extra, attempt to refresh the show's metadata in Plex
-	switch info.MediaType {
-	case MediaTypeTV:
-		go func(mediaId int) {
-			if err := RefreshPlexShowMetadata(mediaId); err != nil {
-				TrailarrLog(WARN, "YouTube", "Failed to refresh Plex metadata for mediaId=%d: %v", mediaId, err)
-			}
-		}(info.MediaId)
-	case MediaTypeMovie:
-		// If this was a movie extra, request a movie metadata refresh in Plex
-		go func(mediaId int) {
-			if err := RefreshPlexMovieMetadata(mediaId); err != nil {
-				TrailarrLog(WARN, "YouTube", "Failed to refresh Plex movie metadata for mediaId=%d: %v", mediaId, err)
-			}
-		}(info.MediaId)
-	}
+	// Let the media servers pick up the new extra
+	go refreshMediaServers(MediaRef{Type: info.MediaType, ID: info.MediaId, Path: info.MediaPath})
 	return meta, nil
 }
 
@@ -1238,7 +2149,8 @@ func markMediaNotWantedAndPersistAsync(info *downloadInfo) {
 	}
 }
 
-// recordDownloadHistory appends a download event to the history.
+// recordDownloadHistory appends a download event to the history, with the
+// download duration and the size of info.OutFile.
 func recordDownloadHistory(info *downloadInfo) {
 	mediaTitle := getMediaTitleFromCache(info.MediaType, info.MediaId)
 	if mediaTitle == "" {
@@ -1253,6 +2165,14 @@ func recordDownloadHistory(info *downloadInfo) {
 		ExtraTitle: info.ExtraTitle,
 		Date:       time.Now(),
 	}
+	if !info.StartedAt.IsZero() {
+		event.DurationSeconds = event.Date.Sub(info.StartedAt).Seconds()
+	}
+	if fi, err := os.Stat(info.OutFile); err == nil {
+		event.FileSizeBytes = fi.Size()
+	} else {
+		TrailarrLog(WARN, "YouTube", "Failed to stat %s for history: %v", info.OutFile, err)
+	}
 	_ = AppendHistoryEvent(event)
 }
 
@@ -1273,9 +2193,22 @@ func getMediaTitleFromCache(mediaType MediaType, mediaId int) string {
 	return ""
 }
 
-// writeMetaFile writes the JSON metadata file next to the downloaded file.
+// writeMetaFile writes the metadata sidecars of the downloaded file selected
+// by general.metadataFormat: the JSON .mkv.json, the Kodi .nfo, or both. When
+// only the NFO is written, a .mkv.json left from queueing is removed so it
+// does not keep reporting the extra as queued.
 func writeMetaFile(meta *ExtraDownloadMetadata, outFile string) {
 	metaFile := outFile + ".json"
+	format := GetMetadataFormat()
+	if format == MetadataFormatNFO || format == MetadataFormatBoth {
+		if err := writeExtraNFO(meta, outFile); err != nil {
+			TrailarrLog(WARN, "YouTube", "Failed to write NFO file for %s: %v", outFile, err)
+		}
+	}
+	if format == MetadataFormatNFO {
+		_ = os.Remove(metaFile)
+		return
+	}
 	if metaBytes, err := json.MarshalIndent(meta, "", "  "); err == nil {
 		_ = os.WriteFile(metaFile, metaBytes, 0644)
 	} else {
@@ -1324,6 +2257,121 @@ func YouTubeTrailerSearchHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"items": results})
 }
 
+// youTubeIDPattern matches a bare YouTube video ID.
+var youTubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// extractYouTubeID returns the video ID of a YouTube ID or watch, youtu.be,
+// shorts or embed URL.
+func extractYouTubeID(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if youTubeIDPattern.MatchString(input) {
+		return input, nil
+	}
+	u, err := url.Parse(input)
+	if err == nil && u.Host != "" {
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		id := ""
+		switch {
+		case host == "youtu.be":
+			id = strings.Trim(u.Path, "/")
+		case host == "youtube.com" || host == "m.youtube.com" || host == "music.youtube.com":
+			if v := u.Query().Get("v"); v != "" {
+				id = v
+			} else if parts := strings.Split(strings.Trim(u.Path, "/"), "/"); len(parts) == 2 && (parts[0] == "shorts" || parts[0] == "embed") {
+				id = parts[1]
+			}
+		}
+		if youTubeIDPattern.MatchString(id) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("not a YouTube video ID or URL: %q", input)
+}
+
+// YouTubeProbeHandler handles POST /api/youtube/probe. It resolves a single
+// YouTube ID or URL with `yt-dlp -j --skip-download` and returns its title,
+// duration, uploader and formats so the UI can preview it before queueing.
+func YouTubeProbeHandler(c *gin.Context) {
+	var req struct {
+		YoutubeId string `json:"youtubeId"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.YoutubeId == "" {
+		respondError(c, http.StatusBadRequest, "missing youtubeId (ID or URL)")
+		return
+	}
+	id, err := extractYouTubeID(req.YoutubeId)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	cfg, _ := GetYtdlpFlagsConfig()
+	args := append([]string{"-j", ytDlpSkipDownload, "--no-playlist"}, extraYtDlpArgs(cfg)...)
+	args = append(args, "--", id)
+
+	// Probes share the yt-dlp process slots with searches and downloads, and
+	// a closed connection or the timeout kills the process.
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 45*time.Second)
+	defer cancel()
+	if err := ytDlpProcessLimiter.acquireCtx(ctx, GetMaxYtdlpProcesses()); err != nil {
+		respondError(c, http.StatusServiceUnavailable, "waiting for a yt-dlp slot: "+err.Error())
+		return
+	}
+	defer ytDlpProcessLimiter.release()
+	stdout, cmd, err := ytDlpRunner.StartCommand(ctx, YtDlpPath, args)
+	if err != nil {
+		TrailarrLog(WARN, "YouTube", "Probe failed to start yt-dlp for %s: %v", id, err)
+		respondError(c, http.StatusBadGateway, "yt-dlp could not be started: "+err.Error())
+		return
+	}
+	out, _ := io.ReadAll(stdout)
+	err = cmd.Wait()
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	// Take the first line that decodes as the JSON document.
+	var it ytDlpItem
+	found := false
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] == '{' && json.Unmarshal(line, &it) == nil && it.ID != "" {
+			found = true
+			break
+		}
+	}
+	if !found {
+		msg := strings.TrimSpace(string(out))
+		if err != nil {
+			msg = fmt.Sprintf("%v: %s", err, msg)
+		}
+		TrailarrLog(WARN, "YouTube", "Probe failed for %s: %s", id, msg)
+		respondError(c, http.StatusBadGateway, "yt-dlp could not resolve "+id+": "+msg)
+		return
+	}
+	formats := make([]gin.H, 0, len(it.Formats))
+	for _, f := range it.Formats {
+		formats = append(formats, gin.H{
+			"formatId":   f.FormatID,
+			"ext":        f.Ext,
+			"height":     f.Height,
+			"fps":        f.FPS,
+			"vcodec":     f.VCodec,
+			"acodec":     f.ACodec,
+			"filesize":   f.Filesize,
+			"formatNote": f.FormatNote,
+		})
+	}
+	respondJSON(c, http.StatusOK, gin.H{
+		"youtubeId": it.ID,
+		"title":     it.Title,
+		"duration":  it.Duration,
+		"uploader":  it.Uploader,
+		"channel":   it.Channel,
+		"channelId": it.ChannelID,
+		"thumbnail": it.Thumbnail,
+		"formats":   formats,
+	})
+}
+
 // getTitlesFromCache fetches title and originalTitle for the given media type/id
 func getTitlesFromCache(mediaType MediaType, mediaId int) (string, string, error) {
 	cacheFile, _ := resolveCachePath(mediaType)
@@ -1359,18 +2407,31 @@ func searchYtDlpForTerms(terms []string, maxResults int) ([]gin.H, error) {
 			break
 		}
 		searchQuery := term + " trailer"
-		TrailarrLog(INFO, "YouTube", "yt-dlp command: yt-dlp %v", []string{"-j", ytDlpSearchPrefix + searchQuery, ytDlpSkipDownload})
 		if err := runYtDlpSearch(searchQuery, videoIdSet, &allResults, maxResults); err != nil {
 			TrailarrLog(ERROR, "YouTube", "yt-dlp search error for query '%s': %v", searchQuery, err)
 			// continue searching other terms despite the error
 		}
 	}
+	// Results from general.officialChannels come first, otherwise in
+	// yt-dlp's order.
+	slices.SortStableFunc(allResults, func(a, b gin.H) int {
+		oa, _ := a["official"].(bool)
+		ob, _ := b["official"].(bool)
+		switch {
+		case oa && !ob:
+			return -1
+		case ob && !oa:
+			return 1
+		}
+		return 0
+	})
 	return allResults, nil
 }
 
 // runYtDlpSearch executes yt-dlp for a single searchQuery, appending unique results to results up to maxResults.
 func runYtDlpSearch(searchQuery string, videoIdSet map[string]bool, results *[]gin.H, maxResults int) error {
-	ytDlpArgs := []string{"-j", ytDlpSearchPrefix + searchQuery, ytDlpSkipDownload}
+	ytDlpArgs := ytDlpSearchArgs(searchQuery)
+	TrailarrLog(INFO, "YouTube", "yt-dlp command: yt-dlp %v", ytDlpArgs)
 	if YtDlpTestMode {
 		runYtDlpSearchTestMode(searchQuery, videoIdSet, results, maxResults)
 		return nil
@@ -1381,6 +2442,10 @@ func runYtDlpSearch(searchQuery string, videoIdSet map[string]bool, results *[]g
 func runYtDlpSearchReal(searchQuery string, videoIdSet map[string]bool, results *[]gin.H, maxResults int, ytDlpArgs []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
 	defer cancel()
+	if err := ytDlpProcessLimiter.acquireCtx(ctx, GetMaxYtdlpProcesses()); err != nil {
+		return fmt.Errorf("waiting for a yt-dlp slot: %w", err)
+	}
+	defer ytDlpProcessLimiter.release()
 	stdout, cmd, err := ytDlpRunner.StartCommand(ctx, YtDlpPath, ytDlpArgs)
 	if err != nil {
 		return fmt.Errorf("failed to start yt-dlp via runner: %w", err)
@@ -1428,17 +2493,31 @@ func runYtDlpSearchTestMode(searchQuery string, videoIdSet map[string]bool, resu
 	}
 }
 
+// ytDlpItem is the subset of a `yt-dlp -j` video document Trailarr reads.
+type ytDlpItem struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Thumbnail   string  `json:"thumbnail"`
+	Channel     string  `json:"channel"`
+	ChannelID   string  `json:"channel_id"`
+	Uploader    string  `json:"uploader"`
+	Duration    float64 `json:"duration"`
+	Formats     []struct {
+		FormatID   string  `json:"format_id"`
+		Ext        string  `json:"ext"`
+		Height     int     `json:"height"`
+		FPS        float64 `json:"fps"`
+		VCodec     string  `json:"vcodec"`
+		ACodec     string  `json:"acodec"`
+		Filesize   int64   `json:"filesize"`
+		FormatNote string  `json:"format_note"`
+	} `json:"formats"`
+}
+
 // parseYtDlpLine parses a single yt-dlp JSON line and appends it to results if unique.
 func parseYtDlpLine(line []byte, videoIdSet map[string]bool, results *[]gin.H) {
-	type ytItem struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Thumbnail   string `json:"thumbnail"`
-		Channel     string `json:"channel"`
-		ChannelID   string `json:"channel_id"`
-	}
-	var it ytItem
+	var it ytDlpItem
 	if err := json.Unmarshal(bytes.TrimSpace(line), &it); err != nil {
 		if len(bytes.TrimSpace(line)) > 0 {
 			TrailarrLog(WARN, "YouTube", "Failed to parse yt-dlp output line: %s | error: %v", string(line), err)
@@ -1452,6 +2531,19 @@ func parseYtDlpLine(line []byte, videoIdSet map[string]bool, results *[]gin.H) {
 	if videoIdSet[it.ID] {
 		return
 	}
+	if !trailerDurationAllowed(it.Duration) {
+		TrailarrLog(DEBUG, "YouTube", "Skipping %s: duration %.0fs outside configured range", it.ID, it.Duration)
+		return
+	}
+	if !channelAllowed(it.ChannelID) {
+		TrailarrLog(DEBUG, "YouTube", "Skipping %s: channel %s filtered", it.ID, it.ChannelID)
+		return
+	}
+	official, keep := officialChannelResult(it.Channel)
+	if !keep {
+		TrailarrLog(DEBUG, "YouTube", "Skipping %s: channel %q is not an official channel", it.ID, it.Channel)
+		return
+	}
 	*results = append(*results, gin.H{
 		"id": gin.H{"videoId": it.ID},
 		"snippet": gin.H{
@@ -1463,6 +2555,8 @@ func parseYtDlpLine(line []byte, videoIdSet map[string]bool, results *[]gin.H) {
 			"channelTitle": it.Channel,
 			"channelId":    it.ChannelID,
 		},
+		"duration": it.Duration,
+		"official": official,
 	})
 	videoIdSet[it.ID] = true
 }
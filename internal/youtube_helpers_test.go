@@ -2,29 +2,18 @@ package internal
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func TestSanitizeFileName(t *testing.T) {
-	input := "inva/lid\\name:with*chars?\"<>|"
-	got := sanitizeFileName(input)
-	if got == input {
-		t.Fatalf("sanitizeFileName did not change forbidden chars: %s", got)
-	}
-	// none of the forbidden runes should remain
-	forbidden := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|"}
-	for _, f := range forbidden {
-		if bytes.Contains([]byte(got), []byte(f)) {
-			t.Fatalf("sanitized name still contains forbidden char %q in %q", f, got)
-		}
-	}
-}
-
 func TestDeduplicateByKey(t *testing.T) {
 	list := []map[string]string{
 		{"id": "1", "name": "a"},
@@ -139,3 +128,255 @@ func TestBuildYtDlpArgs_FfmpegLocation(t *testing.T) {
 func TestDefaultRunnerImplementsInterface(t *testing.T) {
 	var _ YtDlpRunner = &DefaultYtDlpRunner{}
 }
+
+func TestBuildYtDlpArgs_ImpersonateTarget(t *testing.T) {
+	CreateTempConfig(t)
+	info := &downloadInfo{TempFile: "tmpfile.mkv"}
+	impersonateArg := func(args []string) string {
+		for i := 0; i < len(args)-1; i++ {
+			if args[i] == "--impersonate" {
+				return args[i+1]
+			}
+		}
+		return ""
+	}
+	if got := impersonateArg(buildYtDlpArgs(info, "ytid", true)); got != "chrome" {
+		t.Fatalf("expected default impersonate target chrome, got %q", got)
+	}
+	for target, want := range map[string]string{"safari-15.5": "safari-15.5", "": "", "chrmoe": ""} {
+		cfg := DefaultYtdlpFlagsConfig()
+		cfg.ImpersonateTarget = target
+		if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+			t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+		}
+		if got := impersonateArg(buildYtDlpArgs(info, "ytid", true)); got != want {
+			t.Fatalf("target %q: expected --impersonate %q, got %q", target, want, got)
+		}
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/ytdlpflags", SaveYtdlpFlagsConfigHandler)
+	if w := DoRequest(r, "POST", "/api/settings/ytdlpflags", []byte(`{"impersonateTarget":"chrmoe"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 for unknown impersonate target, got %d", w.Code)
+	}
+	if w := DoRequest(r, "POST", "/api/settings/ytdlpflags", []byte(`{"impersonateTarget":"chrome-124:macos-14"}`)); w.Code != 200 {
+		t.Fatalf("expected 200 for a versioned target, got %d body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestTrailerDurationRangeFiltersSearchAndDownloads(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["minTrailerSeconds"] = 30
+	general["maxTrailerSeconds"] = 600
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	videoIdSet := map[string]bool{}
+	results := &[]gin.H{}
+	for _, line := range []string{
+		`{"id":"clip","title":"Clip","duration":10}`,
+		`{"id":"trailer","title":"Trailer","duration":150}`,
+		`{"id":"movie","title":"Full Movie","duration":7200}`,
+		`{"id":"unknown","title":"No Duration"}`,
+	} {
+		parseYtDlpLine([]byte(line), videoIdSet, results)
+	}
+	got := map[string]bool{}
+	for _, r := range *results {
+		got[r["id"].(gin.H)["videoId"].(string)] = true
+	}
+	if len(got) != 2 || !got["trailer"] || !got["unknown"] {
+		t.Fatalf("expected only in-range and unknown-duration results, got %v", got)
+	}
+
+	args := buildYtDlpArgs(&downloadInfo{TempFile: "tmpfile.mkv"}, "ytid", false)
+	found := false
+	for i := 0; i < len(args)-1; i++ {
+		if args[i] == "--match-filter" {
+			found = args[i+1] == "duration >=? 30 & duration <=? 600"
+		}
+	}
+	if !found {
+		t.Fatalf("expected duration --match-filter in download args, got %v", args)
+	}
+}
+
+func TestChannelFiltersApplyToSearchAndDownloads(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["allowedChannelIds"] = []string{"UCgood", "UCboth"}
+	general["blockedChannelIds"] = []string{"UCboth", "bad id'"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	videoIdSet := map[string]bool{}
+	results := &[]gin.H{}
+	for _, line := range []string{
+		`{"id":"a","title":"Allowed","channel_id":"UCgood"}`,
+		`{"id":"b","title":"Blocked wins","channel_id":"UCboth"}`,
+		`{"id":"c","title":"Not allowed","channel_id":"UCother"}`,
+	} {
+		parseYtDlpLine([]byte(line), videoIdSet, results)
+	}
+	if len(*results) != 1 || (*results)[0]["id"].(gin.H)["videoId"] != "a" {
+		t.Fatalf("expected only the allowed channel, got %v", *results)
+	}
+
+	want := "channel_id !=? 'UCboth' & channel_id ~= '^(UCgood|UCboth)$'"
+	if got := ytDlpMatchFilter(); got != want {
+		t.Fatalf("expected match filter %q, got %q", want, got)
+	}
+}
+
+type fakeHwaccelRunner struct {
+	DefaultYtDlpRunner
+	calls int
+}
+
+func (f *fakeHwaccelRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	f.calls++
+	return []byte("Hardware acceleration methods:\nvdpau\nvaapi\n\n"), nil
+}
+
+func TestBuildYtDlpArgs_PostProcessingAndHwaccel(t *testing.T) {
+	CreateTempConfig(t)
+	orig := ffmpegRunner
+	runner := &fakeHwaccelRunner{}
+	ffmpegRunner = runner
+	invalidateFfmpegHwaccels()
+	defer func() { ffmpegRunner = orig; invalidateFfmpegHwaccels() }()
+	info := &downloadInfo{TempFile: "tmpfile.mkv"}
+	argValue := func(args []string, flag string) string {
+		for i := 0; i < len(args)-1; i++ {
+			if args[i] == flag {
+				return args[i+1]
+			}
+		}
+		return ""
+	}
+
+	args := buildYtDlpArgs(info, "ytid", false)
+	if argValue(args, "--remux-video") != "mkv" || argValue(args, "--recode-video") != "" {
+		t.Fatalf("expected remux by default, got %v", args)
+	}
+
+	cfg := DefaultYtdlpFlagsConfig()
+	cfg.PostProcessing = PostProcessReencode
+	cfg.Hwaccel = "vaapi"
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	args = buildYtDlpArgs(info, "ytid", false)
+	if argValue(args, "--recode-video") != "mkv" || argValue(args, "--remux-video") != "" {
+		t.Fatalf("expected re-encode, got %v", args)
+	}
+	if got := argValue(args, "--postprocessor-args"); got != "VideoConvertor+ffmpeg_i:-hwaccel vaapi" {
+		t.Fatalf("expected hwaccel postprocessor args, got %q", got)
+	}
+	if h := hwaccelHealthCheck(); h != nil {
+		t.Fatalf("expected no health issue for a supported hwaccel, got %+v", h)
+	}
+
+	cfg.Hwaccel = "cuda"
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	if got := argValue(buildYtDlpArgs(info, "ytid", false), "--postprocessor-args"); got != "" {
+		t.Fatalf("expected unsupported hwaccel to be skipped, got %q", got)
+	}
+	if h := hwaccelHealthCheck(); h == nil {
+		t.Fatalf("expected a health issue for an unsupported hwaccel")
+	}
+	if runner.calls != 1 {
+		t.Fatalf("expected ffmpeg -hwaccels to run once for the same binary, ran %d times", runner.calls)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/ytdlpflags", SaveYtdlpFlagsConfigHandler)
+	if w := DoRequest(r, "POST", "/api/settings/ytdlpflags", []byte(`{"postProcessing":"transcode"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 for unknown postProcessing, got %d", w.Code)
+	}
+}
+
+func TestTooManyRequestsPauseEscalatesAndResets(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["tooManyRequestsPauseSeconds"] = 10
+	general["tooManyRequestsBackoffMultiplier"] = 3
+	general["tooManyRequestsMaxPauseSeconds"] = 60
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	resetTooManyRequestsBackoff()
+	defer resetTooManyRequestsBackoff()
+
+	for i, want := range []time.Duration{10 * time.Second, 30 * time.Second, 60 * time.Second, 60 * time.Second} {
+		if got := nextTooManyRequestsPause(); got != want {
+			t.Fatalf("429 #%d: expected pause %v, got %v", i+1, want, got)
+		}
+	}
+	resetTooManyRequestsBackoff()
+	if got := nextTooManyRequestsPause(); got != 10*time.Second {
+		t.Fatalf("expected base pause after reset, got %v", got)
+	}
+}
+
+// channelSearchRunner answers yt-dlp searches with results from two channels.
+type channelSearchRunner struct{ fakeRunner }
+
+func (f *channelSearchRunner) StartCommand(ctx context.Context, name string, args []string) (io.ReadCloser, *exec.Cmd, error) {
+	lines := `{"id":"fan","title":"Fan edit","channel":"Trailer Fan"}` + "\n" +
+		`{"id":"studio","title":"Official","channel":"Studio Pictures"}` + "\n"
+	return io.NopCloser(bytes.NewBufferString(lines)), &exec.Cmd{}, nil
+}
+
+func TestOfficialChannelsRankAndRequire(t *testing.T) {
+	CreateTempConfig(t)
+	oldRunner := ytDlpRunner
+	ytDlpRunner = &channelSearchRunner{}
+	defer func() { ytDlpRunner = oldRunner }()
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	general := cfg["general"].(map[string]interface{})
+	general["officialChannels"] = []string{"studio pictures", "A.B. Films", "Bad 'Name"}
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	results, _ := searchYtDlpForTerms([]string{"Film"}, 10)
+	if len(results) != 2 || results[0]["id"].(gin.H)["videoId"] != "studio" || results[0]["official"] != true {
+		t.Fatalf("expected the official result ranked first, got %v", results)
+	}
+	if got := ytDlpMatchFilter(); got != "" {
+		t.Fatalf("expected no match filter in prefer mode, got %q", got)
+	}
+
+	general["officialChannelsMode"] = OfficialChannelsRequire
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	results, _ = searchYtDlpForTerms([]string{"Film"}, 10)
+	if len(results) != 1 || results[0]["id"].(gin.H)["videoId"] != "studio" {
+		t.Fatalf("expected only the official result, got %v", results)
+	}
+	if want, got := `channel ~= '(?i)^(studio pictures|A\.B\. Films)$'`, ytDlpMatchFilter(); got != want {
+		t.Fatalf("expected match filter %q, got %q", want, got)
+	}
+}
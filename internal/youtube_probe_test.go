@@ -0,0 +1,94 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+type probeRunner struct {
+	DefaultYtDlpRunner
+	args []string
+}
+
+func (p *probeRunner) StartCommand(ctx context.Context, name string, args []string) (io.ReadCloser, *exec.Cmd, error) {
+	p.args = args
+	out := ""
+	if args[len(args)-1] == "dQw4w9WgXcQ" {
+		out = "[youtube] dQw4w9WgXcQ: Downloading webpage\n" +
+			`{"id":"dQw4w9WgXcQ","title":"Probe Trailer","uploader":"Studio","channel_id":"UC1","duration":142,"formats":[{"format_id":"137","ext":"mp4","height":1080,"vcodec":"avc1"}]}` + "\n"
+	}
+	return io.NopCloser(strings.NewReader(out)), &exec.Cmd{}, nil
+}
+
+func TestYouTubeProbeHandler(t *testing.T) {
+	oldRunner := ytDlpRunner
+	runner := &probeRunner{}
+	ytDlpRunner = runner
+	defer func() { ytDlpRunner = oldRunner }()
+
+	r := NewTestRouter()
+	r.POST("/api/youtube/probe", YouTubeProbeHandler)
+	w := DoRequest(r, "POST", "/api/youtube/probe", []byte(`{"youtubeId":"https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=5"}`))
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		YoutubeId string                   `json:"youtubeId"`
+		Title     string                   `json:"title"`
+		Duration  float64                  `json:"duration"`
+		Uploader  string                   `json:"uploader"`
+		Formats   []map[string]interface{} `json:"formats"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.YoutubeId != "dQw4w9WgXcQ" || resp.Title != "Probe Trailer" || resp.Duration != 142 || resp.Uploader != "Studio" || len(resp.Formats) != 1 || resp.Formats[0]["formatId"] != "137" {
+		t.Fatalf("unexpected probe response: %+v", resp)
+	}
+	if runner.args[0] != "-j" || runner.args[1] != ytDlpSkipDownload {
+		t.Fatalf("expected a -j --skip-download probe, got %v", runner.args)
+	}
+
+	for input, want := range map[string]string{
+		"https://youtu.be/dQw4w9WgXcQ":              "dQw4w9WgXcQ",
+		"https://youtube.com/shorts/dQw4w9WgXcQ":    "dQw4w9WgXcQ",
+		"https://www.youtube.com/embed/abcdefghijk": "abcdefghijk",
+	} {
+		if got, err := extractYouTubeID(input); err != nil || got != want {
+			t.Fatalf("extractYouTubeID(%q) = %q, %v", input, got, err)
+		}
+	}
+	if w := DoRequest(r, "POST", "/api/youtube/probe", []byte(`{"youtubeId":"https://example.com/watch?v=dQw4w9WgXcQ"}`)); w.Code != 400 {
+		t.Fatalf("expected 400 for a non-YouTube URL, got %d", w.Code)
+	}
+	if w := DoRequest(r, "POST", "/api/youtube/probe", []byte(`{"youtubeId":"xxxxxxxxxxx"}`)); w.Code != 502 {
+		t.Fatalf("expected 502 for an unresolvable video, got %d", w.Code)
+	}
+
+	// A probe waits for a yt-dlp process slot and gives up with the request.
+	slots := GetMaxYtdlpProcesses()
+	for i := 0; i < slots; i++ {
+		ytDlpProcessLimiter.acquire(slots)
+	}
+	defer func() {
+		for i := 0; i < slots; i++ {
+			ytDlpProcessLimiter.release()
+		}
+	}()
+	runner.args = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest("POST", "/api/youtube/probe", strings.NewReader(`{"youtubeId":"dQw4w9WgXcQ"}`)).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != 503 || runner.args != nil {
+		t.Fatalf("expected 503 without running yt-dlp while every slot is taken, got %d ran=%v", w.Code, runner.args != nil)
+	}
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestYouTubeThumbnailProxyTimeoutAndNegativeCache(t *testing.T) {
+	CreateTempConfig(t)
+	var missingHits int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vi/thumb-slow/maxresdefault.jpg":
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		case "/vi/thumb-slow/hqdefault.jpg":
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write([]byte("hq-bytes"))
+		default:
+			atomic.AddInt32(&missingHits, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String(), hosts: []string{"i.ytimg.com"}}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["thumbnailFetchTimeoutSeconds"] = 1
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	defer GetStoreClient().Del(context.Background(), YouTubeThumbMissingStoreKey)
+	cacheDir := filepath.Join(MediaCoverPath, "YouTube")
+	for _, id := range []string{"thumb-slow", "thumb-none"} {
+		_ = os.Remove(filepath.Join(cacheDir, id+".jpg"))
+		defer os.Remove(filepath.Join(cacheDir, id+".jpg"))
+	}
+
+	r := NewTestRouter()
+	r.GET("/api/proxy/youtube-image/:youtubeId", ProxyYouTubeImageHandler)
+	start := time.Now()
+	w := DoRequest(r, "GET", "/api/proxy/youtube-image/thumb-slow", nil)
+	if w.Code != 200 || w.Body.String() != "hq-bytes" {
+		t.Fatalf("expected the hq thumbnail after the maxres deadline, got %d body=%q", w.Code, w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("expected the slow URL to be abandoned after its deadline, took %v", elapsed)
+	}
+
+	for i := 0; i < 3; i++ {
+		w := DoRequest(r, "GET", "/api/proxy/youtube-image/thumb-none", nil)
+		if w.Code != 200 || w.Header().Get("X-Proxy-Fallback") != "1" {
+			t.Fatalf("request %d: expected the fallback image, got %d", i, w.Code)
+		}
+	}
+	if n := atomic.LoadInt32(&missingHits); n != 2 {
+		t.Fatalf("expected only the first request to ask YouTube (2 URLs), got %d requests", n)
+	}
+
+	// Markers older than the negative cache TTL are purged by the MediaCover
+	// cleanup; fresh ones are kept.
+	stale, _ := json.Marshal(youTubeThumbMissing{YouTubeID: "thumb-stale", Checked: time.Now().Add(-48 * time.Hour)})
+	if err := GetStoreClient().HSet(context.Background(), YouTubeThumbMissingStoreKey, "thumb-stale", stale); err != nil {
+		t.Fatalf("HSet: %v", err)
+	}
+	if _, err := CleanupMediaCover(0); err != nil {
+		t.Fatalf("CleanupMediaCover: %v", err)
+	}
+	if _, err := GetStoreClient().HGet(context.Background(), YouTubeThumbMissingStoreKey, "thumb-stale"); err == nil {
+		t.Fatalf("expected the stale marker to be purged")
+	}
+	if !isYouTubeThumbMissing("thumb-none") {
+		t.Fatalf("expected the fresh marker to be kept")
+	}
+}
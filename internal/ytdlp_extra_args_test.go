@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestExtraArgsAppendedBeforeSeparatorAndSanitized(t *testing.T) {
+	CreateTempConfig(t)
+	cfg := DefaultYtdlpFlagsConfig()
+	cfg.ExtraArgs = []string{"--retries=10", "--geo-bypass"}
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+
+	args := buildYtDlpArgs(&downloadInfo{TempFile: "tmpfile.mkv"}, "ytid", false)
+	sep := slices.Index(args, "--")
+	if sep < 2 || args[sep-2] != "--retries=10" || args[sep-1] != "--geo-bypass" || args[sep+1] != "ytid" {
+		t.Fatalf("expected extra args right before the separator, got %v", args)
+	}
+	search := ytDlpSearchArgs("film trailer")
+	if !slices.Contains(search, "--geo-bypass") || search[len(search)-2] != "--" || search[len(search)-1] != ytDlpSearchPrefix+"film trailer" {
+		t.Fatalf("expected extra args in the search command, got %v", search)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/ytdlpflags", SaveYtdlpFlagsConfigHandler)
+	for _, extra := range [][]string{
+		{"--exec", "rm -rf /"},
+		{"--exec=touch /tmp/x"},
+		{"--EXEC-BEFORE-DOWNLOAD=id"},
+		{"https://example.com/video"},
+		{"--", "other"},
+		{"-a", "urls.txt"},
+		{"--downloader=/bin/sh"},
+		{"--external-downloader=/bin/sh"},
+		{"--downloader-args=aria2c:--on-download-complete=/bin/sh"},
+		{"--ffmpeg-location=/tmp/evil"},
+		{"--postprocessor-args=-y /etc/passwd"},
+		{"--ppa=ffmpeg:-y /tmp/x"},
+		{"--plugin-dirs=/tmp/plugins"},
+		{"--output=/etc/cron.d/x"},
+		{"--paths=/etc"},
+		{"--print-to-file=id /tmp/x"},
+		{"--cookies=/tmp/cookies.txt"},
+		{"--exe=id"},
+	} {
+		req := DefaultYtdlpFlagsConfig()
+		req.ExtraArgs = extra
+		body, _ := json.Marshal(req)
+		if w := DoRequest(r, "POST", "/api/settings/ytdlpflags", body); w.Code != 400 {
+			t.Fatalf("expected 400 for %v, got %d body=%s", extra, w.Code, w.Body.String())
+		}
+	}
+
+	// A hand-edited config with a blocked option is ignored at build time.
+	cfg.ExtraArgs = []string{"--exec=id"}
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	if args := buildYtDlpArgs(&downloadInfo{TempFile: "tmpfile.mkv"}, "ytid", false); slices.Contains(args, "--exec=id") {
+		t.Fatalf("expected invalid extra args to be dropped, got %v", args)
+	}
+}
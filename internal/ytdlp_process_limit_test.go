@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingRunner records how many yt-dlp downloads run at once.
+type countingRunner struct {
+	inFlight, maxInFlight int32
+}
+
+func (r *countingRunner) StartCommand(ctx context.Context, name string, args []string) (io.ReadCloser, *exec.Cmd, error) {
+	return nil, nil, io.EOF
+}
+
+func (r *countingRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	n := atomic.AddInt32(&r.inFlight, 1)
+	for {
+		m := atomic.LoadInt32(&r.maxInFlight)
+		if n <= m || atomic.CompareAndSwapInt32(&r.maxInFlight, m, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	atomic.AddInt32(&r.inFlight, -1)
+	return nil, nil
+}
+
+func TestYtDlpProcessesBoundedByMaxYtdlpProcesses(t *testing.T) {
+	CreateTempConfig(t)
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["maxYtdlpProcesses"] = 2
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	runner := &countingRunner{}
+	old := ytDlpRunner
+	ytDlpRunner = runner
+	defer func() { ytDlpRunner = old }()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = runYtDlpDownload(nil, "")
+		}()
+	}
+	wg.Wait()
+	if m := atomic.LoadInt32(&runner.maxInFlight); m < 1 || m > 2 {
+		t.Fatalf("expected at most 2 concurrent yt-dlp processes, got %d", m)
+	}
+
+	// A search waiting for a slot gives up when its context ends.
+	ytDlpProcessLimiter.acquire(2)
+	ytDlpProcessLimiter.acquire(2)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := ytDlpProcessLimiter.acquireCtx(ctx, GetMaxYtdlpProcesses()); err == nil {
+		t.Fatalf("expected acquireCtx to fail while every slot is taken")
+	}
+	ytDlpProcessLimiter.release()
+	if err := ytDlpProcessLimiter.acquireCtx(context.Background(), GetMaxYtdlpProcesses()); err != nil {
+		t.Fatalf("expected a freed slot to be acquired, got %v", err)
+	}
+	ytDlpProcessLimiter.release()
+	ytDlpProcessLimiter.release()
+
+	cfg["general"].(map[string]interface{})["maxYtdlpProcesses"] = -1
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if n := GetMaxYtdlpProcesses(); n != DefaultMaxYtdlpProcesses {
+		t.Fatalf("expected the default for an invalid value, got %d", n)
+	}
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestSubFormatPersistedAndFallsBackWhenNotEmbeddable(t *testing.T) {
+	CreateTempConfig(t)
+	subFormatArg := func() string {
+		t.Helper()
+		args := buildYtDlpArgs(&downloadInfo{TempFile: "tmpfile.mkv"}, "ytid", false)
+		i := slices.Index(args, "--sub-format")
+		if i < 0 || i+1 >= len(args) {
+			t.Fatalf("expected --sub-format in %v", args)
+		}
+		return args[i+1]
+	}
+	if got := subFormatArg(); got != DefaultSubFormat {
+		t.Fatalf("expected the default %s, got %s", DefaultSubFormat, got)
+	}
+
+	cfg := DefaultYtdlpFlagsConfig()
+	cfg.SubFormat = "vtt"
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	if saved, _ := GetYtdlpFlagsConfig(); saved.SubFormat != "vtt" {
+		t.Fatalf("expected subFormat persisted, got %q", saved.SubFormat)
+	}
+	if got := subFormatArg(); got != "vtt" {
+		t.Fatalf("expected vtt, got %s", got)
+	}
+
+	// ttml cannot be embedded in mkv, but is fine as a sidecar file.
+	cfg.SubFormat = "ttml"
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	if got := subFormatArg(); got != DefaultSubFormat {
+		t.Fatalf("expected the fallback when embedding, got %s", got)
+	}
+	cfg.EmbedSubs = false
+	if err := SaveYtdlpFlagsConfig(cfg); err != nil {
+		t.Fatalf("SaveYtdlpFlagsConfig: %v", err)
+	}
+	if got := subFormatArg(); got != "ttml" {
+		t.Fatalf("expected ttml without embedding, got %s", got)
+	}
+
+	r := NewTestRouter()
+	r.POST("/api/settings/ytdlpflags", SaveYtdlpFlagsConfigHandler)
+	req := DefaultYtdlpFlagsConfig()
+	req.SubFormat = "docx"
+	body, _ := json.Marshal(req)
+	if w := DoRequest(r, "POST", "/api/settings/ytdlpflags", body); w.Code != 400 {
+		t.Fatalf("expected 400 for an unknown subFormat, got %d body=%s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// installCheckRunner reports the contents of the binary as its version and
+// fails when they are "broken".
+type installCheckRunner struct{ DefaultYtDlpRunner }
+
+func (*installCheckRunner) CombinedOutput(name string, args []string, dir string) ([]byte, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if string(b) == "broken" {
+		return []byte("ImportError: bad build"), errors.New("exit status 1")
+	}
+	return b, nil
+}
+
+func TestInstallYtdlpValidatesAndRollsBack(t *testing.T) {
+	oldRunner := ytDlpRunner
+	ytDlpRunner = &installCheckRunner{}
+	defer func() { ytDlpRunner = oldRunner }()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "yt-dlp")
+	write := func(p, content string) {
+		if err := os.WriteFile(p, []byte(content), 0o755); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+	write(path, "2024.07.01")
+
+	good := filepath.Join(dir, "good")
+	write(good, "2024.08.06")
+	res, err := installYtdlp(good, path)
+	if err != nil || res.Version != "2024.08.06" || res.RolledBack {
+		t.Fatalf("expected a validated install, got %+v err=%v", res, err)
+	}
+	if _, err := os.Stat(path + ".bak"); !os.IsNotExist(err) {
+		t.Fatalf("expected backup removed after a successful install")
+	}
+
+	broken := filepath.Join(dir, "broken")
+	write(broken, "broken")
+	res, err = installYtdlp(broken, path)
+	if err == nil || !res.RolledBack {
+		t.Fatalf("expected a rolled back install, got %+v err=%v", res, err)
+	}
+	if b, _ := os.ReadFile(path); string(b) != "2024.08.06" {
+		t.Fatalf("expected previous binary restored, got %q", b)
+	}
+}
+
+func TestUpdateYtdlpFetchesPinnedRelease(t *testing.T) {
+	CreateTempConfig(t)
+	var requested string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+	oldTransport := http.DefaultTransport
+	http.DefaultTransport = &rewriteTransport{base: oldTransport, target: ts.Listener.Addr().String(), hosts: []string{"api.github.com"}}
+	defer func() { http.DefaultTransport = oldTransport }()
+
+	if _, err := updateYtdlp(); err == nil || strings.Contains(err.Error(), "ytdlpVersion") {
+		t.Fatalf("expected the unpinned 404 error, got %v", err)
+	}
+	if requested != "/repos/yt-dlp/yt-dlp/releases/latest" {
+		t.Fatalf("expected latest release lookup, got %s", requested)
+	}
+
+	cfg, err := readConfigFileRaw()
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	cfg["general"].(map[string]interface{})["ytdlpVersion"] = "2099.01.01"
+	if err := writeConfigFile(cfg); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	_, err = updateYtdlp()
+	if requested != "/repos/yt-dlp/yt-dlp/releases/tags/2099.01.01" {
+		t.Fatalf("expected pinned tag lookup, got %s", requested)
+	}
+	if err == nil || !strings.Contains(err.Error(), `"2099.01.01" not found`) {
+		t.Fatalf("expected a clear error for a missing tag, got %v", err)
+	}
+}
@@ -222,7 +222,12 @@ export default function StatusPage() {
         }
         throw new Error(errTxt);
       }
-      setToastMessage(`${toolName} update triggered`);
+      const body = await res.json().catch(() => ({}));
+      setToastMessage(
+        body.version
+          ? `${toolName} updated to ${body.version}`
+          : `${toolName} update triggered`,
+      );
       setToastSuccess(true);
       await fetchStatus(true);
     } catch (err) {
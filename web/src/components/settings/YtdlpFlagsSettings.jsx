@@ -15,12 +15,32 @@ const YTDLP_FLAGS = [
   { key: "writeautosubs", label: "Write Auto Subs", type: "boolean" },
   { key: "embedsubs", label: "Embed Subs", type: "boolean" },
   { key: "sublangs", label: "Subtitle Languages", type: "string" },
+  {
+    key: "subFormat",
+    label: "Subtitle Format (srt, vtt, ass)",
+    type: "string",
+  },
   { key: "requestedformats", label: "Requested Formats", type: "string" },
   {
     key: "ffmpegLocation",
     label: "ffmpeg Location (optional)",
     type: "string",
   },
+  {
+    key: "impersonateTarget",
+    label: "Impersonate Target (empty to disable)",
+    type: "string",
+  },
+  {
+    key: "postProcessing",
+    label: "Post-processing (remux or reencode)",
+    type: "string",
+  },
+  {
+    key: "hwaccel",
+    label: "ffmpeg Hwaccel for re-encode (empty to disable)",
+    type: "string",
+  },
   { key: "timeout", label: "Timeout (s)", type: "number" },
   { key: "sleepInterval", label: "Sleep Interval (s)", type: "number" },
   { key: "maxDownloads", label: "Max Downloads", type: "number" },
@@ -161,7 +181,8 @@ export default function YtdlpFlagsSettings() {
             const dependentOnWriteSubs =
               key === "writeautosubs" ||
               key === "embedsubs" ||
-              key === "sublangs";
+              key === "sublangs" ||
+              key === "subFormat";
             const disabledDueToWriteSubs =
               dependentOnWriteSubs && ytFlags.writesubs === false;
             return (
@@ -10,6 +10,7 @@ import {
   faBan,
   faCircleXmark,
   faClock,
+  faThumbtack,
 } from "@fortawesome/free-solid-svg-icons";
 import { isDark } from "../../utils/isDark.js";
 
@@ -20,6 +21,7 @@ function areEqual(prevProps, nextProps) {
   const next = nextProps.extra || {};
   if (prev.YoutubeId !== next.YoutubeId) return false;
   if (prev.Status !== next.Status) return false;
+  if (prev.Pinned !== next.Pinned) return false;
   if (prev.reason !== next.reason) return false;
   if (prevProps.rejected !== nextProps.rejected) return false;
   if (prevProps.idx !== nextProps.idx) return false;
@@ -109,6 +111,15 @@ function ExtraCardActions({
           />
         </div>
       )}
+      {/* Pinned marker: the extras task always uses this video for its type */}
+      {extra.Pinned && (
+        <div
+          style={{ position: "absolute", bottom: 8, left: 8, zIndex: 2 }}
+          title="Pinned"
+        >
+          <FontAwesomeIcon icon={faThumbtack} color="#facc15" />
+        </div>
+      )}
       {/* Download or Delete Buttons */}
       {extra.YoutubeId && !downloaded && !imgError && !isFallback && (
         <div style={{ position: "absolute", top: 8, right: 8, zIndex: 2 }}>
@@ -567,6 +578,7 @@ function ExtraCard({
           extraType: baseType,
           extraTitle: baseTitle,
           youtubeId: extra.YoutubeId,
+          seasonNumber: extra.SeasonNumber || 0,
         }),
       });
       if (!res.ok) {